@@ -0,0 +1,117 @@
+package duckdb
+
+import (
+	"database/sql/driver"
+	"encoding/csv"
+	"errors"
+	"io"
+)
+
+// CSVOptions configures Appender.AppendCSV.
+type CSVOptions struct {
+	// Comma is the field delimiter. Zero means ','.
+	Comma rune
+	// Comment is the character starting comment lines. Zero disables comments.
+	Comment rune
+	// Header indicates that the first record contains column names.
+	// AppendCSV matches them to the table's columns, ignoring case, unless the Appender matches column names exactly,
+	// and appends NULL to the other columns.
+	// Without a header, the fields map to the table's columns by their position.
+	Header bool
+	// NullString is the field value to append as NULL. With the default, empty fields are NULL.
+	NullString string
+}
+
+// AppendCSV parses CSV records from r and appends them to the Appender's table.
+// It converts the fields to the table's numeric, BOOLEAN, DECIMAL, HUGEINT, TIMESTAMP, DATE, and TIME columns,
+// like AppendRows, and returns the number of appended rows.
+// It flushes the Appender periodically and after appending the last row.
+func (a *Appender) AppendCSV(r io.Reader, opts CSVOptions) (int64, error) {
+	if a.closed {
+		return 0, getError(errAppenderAppendAfterClose, nil)
+	}
+
+	reader := csv.NewReader(r)
+	if opts.Comma != 0 {
+		reader.Comma = opts.Comma
+	}
+	reader.Comment = opts.Comment
+	reader.ReuseRecord = true
+
+	var columnMapping ColumnMapping
+	if opts.Header {
+		header, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return 0, nil
+		}
+		if err != nil {
+			return 0, getError(errAppenderAppendRow, err)
+		}
+		if columnMapping, err = a.csvColumnMapping(header); err != nil {
+			return 0, getError(errAppenderAppendRow, err)
+		}
+	}
+
+	row := make([]driver.Value, len(a.types))
+	var count int64
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return count, getError(errAppenderAppendRow, err)
+		}
+
+		if columnMapping == nil && len(record) != len(a.types) {
+			return count, getError(errAppenderAppendRow, columnCountError(len(record), len(a.types)))
+		}
+		for i := range row {
+			srcIdx := i
+			if columnMapping != nil {
+				srcIdx = columnMapping[i]
+			}
+			row[i] = nil
+			if srcIdx != -1 && record[srcIdx] != opts.NullString {
+				row[i] = coerceValue(a.types[i], record[srcIdx])
+			}
+		}
+		if err = a.AppendRow(row...); err != nil {
+			return count, err
+		}
+		count++
+
+		if a.bufferedRows() >= appendRowsFlushChunks*GetDataChunkCapacity() {
+			if err = a.Flush(); err != nil {
+				return count, err
+			}
+		}
+	}
+
+	return count, a.Flush()
+}
+
+// csvColumnMapping maps the table's columns to the fields of a CSV header.
+func (a *Appender) csvColumnMapping(header []string) (ColumnMapping, error) {
+	if a.columnIndexes == nil {
+		if err := a.loadColumnNames(); err != nil {
+			return nil, err
+		}
+	}
+
+	columnMapping := make(ColumnMapping, len(a.types))
+	for i := range columnMapping {
+		columnMapping[i] = -1
+	}
+	for srcIdx, name := range header {
+		idx, ok := a.columnIndex(name)
+		if !ok {
+			return nil, invalidInputError(name, "column name")
+		}
+		if columnMapping[idx] != -1 {
+			return nil, duplicateNameError(name)
+		}
+		columnMapping[idx] = srcIdx
+	}
+	return columnMapping, nil
+}
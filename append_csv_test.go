@@ -0,0 +1,72 @@
+package duckdb
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppendCSV(t *testing.T) {
+	c, db, conn, a := prepareAppender(t, `CREATE TABLE test (id INTEGER, name VARCHAR, price DECIMAL(10, 2), ok BOOLEAN, ts TIMESTAMP)`)
+	defer cleanupAppender(t, c, db, conn, a)
+
+	// Map the fields by their position.
+	var b strings.Builder
+	for i := 0; i < 5000; i++ {
+		b.WriteString("1,\"name, quoted\",12.34,true,2024-01-02 03:04:05\n")
+	}
+	count, err := a.AppendCSV(strings.NewReader(b.String()), CSVOptions{})
+	require.NoError(t, err)
+	require.Equal(t, int64(5000), count)
+
+	var (
+		name  string
+		price Decimal
+		ok    bool
+		ts    time.Time
+	)
+	require.NoError(t, db.QueryRow(`SELECT name, price, ok, ts FROM test LIMIT 1`).Scan(&name, &price, &ok, &ts))
+	require.Equal(t, "name, quoted", name)
+	require.Equal(t, 12.34, price.Float64())
+	require.True(t, ok)
+	require.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), ts)
+
+	// Map the fields by the header, with custom delimiters and NULL values.
+	csv := "# comment\nOK;ID;name\nfalse;2;NA\nNA;3;c\n"
+	count, err = a.AppendCSV(strings.NewReader(csv), CSVOptions{Comma: ';', Comment: '#', Header: true, NullString: "NA"})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+
+	res, err := db.Query(`SELECT id, name, price, ok FROM test WHERE id > 1 ORDER BY id`)
+	require.NoError(t, err)
+	defer closeRowsWrapper(t, res)
+
+	var rows [][4]any
+	for res.Next() {
+		var r [4]any
+		require.NoError(t, res.Scan(&r[0], &r[1], &r[2], &r[3]))
+		rows = append(rows, r)
+	}
+	require.NoError(t, res.Err())
+	require.Equal(t, [][4]any{
+		{int32(2), nil, nil, false},
+		{int32(3), "c", nil, nil},
+	}, rows)
+
+	// An empty input with a header appends nothing.
+	count, err = a.AppendCSV(strings.NewReader(""), CSVOptions{Header: true})
+	require.NoError(t, err)
+	require.Equal(t, int64(0), count)
+
+	// Invalid inputs.
+	_, err = a.AppendCSV(strings.NewReader("unknown\n1\n"), CSVOptions{Header: true})
+	testError(t, err, errAppenderAppendRow.Error(), invalidInputErrMsg)
+	_, err = a.AppendCSV(strings.NewReader("id,ID\n1,1\n"), CSVOptions{Header: true})
+	testError(t, err, errAppenderAppendRow.Error(), duplicateNameErrMsg)
+	_, err = a.AppendCSV(strings.NewReader("1,a\n"), CSVOptions{})
+	testError(t, err, errAppenderAppendRow.Error(), columnCountErrMsg)
+	_, err = a.AppendCSV(strings.NewReader("x,a,1,true,2024-01-02\n"), CSVOptions{})
+	testError(t, err, errAppenderAppendRow.Error(), castErrMsg)
+}
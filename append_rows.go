@@ -0,0 +1,161 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/marcboeker/go-duckdb/mapping"
+)
+
+// ColumnMapping maps the columns of a source result to the columns of an Appender's table.
+// The i-th entry is the index of the source column appended to the i-th column of the table,
+// or -1 to append NULL. A nil ColumnMapping maps the columns by their position.
+type ColumnMapping []int
+
+// appendRowsFlushChunks is the number of full data chunks after which AppendRows flushes the Appender.
+const appendRowsFlushChunks = 16
+
+// timeLayouts are the layouts to parse string values appended to TIMESTAMP, DATE, and TIME columns.
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	time.DateOnly,
+	"15:04:05.999999999",
+}
+
+// AppendRows appends all rows of a database/sql result to the Appender, and returns the number of appended rows.
+// The result can stem from any database/sql driver, including other DuckDB connections.
+// AppendRows converts string and []byte values to the table's numeric, BOOLEAN, DECIMAL, HUGEINT,
+// TIMESTAMP, DATE, and TIME columns. It flushes the Appender periodically and after appending the last row.
+// It does not close the rows.
+func AppendRows(ctx context.Context, a *Appender, rows *sql.Rows, columnMapping ColumnMapping) (int64, error) {
+	if a.closed {
+		return 0, getError(errAppenderAppendAfterClose, nil)
+	}
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, getError(errAppenderAppendRow, err)
+	}
+	if columnMapping == nil {
+		if len(columns) != len(a.types) {
+			return 0, getError(errAppenderAppendRow, columnCountError(len(columns), len(a.types)))
+		}
+		columnMapping = make(ColumnMapping, len(a.types))
+		for i := range columnMapping {
+			columnMapping[i] = i
+		}
+	}
+	if len(columnMapping) != len(a.types) {
+		return 0, getError(errAppenderAppendRow, columnCountError(len(columnMapping), len(a.types)))
+	}
+	for _, srcIdx := range columnMapping {
+		if srcIdx < -1 || srcIdx >= len(columns) {
+			return 0, getError(errAppenderAppendRow, invalidInputError(strconv.Itoa(srcIdx), fmt.Sprintf("source column index in [-1, %d)", len(columns))))
+		}
+	}
+
+	src := make([]any, len(columns))
+	srcPtrs := make([]any, len(columns))
+	for i := range src {
+		srcPtrs[i] = &src[i]
+	}
+	row := make([]driver.Value, len(a.types))
+
+	var count int64
+	for rows.Next() {
+		if err = ctx.Err(); err != nil {
+			return count, err
+		}
+		if err = rows.Scan(srcPtrs...); err != nil {
+			return count, getError(errAppenderAppendRow, err)
+		}
+
+		for i, srcIdx := range columnMapping {
+			row[i] = nil
+			if srcIdx != -1 {
+				row[i] = coerceValue(a.types[i], src[srcIdx])
+			}
+		}
+		if err = a.AppendRow(row...); err != nil {
+			return count, err
+		}
+		count++
+
+		if a.bufferedRows() >= appendRowsFlushChunks*GetDataChunkCapacity() {
+			if err = a.Flush(); err != nil {
+				return count, err
+			}
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return count, getError(errAppenderAppendRow, err)
+	}
+
+	return count, a.Flush()
+}
+
+// coerceValue converts a string, []byte, or integer value to the Go type of a column, if possible.
+// Otherwise, it returns the value unchanged.
+func coerceValue(logicalType mapping.LogicalType, val any) any {
+	t := Type(mapping.GetTypeId(logicalType))
+
+	var str string
+	switch v := val.(type) {
+	case string:
+		str = v
+	case []byte:
+		str = string(v)
+	case int64:
+		// Some drivers return BOOLEAN values as integers.
+		if t == TYPE_BOOLEAN {
+			return v != 0
+		}
+		return val
+	default:
+		return val
+	}
+
+	switch t {
+	case TYPE_VARCHAR, TYPE_BLOB, TYPE_ENUM:
+		return val
+	case TYPE_BOOLEAN:
+		if b, err := strconv.ParseBool(str); err == nil {
+			return b
+		}
+	case TYPE_TINYINT, TYPE_SMALLINT, TYPE_INTEGER, TYPE_BIGINT:
+		if i, err := strconv.ParseInt(str, 10, 64); err == nil {
+			return i
+		}
+	case TYPE_UTINYINT, TYPE_USMALLINT, TYPE_UINTEGER, TYPE_UBIGINT:
+		if u, err := strconv.ParseUint(str, 10, 64); err == nil {
+			return u
+		}
+	case TYPE_FLOAT, TYPE_DOUBLE:
+		if f, err := strconv.ParseFloat(str, 64); err == nil {
+			return f
+		}
+	case TYPE_HUGEINT:
+		if i, ok := new(big.Int).SetString(str, 10); ok {
+			return i
+		}
+	case TYPE_DECIMAL:
+		// The appender parses and scales decimal strings.
+		return str
+	case TYPE_TIMESTAMP, TYPE_TIMESTAMP_S, TYPE_TIMESTAMP_MS, TYPE_TIMESTAMP_NS, TYPE_TIMESTAMP_TZ,
+		TYPE_DATE, TYPE_TIME, TYPE_TIME_TZ:
+		for _, layout := range timeLayouts {
+			if ti, err := time.Parse(layout, str); err == nil {
+				return ti
+			}
+		}
+	}
+	return val
+}
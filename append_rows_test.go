@@ -0,0 +1,79 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppendRows(t *testing.T) {
+	c, db, conn, a := prepareAppender(t, `CREATE TABLE test (id INTEGER, name VARCHAR, price DECIMAL(10, 2), ok BOOLEAN, ts TIMESTAMP, big HUGEINT)`)
+	defer cleanupAppender(t, c, db, conn, a)
+
+	// Read the rows from another database.
+	src := openDbWrapper(t, ``)
+	defer closeDbWrapper(t, src)
+
+	ctx := context.Background()
+	rows, err := src.QueryContext(ctx, `SELECT range::INTEGER, 'name_' || range, '12.34', range % 2, '2024-01-02 03:04:05', '170141183460469231731687303715884105727'
+		FROM range(5000)`)
+	require.NoError(t, err)
+	count, err := AppendRows(ctx, a, rows, nil)
+	closeRowsWrapper(t, rows)
+	require.NoError(t, err)
+	require.Equal(t, int64(5000), count)
+
+	var (
+		id    int32
+		name  string
+		price Decimal
+		ok    bool
+		ts    time.Time
+		h     *big.Int
+		total int64
+	)
+	row := db.QueryRow(`SELECT *, count(*) OVER () FROM test WHERE id = 4001`)
+	require.NoError(t, row.Scan(&id, &name, &price, &ok, &ts, &h, &total))
+	require.Equal(t, int32(4001), id)
+	require.Equal(t, "name_4001", name)
+	require.Equal(t, 12.34, price.Float64())
+	require.True(t, ok)
+	require.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), ts)
+	require.Equal(t, "170141183460469231731687303715884105727", h.String())
+	require.Equal(t, int64(1), total)
+	require.NoError(t, db.QueryRow(`SELECT count(*) FROM test`).Scan(&total))
+	require.Equal(t, int64(5000), total)
+
+	// Map the source columns to the table columns.
+	rows, err = src.QueryContext(ctx, `SELECT 'mapped', 5042`)
+	require.NoError(t, err)
+	count, err = AppendRows(ctx, a, rows, ColumnMapping{1, 0, -1, -1, -1, -1})
+	closeRowsWrapper(t, rows)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+
+	var nullOK sql.NullBool
+	require.NoError(t, db.QueryRow(`SELECT name, ok FROM test WHERE id = 5042`).Scan(&name, &nullOK))
+	require.Equal(t, "mapped", name)
+	require.False(t, nullOK.Valid)
+
+	// Invalid mappings.
+	rows, err = src.QueryContext(ctx, `SELECT 1`)
+	require.NoError(t, err)
+	defer closeRowsWrapper(t, rows)
+	_, err = AppendRows(ctx, a, rows, nil)
+	testError(t, err, errAppenderAppendRow.Error(), columnCountErrMsg)
+	_, err = AppendRows(ctx, a, rows, ColumnMapping{0, 1, -1, -1, -1, -1})
+	testError(t, err, errAppenderAppendRow.Error(), invalidInputErrMsg)
+
+	// Values that cannot be converted fail.
+	rows, err = src.QueryContext(ctx, `SELECT 'x', 'y', 'z', 'maybe', 'never', 'huge'`)
+	require.NoError(t, err)
+	defer closeRowsWrapper(t, rows)
+	_, err = AppendRows(ctx, a, rows, nil)
+	testError(t, err, errAppenderAppendRow.Error(), castErrMsg)
+}
@@ -1,8 +1,15 @@
 package duckdb
 
 import (
+	"context"
 	"database/sql/driver"
 	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/marcboeker/go-duckdb/mapping"
 )
@@ -10,17 +17,140 @@ import (
 // Appender holds the DuckDB appender. It allows efficient bulk loading into a DuckDB database.
 type Appender struct {
 	conn     *Conn
+	catalog  string
 	schema   string
 	table    string
 	appender mapping.Appender
 	closed   bool
 
-	// The appender storage before flushing any data.
-	chunks []DataChunk
+	// The data chunk receiving the appended values, or nil, if no row was appended since handing off the last chunk.
+	// The appender hands full chunks to DuckDB immediately.
+	chunk *DataChunk
+	// A reset data chunk to reuse for the next rows, avoiding creating and destroying a chunk each time.
+	spare *DataChunk
 	// The column types of the table to append to.
 	types []mapping.LogicalType
-	// The number of appended rows.
+	// The number of rows in the current data chunk.
 	rowCount int
+	// The number of data chunks, their rows, and their estimated size handed to DuckDB since the last flush.
+	chunkCount    int
+	chunkRows     int
+	bufferedBytes int64
+	// The policies deciding when the appender flushes automatically, and the time of the last flush.
+	flushPolicies []FlushPolicy
+	lastFlush     time.Time
+	// The statistics of the appender, and the callback invoked after each flush.
+	stats         appenderCounters
+	flushCallback func(AppenderStats, error)
+	// The column indexes by their lower-case names, or their names, if the appender matches names exactly.
+	// Loaded by the first call to AppendRowMap.
+	columnIndexes    map[string]int
+	exactColumnNames bool
+	// The default values by column index, set with SetDefault.
+	defaults map[int]driver.Value
+
+	// The columns to append to, if the appender does not append to all columns of the table.
+	columns []string
+
+	// The conflict handling of the appender.
+	conflictMode ConflictMode
+	// The setters writing values of the columns' exact Go types, if the appender trusts the types of the values.
+	// A nil setter falls back to the column's regular setter.
+	trustedSetters []fnSetVectorValue
+	trusted        bool
+	// Whether the appender collects rejected rows instead of failing, and the rows rejected since the last call to RejectedRows.
+	collectRejected bool
+	rejected        []RejectedRow
+	// The qualified names of the target and the staging table, if the appender emulates a conflict mode,
+	// collects rejected rows, or appends to GEOMETRY columns.
+	target  string
+	staging string
+	// The unquoted name of the staging table in the temp catalog.
+	stagingTable string
+	// The names of the GEOMETRY columns, which the staging table stores as BLOB.
+	geometry []string
+	// Whether the appender recovers from failed flushes, and the data chunks handed to DuckDB since the last flush.
+	recovery bool
+	retained []*DataChunk
+}
+
+// ConflictMode defines how an Appender handles rows violating a PRIMARY KEY or UNIQUE constraint.
+type ConflictMode int
+
+const (
+	// ConflictError fails the flush. This is the default.
+	ConflictError ConflictMode = iota
+	// ConflictReplace replaces the existing rows, like INSERT OR REPLACE.
+	ConflictReplace
+	// ConflictIgnore skips the conflicting rows, like INSERT OR IGNORE.
+	ConflictIgnore
+)
+
+// AppenderOption configures an Appender.
+type AppenderOption func(a *Appender)
+
+// WithConflictMode sets the conflict handling of an Appender.
+// For ConflictReplace and ConflictIgnore, the Appender appends the rows to a temporary staging table,
+// and inserts them into the target table with INSERT OR REPLACE or INSERT OR IGNORE when flushing.
+// If inserting the staged rows fails, e.g., due to a NOT NULL constraint, Flush returns the error and keeps the rows,
+// so that the next Flush or Close retries inserting them.
+func WithConflictMode(mode ConflictMode) AppenderOption {
+	return func(a *Appender) {
+		a.conflictMode = mode
+	}
+}
+
+// OnConflictReplace replaces existing rows with appended rows of the same key, see WithConflictMode.
+func OnConflictReplace() AppenderOption {
+	return WithConflictMode(ConflictReplace)
+}
+
+// OnConflictIgnore skips appended rows whose key already exists, see WithConflictMode.
+func OnConflictIgnore() AppenderOption {
+	return WithConflictMode(ConflictIgnore)
+}
+
+// WithColumns binds an Appender to a subset of the table's columns, matched by name, ignoring case.
+// The appender expects the values of these columns in the given order, and fills the omitted columns
+// with their DEFAULT value, or NULL, if they have no DEFAULT value.
+func WithColumns(names ...string) AppenderOption {
+	return func(a *Appender) {
+		a.columns = names
+	}
+}
+
+// WithTrustedTypes configures an Appender to trust that the values of AppendRow have the exact Go types
+// of their columns, e.g., int64 for BIGINT and string for VARCHAR columns. The Appender then writes these values
+// without conversion, and skips resolving pointers, sql.Null values, driver.Valuer implementations, and
+// time.Duration values. Values of other types still go through the regular conversion.
+func WithTrustedTypes() AppenderOption {
+	return func(a *Appender) {
+		a.trusted = true
+	}
+}
+
+// WithExactColumnNames configures an Appender to match column names exactly, instead of ignoring case,
+// when appending rows as maps, setting default values, and matching the header of a CSV input.
+func WithExactColumnNames() AppenderOption {
+	return func(a *Appender) {
+		a.exactColumnNames = true
+	}
+}
+
+// WithMaxBufferedBytes sets the estimated size of the appended rows after which the Appender flushes
+// them automatically. The estimate includes the data chunks and the length of VARCHAR and BLOB values.
+// Zero or a negative value disables automatic flushing, i.e., the Appender buffers all rows until flushing.
+// It is equivalent to WithFlushPolicy(FlushAfterBytes(n)).
+func WithMaxBufferedBytes(n int64) AppenderOption {
+	return WithFlushPolicy(FlushAfterBytes(n))
+}
+
+// WithFlushPolicy adds a policy deciding when the Appender flushes automatically.
+// The Appender consults its policies after appending rows, and flushes if any policy says so.
+func WithFlushPolicy(policy FlushPolicy) AppenderOption {
+	return func(a *Appender) {
+		a.flushPolicies = append(a.flushPolicies, policy)
+	}
 }
 
 // NewAppenderFromConn returns a new Appender for the default catalog from a DuckDB driver connection.
@@ -30,6 +160,13 @@ func NewAppenderFromConn(driverConn driver.Conn, schema, table string) (*Appende
 
 // NewAppender returns a new Appender from a DuckDB driver connection.
 func NewAppender(driverConn driver.Conn, catalog, schema, table string) (*Appender, error) {
+	return NewAppenderWithOptions(driverConn, catalog, schema, table)
+}
+
+// NewAppenderWithOptions returns a new Appender from a DuckDB driver connection, configured by the options.
+// The Appender accepts WKB ([]byte) and WKT (string) values for the GEOMETRY columns of the spatial extension.
+// It appends these values to a temporary staging table, and converts them when flushing.
+func NewAppenderWithOptions(driverConn driver.Conn, catalog, schema, table string, opts ...AppenderOption) (*Appender, error) {
 	conn, ok := driverConn.(*Conn)
 	if !ok {
 		return nil, getError(errInvalidCon, nil)
@@ -37,27 +174,78 @@ func NewAppender(driverConn driver.Conn, catalog, schema, table string) (*Append
 	if conn.closed {
 		return nil, getError(errClosedCon, nil)
 	}
+	if conn.readOnly {
+		return nil, getError(errAppenderCreation, errReadOnlyConn)
+	}
 
-	var appender mapping.Appender
-	state := mapping.AppenderCreateExt(conn.conn, catalog, schema, table, &appender)
-	if state == mapping.StateError {
-		err := getDuckDBError(mapping.AppenderError(appender))
-		mapping.AppenderDestroy(&appender)
+	a := &Appender{
+		conn:      conn,
+		catalog:   catalog,
+		schema:    schema,
+		table:     table,
+		lastFlush: time.Now(),
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	if len(a.columns) != 0 {
+		if err := a.resolveColumns(); err != nil {
+			return nil, getError(errAppenderCreation, err)
+		}
+	}
+
+	if err := a.open(); err != nil {
 		return nil, getError(errAppenderCreation, err)
 	}
 
-	a := &Appender{
-		conn:     conn,
-		schema:   schema,
-		table:    table,
-		appender: appender,
-		rowCount: 0,
+	// The appender cannot write GEOMETRY values of the spatial extension directly.
+	// Instead, it appends WKB or WKT values to a staging table, and converts them when flushing.
+	if geometry, err := a.geometryColumns(); err != nil || len(geometry) != 0 {
+		err = errors.Join(err, a.destroy())
+		if err == nil {
+			a.geometry = geometry
+			err = a.open()
+		}
+		if err != nil {
+			return nil, getError(errAppenderCreation, err)
+		}
+	}
+
+	if a.trusted {
+		a.trustedSetters = make([]fnSetVectorValue, len(a.types))
+		for i, t := range a.types {
+			a.trustedSetters[i] = trustedSetter(t)
+		}
+	}
+
+	return a, nil
+}
+
+// open creates the DuckDB appender, and the staging table, if the appender needs one.
+func (a *Appender) open() error {
+	if a.conflictMode != ConflictError || a.collectRejected || len(a.geometry) != 0 {
+		// Append to a staging table with the columns of the target table.
+		staging := stagingTableName("duckdb_appender")
+		a.target = QuoteQualified(a.catalog, a.schema, a.table)
+		a.staging = QuoteQualified("temp", "main", staging)
+		columns := a.selectList(func(column string) string { return "CAST(" + column + " AS BLOB)" })
+		query := fmt.Sprintf(`CREATE TEMP TABLE %s AS SELECT %s FROM %s LIMIT 0`, QuoteIdentifier(staging), columns, a.target)
+		if _, err := a.conn.ExecContext(context.Background(), query, nil); err != nil {
+			a.staging = ""
+			return err
+		}
+		a.stagingTable = staging
+	}
+
+	if err := a.createAppender(); err != nil {
+		return errors.Join(err, a.dropStaging())
 	}
 
 	// Get the column types.
-	columnCount := mapping.AppenderColumnCount(appender)
+	columnCount := mapping.AppenderColumnCount(a.appender)
 	for i := mapping.IdxT(0); i < columnCount; i++ {
-		colType := mapping.AppenderColumnType(appender, i)
+		colType := mapping.AppenderColumnType(a.appender, i)
 		a.types = append(a.types, colType)
 
 		// Ensure that we only create an appender for supported column types.
@@ -65,26 +253,99 @@ func NewAppender(driverConn driver.Conn, catalog, schema, table string) (*Append
 		name, found := unsupportedTypeToStringMap[t]
 		if found {
 			err := addIndexToError(unsupportedTypeError(name), int(i)+1)
-			destroyTypeSlice(a.types)
-			mapping.AppenderDestroy(&appender)
-			return nil, getError(errAppenderCreation, err)
+			return errors.Join(err, a.destroy())
 		}
 	}
 
-	return a, nil
+	return nil
+}
+
+// createAppender creates the DuckDB appender for the target or the staging table.
+func (a *Appender) createAppender() error {
+	catalog, schema, table := a.catalog, a.schema, a.table
+	if a.staging != "" {
+		catalog, schema, table = "temp", "main", a.stagingTable
+	}
+
+	var appender mapping.Appender
+	state := mapping.AppenderCreateExt(a.conn.conn, catalog, schema, table, &appender)
+	if state == mapping.StateError {
+		err := a.conn.getDuckDBError(mapping.AppenderError(appender))
+		mapping.AppenderDestroy(&appender)
+		return err
+	}
+
+	// Bind the appender to the columns. The staging table only contains these columns.
+	if a.staging == "" {
+		for _, name := range a.columns {
+			if mapping.AppenderAddColumn(appender, name) == mapping.StateError {
+				err := a.conn.getDuckDBError(mapping.AppenderError(appender))
+				mapping.AppenderDestroy(&appender)
+				return err
+			}
+		}
+	}
+
+	a.appender = appender
+	return nil
+}
+
+// destroy destroys the DuckDB appender and the column types, and drops the staging table.
+func (a *Appender) destroy() error {
+	destroyTypeSlice(a.types)
+	a.types = nil
+	mapping.AppenderDestroy(&a.appender)
+	err := a.dropStaging()
+	a.staging = ""
+	a.stagingTable = ""
+	return err
 }
 
 // Flush the data chunks to the underlying table and clear the internal cache.
 // Does not close the appender, even if it returns an error. Unless you have a good reason to call this,
 // call Close when you are done with the appender.
 func (a *Appender) Flush() error {
-	if err := a.appendDataChunks(); err != nil {
-		return getError(errAppenderFlush, invalidatedAppenderError(err))
+	return a.FlushContext(context.Background())
+}
+
+// FlushContext is like Flush, but interrupts the flush when the context is done, and returns the context's error.
+// If the context is done before flushing, the appender keeps its rows. An interrupted flush invalidates the appender.
+func (a *Appender) FlushContext(ctx context.Context) error {
+	start := time.Now()
+	var err error
+	if err = ctx.Err(); err == nil {
+		err = a.flush(ctx)
+		if err != nil && ctx.Err() != nil {
+			err = ctx.Err()
+		}
 	}
-	if mapping.AppenderFlush(a.appender) == mapping.StateError {
-		err := getDuckDBError(mapping.AppenderError(a.appender))
+	a.recordFlush(start, err)
+	return err
+}
+
+func (a *Appender) flush(ctx context.Context) error {
+	stop := a.conn.interruptOnDone(ctx)
+	defer stop()
+
+	err := a.appendDataChunks()
+	if err == nil && mapping.AppenderFlush(a.appender) == mapping.StateError {
+		err = a.conn.getDuckDBError(mapping.AppenderError(a.appender))
+	}
+	if err != nil {
+		if a.recovery && ctx.Err() == nil {
+			if errRecover := a.recover(); errRecover != nil {
+				err = errors.Join(err, invalidatedAppenderError(errRecover))
+			}
+			return getError(errAppenderFlush, err)
+		}
 		return getError(errAppenderFlush, invalidatedAppenderError(err))
 	}
+	a.releaseRetained()
+
+	if err := a.insertStaged(ctx); err != nil {
+		return getError(errAppenderFlush, err)
+	}
+	a.lastFlush = time.Now()
 
 	return nil
 }
@@ -92,55 +353,273 @@ func (a *Appender) Flush() error {
 // Close the appender. This will flush the appender to the underlying table.
 // It is vital to call this when you are done with the appender to avoid leaking memory.
 func (a *Appender) Close() error {
+	return a.CloseContext(context.Background())
+}
+
+// CloseContext is like Close, but interrupts the final flush when the context is done.
+// It always releases the appender's resources. If it interrupts the flush, the buffered rows are lost,
+// and it returns the context's error.
+func (a *Appender) CloseContext(ctx context.Context) error {
 	if a.closed {
 		return getError(errAppenderDoubleClose, nil)
 	}
 	a.closed = true
+	start := time.Now()
+	stop := a.conn.interruptOnDone(ctx)
 
 	// Append all remaining chunks.
-	errAppend := a.appendDataChunks()
+	errAppend := ctx.Err()
+	if errAppend == nil {
+		errAppend = a.appendDataChunks()
+	}
 
 	// We flush before closing to get a meaningful error message.
 	var errFlush error
-	if mapping.AppenderFlush(a.appender) == mapping.StateError {
-		errFlush = getDuckDBError(mapping.AppenderError(a.appender))
+	if ctx.Err() == nil && mapping.AppenderFlush(a.appender) == mapping.StateError {
+		errFlush = a.conn.getDuckDBError(mapping.AppenderError(a.appender))
 	}
 
 	// Destroy all appender data and the appender.
+	if a.chunk != nil {
+		a.chunk.close()
+		a.chunk = nil
+	}
+	a.releaseRetained()
+	if a.spare != nil {
+		a.spare.close()
+		a.spare = nil
+	}
 	destroyTypeSlice(a.types)
 	var errClose error
 	if mapping.AppenderDestroy(&a.appender) == mapping.StateError {
 		errClose = errAppenderClose
 	}
 
-	err := errors.Join(errAppend, errFlush, errClose)
-	if err != nil {
-		return getError(invalidatedAppenderError(err), nil)
+	// Insert the staged rows, and drop the staging table.
+	var errStaged error
+	if errAppend == nil && errFlush == nil {
+		errStaged = a.insertStaged(ctx)
 	}
+	stop()
+	errDrop := a.dropStaging()
 
-	return nil
+	err := errors.Join(errAppend, errFlush, errClose, errStaged, errDrop)
+	if ctx.Err() != nil {
+		err = ctx.Err()
+	} else if err != nil {
+		err = getError(invalidatedAppenderError(err), nil)
+	}
+	a.recordFlush(start, err)
+
+	return err
 }
 
 // AppendRow loads a row of values into the appender. The values are provided as separate arguments.
+// Alternatively, a single map[string]any argument provides the values by column name, like AppendRowMap.
+// For a table with a single STRUCT, MAP, or JSON column, AppendRow appends the map to that column.
 func (a *Appender) AppendRow(args ...driver.Value) error {
 	if a.closed {
 		return getError(errAppenderAppendAfterClose, nil)
 	}
 
-	err := a.appendRowSlice(args)
+	var err error
+	if values, ok := a.rowMap(args); ok {
+		err = a.appendRowMap(values)
+	} else {
+		err = a.appendRowSlice(args)
+	}
+	if err != nil {
+		err = getError(errAppenderAppendRow, err)
+		if !a.collectRejected {
+			return err
+		}
+		a.reject(args, err)
+	}
+
+	return a.autoFlush()
+}
+
+// AppendRows loads a batch of rows into the appender. Each row provides a value per column, like AppendRow.
+// It consults the flush policies after each full data chunk and after the last row, instead of after each row.
+// If AppendRows returns an error, the rows before the failing row are loaded.
+func (a *Appender) AppendRows(rows [][]driver.Value) error {
+	if a.closed {
+		return getError(errAppenderAppendAfterClose, nil)
+	}
+
+	for i, row := range rows {
+		if err := a.appendRowSlice(row); err != nil {
+			err = getError(errAppenderAppendRow, addRowIndexToError(err, i))
+			if !a.collectRejected {
+				return err
+			}
+			a.reject(row, err)
+		}
+		if a.rowCount == GetDataChunkCapacity() {
+			if err := a.autoFlush(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return a.autoFlush()
+}
+
+// EnumDictionary returns the dictionary indexes of the values of an ENUM column.
+// AppendRow accepts these uint32 indexes for the column, which avoids looking up the dictionary for each value.
+func (a *Appender) EnumDictionary(colIdx int) (map[string]uint32, error) {
+	if colIdx < 0 || colIdx >= len(a.types) {
+		return nil, getError(errAPI, columnCountError(colIdx, len(a.types)))
+	}
+	t := Type(mapping.GetTypeId(a.types[colIdx]))
+	if t != TYPE_ENUM {
+		return nil, getError(errAPI, invalidInputError(typeToStringMap[t], typeToStringMap[TYPE_ENUM]))
+	}
+	return enumDictionary(a.types[colIdx]), nil
+}
+
+// AppendNullRow loads a row into the appender, whose values are all NULL.
+// It is faster than passing nil for each value to AppendRow, e.g., for wide tables.
+func (a *Appender) AppendNullRow() error {
+	if a.closed {
+		return getError(errAppenderAppendAfterClose, nil)
+	}
+
+	chunk, err := a.rowChunk()
+	if err != nil {
+		return getError(errAppenderAppendRow, err)
+	}
+	for i := range chunk.columns {
+		chunk.columns[i].setNull(mapping.IdxT(a.rowCount))
+	}
+	a.rowCount++
+
+	return a.autoFlush()
+}
+
+// AppendColumns loads the values of each column into the appender. The columns are provided
+// as separate arguments, one Go slice per column of the table, e.g., []int32 for an INTEGER column.
+// All slices must have the same length. Slices whose element type matches the column's type,
+// e.g., []float64 for a DOUBLE column, are copied directly into the appender's data chunks.
+// If AppendColumns returns an error, some of the rows may have been loaded partially.
+func (a *Appender) AppendColumns(columns ...any) error {
+	if a.closed {
+		return getError(errAppenderAppendAfterClose, nil)
+	}
+
+	err := a.appendColumnSlices(columns)
+	if err != nil {
+		if errors.Is(err, errAppenderFlush) {
+			return err
+		}
+		return getError(errAppenderAppendRow, err)
+	}
+
+	return nil
+}
+
+// AppendRowMap loads a row of values into the appender. The values are matched to the table's columns
+// by their names, ignoring case. The appender appends the default value set with SetDefault,
+// or NULL, to columns without a value.
+func (a *Appender) AppendRowMap(values map[string]driver.Value) error {
+	if a.closed {
+		return getError(errAppenderAppendAfterClose, nil)
+	}
+
+	err := a.appendRowMap(values)
 	if err != nil {
 		return getError(errAppenderAppendRow, err)
 	}
 
+	return a.autoFlush()
+}
+
+// SetDefault sets the value that AppendRowMap appends to a column, matched by name, ignoring case,
+// if the row does not contain the column. If value is a func() driver.Value, e.g., to set a created_at column
+// with the current time, the appender calls it for each row. A nil value removes the column's default value,
+// i.e., AppendRowMap appends NULL. To append the DEFAULT value of the table's column, use WithColumns.
+func (a *Appender) SetDefault(column string, value driver.Value) error {
+	if a.closed {
+		return getError(errAppenderAppendAfterClose, nil)
+	}
+	if a.columnIndexes == nil {
+		if err := a.loadColumnNames(); err != nil {
+			return getError(errAppenderSetDefault, err)
+		}
+	}
+
+	idx, ok := a.columnIndex(column)
+	if !ok {
+		return getError(errAppenderSetDefault, invalidInputError(column, "column name"))
+	}
+	if value == nil {
+		delete(a.defaults, idx)
+		return nil
+	}
+	if a.defaults == nil {
+		a.defaults = make(map[int]driver.Value)
+	}
+	a.defaults[idx] = value
 	return nil
 }
 
+// NewDataChunk returns a new data chunk with the column types of the appender's table, to use with AppendChunk.
+// The chunk's size is its capacity, see GetDataChunkCapacity. It is the caller's responsibility to close the chunk.
+func (a *Appender) NewDataChunk() (*DataChunk, error) {
+	if a.closed {
+		return nil, getError(errAppenderAppendAfterClose, nil)
+	}
+
+	var chunk DataChunk
+	if err := chunk.initFromTypes(a.types, true); err != nil {
+		return nil, getError(errAppenderAppendRow, err)
+	}
+	return &chunk, nil
+}
+
+// AppendChunk loads the rows of a data chunk into the appender, up to the chunk's size, see DataChunk.SetSize.
+// The chunk's column types should match the table's column types, e.g., by creating it with NewDataChunk.
+// DuckDB casts columns of other types to the table's column types.
+// The appender copies the rows, so the caller can reuse the chunk afterward.
+// The rows appended before the chunk are loaded first, keeping their order.
+func (a *Appender) AppendChunk(chunk *DataChunk) error {
+	if a.closed {
+		return getError(errAppenderAppendAfterClose, nil)
+	}
+	if len(chunk.columns) != len(a.types) {
+		return getError(errAppenderAppendRow, columnCountError(len(chunk.columns), len(a.types)))
+	}
+
+	// Hand off the pending rows first.
+	if a.chunk != nil {
+		if err := a.appendDataChunk(); err != nil {
+			return getError(errAppenderAppendRow, invalidatedAppenderError(err))
+		}
+	}
+	if a.recovery {
+		// Copy the rows, so that the appender can retain them.
+		if err := a.appendChunkValues(chunk); err != nil {
+			return getError(errAppenderAppendRow, err)
+		}
+	} else if err := a.handOff(chunk, chunk.GetSize()); err != nil {
+		return getError(errAppenderAppendRow, err)
+	}
+
+	return a.autoFlush()
+}
+
 func (a *Appender) addDataChunk() error {
+	a.rowCount = 0
+	if a.spare != nil {
+		a.chunk, a.spare = a.spare, nil
+		return nil
+	}
+
 	var chunk DataChunk
 	if err := chunk.initFromTypes(a.types, true); err != nil {
 		return err
 	}
-	a.chunks = append(a.chunks, chunk)
+	a.chunk = &chunk
 
 	return nil
 }
@@ -151,51 +630,439 @@ func (a *Appender) appendRowSlice(args []driver.Value) error {
 		return columnCountError(len(args), len(a.types))
 	}
 
-	// Create a new data chunk if the current chunk is full.
-	if a.rowCount == GetDataChunkCapacity() || len(a.chunks) == 0 {
-		if err := a.addDataChunk(); err != nil {
-			return err
+	chunk, err := a.rowChunk()
+	if err != nil {
+		return err
+	}
+	if err = a.setRow(chunk, args); err != nil {
+		// A later row reuses the row, so it must not keep the NULL values of this row.
+		a.clearRow(chunk)
+		return err
+	}
+	a.rowCount++
+	return nil
+}
+
+func (a *Appender) setRow(chunk *DataChunk, args []driver.Value) error {
+	if a.trustedSetters != nil {
+		for i, val := range args {
+			vec := &chunk.columns[i]
+			setFn := a.trustedSetters[i]
+			if setFn == nil {
+				setFn = vec.setFn
+			}
+			if err := setFn(vec, mapping.IdxT(a.rowCount), val); err != nil {
+				return err
+			}
 		}
-		a.rowCount = 0
+		return nil
 	}
 
 	// Set all values.
 	for i, val := range args {
-		chunk := &a.chunks[len(a.chunks)-1]
-		err := chunk.SetValue(i, a.rowCount, val)
+		if d, ok := val.(time.Duration); ok {
+			val = IntervalFromDuration(d, a.conn.durationConversion)
+		}
+		val, err := unwrapValue(val)
 		if err != nil {
+			return addIndexToError(err, i+1)
+		}
+		if err = chunk.SetValue(i, a.rowCount, val); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// clearRow marks the values of the current row as not NULL after setting its values failed.
+func (a *Appender) clearRow(chunk *DataChunk) {
+	for i := range chunk.columns {
+		chunk.columns[i].setValid(mapping.IdxT(a.rowCount))
+	}
+}
+
+// autoFlush flushes the appender, if any of its flush policies says so.
+func (a *Appender) autoFlush() error {
+	if len(a.flushPolicies) == 0 || a.bufferedRows() == 0 {
+		return nil
+	}
+
+	state := FlushState{
+		Rows:      a.bufferedRows(),
+		Chunks:    a.bufferedChunks(),
+		Bytes:     a.bufferedSize(),
+		LastFlush: a.lastFlush,
+	}
+	for _, policy := range a.flushPolicies {
+		if policy.ShouldFlush(state) {
+			return a.Flush()
+		}
+	}
+	return nil
+}
+
+// bufferedRows returns the number of rows appended since the last flush.
+func (a *Appender) bufferedRows() int {
+	return a.chunkRows + a.rowCount
+}
+
+// bufferedChunks returns the number of data chunks holding the rows appended since the last flush.
+func (a *Appender) bufferedChunks() int {
+	if a.chunk == nil {
+		return a.chunkCount
+	}
+	return a.chunkCount + 1
+}
+
+// bufferedSize returns the estimated size of the rows appended since the last flush.
+func (a *Appender) bufferedSize() int64 {
+	if a.chunk == nil {
+		return a.bufferedBytes
+	}
+	return a.bufferedBytes + a.chunk.estimatedBytes()
+}
+
+// rowChunk returns the data chunk of the next row. If the current chunk is full,
+// it hands the chunk to DuckDB and creates a new one.
+func (a *Appender) rowChunk() (*DataChunk, error) {
+	if a.chunk != nil && a.rowCount == GetDataChunkCapacity() {
+		if err := a.appendDataChunk(); err != nil {
+			return nil, invalidatedAppenderError(err)
+		}
+	}
+	if a.chunk == nil {
+		if err := a.addDataChunk(); err != nil {
+			return nil, err
+		}
+	}
+	return a.chunk, nil
+}
+
+func (a *Appender) appendColumnSlices(columns []any) error {
+	// Early-out, if the number of columns does not match the column count.
+	if len(columns) != len(a.types) {
+		return columnCountError(len(columns), len(a.types))
+	}
+
+	rowCount := -1
+	for _, column := range columns {
+		v := reflect.ValueOf(column)
+		if v.Kind() != reflect.Slice {
+			return invalidInputError(fmt.Sprintf("%T", column), "slice")
+		}
+		if rowCount == -1 {
+			rowCount = v.Len()
+		} else if v.Len() != rowCount {
+			return invalidInputError(strconv.Itoa(v.Len()), fmt.Sprintf("slice of length %d", rowCount))
+		}
+	}
+
+	for offset := 0; offset < rowCount; {
+		chunk, err := a.rowChunk()
+		if err != nil {
+			return err
+		}
+
+		count := min(rowCount-offset, GetDataChunkCapacity()-a.rowCount)
+		for i, column := range columns {
+			if err := setColumn(&chunk.columns[i], a.rowCount, column, offset, count); err != nil {
+				return addIndexToError(err, i+1)
+			}
+		}
+		a.rowCount += count
+		offset += count
+
+		if err := a.autoFlush(); err != nil {
 			return err
 		}
 	}
-	a.rowCount++
 
 	return nil
 }
 
-func (a *Appender) appendDataChunks() error {
-	var err error
+func (a *Appender) appendRowMap(values map[string]driver.Value) error {
+	if a.columnIndexes == nil {
+		if err := a.loadColumnNames(); err != nil {
+			return err
+		}
+	}
 
-	for i, chunk := range a.chunks {
-		// All data chunks except the last are at maximum capacity.
-		size := GetDataChunkCapacity()
-		if i == len(a.chunks)-1 {
-			size = a.rowCount
+	row := make([]driver.Value, len(a.types))
+	set := make([]bool, len(a.types))
+	for name, val := range values {
+		idx, ok := a.columnIndex(name)
+		if !ok {
+			return invalidInputError(name, "column name")
 		}
-		if err = chunk.SetSize(size); err != nil {
-			break
+		if set[idx] {
+			return duplicateNameError(name)
+		}
+		row[idx] = val
+		set[idx] = true
+	}
+	for idx, val := range a.defaults {
+		if set[idx] {
+			continue
 		}
-		if mapping.AppendDataChunk(a.appender, chunk.chunk) == mapping.StateError {
-			err = getDuckDBError(mapping.AppenderError(a.appender))
+		if fn, ok := val.(func() driver.Value); ok {
+			val = fn()
+		}
+		row[idx] = val
+	}
+
+	return a.appendRowSlice(row)
+}
+
+// rowMap returns the values of a row, if the arguments of AppendRow are a single map of the values by column name.
+func (a *Appender) rowMap(args []driver.Value) (map[string]driver.Value, bool) {
+	if len(args) != 1 {
+		return nil, false
+	}
+	if len(a.types) == 1 {
+		switch Type(mapping.GetTypeId(a.types[0])) {
+		case TYPE_STRUCT, TYPE_MAP:
+			return nil, false
+		}
+		if mapping.LogicalTypeGetAlias(a.types[0]) == aliasJSON {
+			return nil, false
+		}
+	}
+
+	switch v := args[0].(type) {
+	case map[string]driver.Value:
+		return v, true
+	case map[string]any:
+		values := make(map[string]driver.Value, len(v))
+		for name, val := range v {
+			values[name] = val
+		}
+		return values, true
+	}
+	return nil, false
+}
+
+// loadColumnNames loads the column names of the appender's table from the catalog.
+func (a *Appender) loadColumnNames() error {
+	names := a.columns
+	if len(names) == 0 {
+		var err error
+		if names, err = a.tableColumnNames(); err != nil {
+			return err
+		}
+	}
+	if len(names) != len(a.types) {
+		return columnCountError(len(names), len(a.types))
+	}
+
+	a.columnIndexes = make(map[string]int, len(names))
+	for i, name := range names {
+		if !a.exactColumnNames {
+			name = strings.ToLower(name)
+		}
+		a.columnIndexes[name] = i
+	}
+	return nil
+}
+
+// columnIndex returns the index of a column, matched by its name.
+func (a *Appender) columnIndex(name string) (int, bool) {
+	if !a.exactColumnNames {
+		name = strings.ToLower(name)
+	}
+	idx, ok := a.columnIndexes[name]
+	return idx, ok
+}
+
+// tableColumnNames returns the column names of the appender's table from the catalog.
+func (a *Appender) tableColumnNames() ([]string, error) {
+	query := `SELECT column_name FROM duckdb_columns()
+		WHERE database_name = coalesce(nullif(?, ''), current_database())
+		AND schema_name = coalesce(nullif(?, ''), current_schema())
+		AND lower(table_name) = lower(?)
+		ORDER BY column_index`
+	args := []driver.NamedValue{
+		{Ordinal: 1, Value: a.catalog},
+		{Ordinal: 2, Value: a.schema},
+		{Ordinal: 3, Value: a.table},
+	}
+	r, err := a.conn.QueryContext(context.Background(), query, args)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	var names []string
+	row := make([]driver.Value, 1)
+	for {
+		if err = r.Next(row); err != nil {
 			break
 		}
+		names = append(names, row[0].(string))
 	}
+	if !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	return names, nil
+}
 
-	for _, chunk := range a.chunks {
-		chunk.close()
+// resolveColumns replaces the appender's column names with the matching column names of the table, ignoring case.
+func (a *Appender) resolveColumns() error {
+	names, err := a.tableColumnNames()
+	if err != nil {
+		return err
 	}
-	a.chunks = a.chunks[:0]
+	columns := make([]string, len(a.columns))
+	for i, column := range a.columns {
+		columns[i] = column
+		for _, name := range names {
+			if strings.EqualFold(column, name) {
+				columns[i] = name
+				break
+			}
+		}
+	}
+	a.columns = columns
+	return nil
+}
+
+// appendDataChunks hands the current data chunk to DuckDB, and resets the buffered row statistics.
+func (a *Appender) appendDataChunks() error {
+	a.stats.flushedRows += int64(a.bufferedRows())
+
+	var err error
+	if a.chunk != nil {
+		err = a.appendDataChunk()
+	}
+	a.chunkCount = 0
+	a.chunkRows = 0
+	a.bufferedBytes = 0
+
+	return err
+}
+
+// appendDataChunk hands the current data chunk to DuckDB, which copies its rows, and keeps the reset chunk for reuse.
+// The row count never exceeds the chunk's capacity, so the size needs no validation.
+// With recovery, the appender retains the chunk until the next successful flush instead.
+func (a *Appender) appendDataChunk() error {
+	chunk := a.chunk
+	a.chunk = nil
+	mapping.DataChunkSetSize(chunk.chunk, mapping.IdxT(a.rowCount))
+	rowCount := a.rowCount
 	a.rowCount = 0
 
+	err := a.handOff(chunk, rowCount)
+	if a.recovery {
+		a.retained = append(a.retained, chunk)
+	} else {
+		chunk.reset()
+		a.spare = chunk
+	}
+	return err
+}
+
+// handOff appends the rows of a data chunk to DuckDB's appender, and counts them as buffered.
+func (a *Appender) handOff(chunk *DataChunk, rowCount int) error {
+	if mapping.AppendDataChunk(a.appender, chunk.chunk) == mapping.StateError {
+		return a.conn.getDuckDBError(mapping.AppenderError(a.appender))
+	}
+
+	a.chunkCount++
+	a.chunkRows += rowCount
+	a.stats.chunks++
+	a.bufferedBytes += chunk.estimatedBytes()
+	return nil
+}
+
+// insertStaged inserts the rows of the staging table into the target table, and clears the staging table.
+// If inserting the rows fails, it keeps them in the staging table.
+func (a *Appender) insertStaged(ctx context.Context) error {
+	if a.staging == "" {
+		return nil
+	}
+
+	var verb string
+	switch a.conflictMode {
+	case ConflictError:
+		verb = `INSERT`
+	case ConflictReplace:
+		verb = `INSERT OR REPLACE`
+	case ConflictIgnore:
+		verb = `INSERT OR IGNORE`
+	}
+	target := a.target
+	if len(a.columns) != 0 {
+		target += " (" + a.columnList() + ")"
+	}
+	query := fmt.Sprintf(`%s INTO %s SELECT %s FROM %s`, verb, target, a.selectList(geometryFromBlob), a.staging)
+	err := a.stagingTx(ctx, func() error {
+		if _, err := a.conn.ExecContext(ctx, query, nil); err != nil {
+			return err
+		}
+		_, err := a.conn.ExecContext(ctx, `DELETE FROM `+a.staging, nil)
+		return err
+	})
+	if err != nil && a.collectRejected && ctx.Err() == nil {
+		// Insert halves of the rows, and collect the rows that fail.
+		err = a.insertStagedRows(ctx, query)
+	}
+	// If inserting fails, the transaction keeps the staged rows, so that the next Flush or Close retries inserting them.
+	return err
+}
+
+// stagingTx executes fn in a transaction, so that inserting staged rows and deleting them from the staging table
+// either both take effect or neither does. If the connection is in a transaction, fn executes in that transaction.
+func (a *Appender) stagingTx(ctx context.Context, fn func() error) error {
+	if a.conn.tx {
+		return fn()
+	}
+	if _, err := a.conn.ExecContext(ctx, `BEGIN TRANSACTION`, nil); err != nil {
+		return err
+	}
+	if err := fn(); err != nil {
+		_, errRollback := a.conn.ExecContext(context.Background(), `ROLLBACK`, nil)
+		return errors.Join(err, errRollback)
+	}
+	_, err := a.conn.ExecContext(context.Background(), `COMMIT`, nil)
+	return err
+}
+
+// interruptOnDone interrupts the connection's running statement or flush, if the context is done
+// before calling the returned stop function.
+func (conn *Conn) interruptOnDone(ctx context.Context) (stop func()) {
+	if ctx.Done() == nil {
+		return func() {}
+	}
+
+	mainDoneCh := make(chan struct{})
+	bgDoneCh := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			mapping.Interrupt(conn.conn)
+		case <-mainDoneCh:
+		}
+		close(bgDoneCh)
+	}()
+	return func() {
+		close(mainDoneCh)
+		// Wait for the goroutine, so that it cannot interrupt a later statement.
+		<-bgDoneCh
+	}
+}
+
+// columnList returns the quoted, comma-separated columns of the appender, or *, if it appends to all columns.
+func (a *Appender) columnList() string {
+	if len(a.columns) == 0 {
+		return "*"
+	}
+	return strings.Join(quoteColumns(a.columns), ", ")
+}
+
+func (a *Appender) dropStaging() error {
+	if a.staging == "" {
+		return nil
+	}
+	_, err := a.conn.ExecContext(context.Background(), `DROP TABLE IF EXISTS `+a.staging, nil)
 	return err
 }
 
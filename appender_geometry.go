@@ -0,0 +1,68 @@
+package duckdb
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/marcboeker/go-duckdb/mapping"
+)
+
+// geometryColumns returns the names of the appender's GEOMETRY columns.
+// GEOMETRY is a type of the spatial extension, whose values have an internal binary format.
+func (a *Appender) geometryColumns() ([]string, error) {
+	var indexes []int
+	for i, t := range a.types {
+		if mapping.LogicalTypeGetAlias(t) == aliasGeometry {
+			indexes = append(indexes, i)
+		}
+	}
+	if len(indexes) == 0 {
+		return nil, nil
+	}
+
+	names := a.columns
+	if len(names) == 0 {
+		var err error
+		if names, err = a.tableColumnNames(); err != nil {
+			return nil, err
+		}
+		if len(names) != len(a.types) {
+			return nil, columnCountError(len(names), len(a.types))
+		}
+	}
+	geometry := make([]string, len(indexes))
+	for i, idx := range indexes {
+		geometry[i] = names[idx]
+	}
+	return geometry, nil
+}
+
+// selectList returns the select list of the appender's columns, applying convert to the quoted GEOMETRY columns.
+func (a *Appender) selectList(convert func(column string) string) string {
+	if len(a.geometry) == 0 {
+		return a.columnList()
+	}
+
+	if len(a.columns) == 0 {
+		replace := make([]string, len(a.geometry))
+		for i, name := range quoteColumns(a.geometry) {
+			replace[i] = convert(name) + " AS " + name
+		}
+		return "* REPLACE (" + strings.Join(replace, ", ") + ")"
+	}
+
+	list := quoteColumns(a.columns)
+	for i, name := range a.columns {
+		if slices.Contains(a.geometry, name) {
+			list[i] = convert(list[i]) + " AS " + list[i]
+		}
+	}
+	return strings.Join(list, ", ")
+}
+
+// geometryFromBlob returns the expression converting a staged WKB or WKT value to a GEOMETRY value.
+// WKB values start with their byte order, i.e., 0x00 or 0x01, while WKT values start with a letter.
+func geometryFromBlob(column string) string {
+	return `CASE WHEN starts_with(CAST(` + column + ` AS VARCHAR), '\x00') OR starts_with(CAST(` + column + ` AS VARCHAR), '\x01')
+		THEN ST_GeomFromWKB(` + column + `) ELSE ST_GeomFromText(decode(` + column + `)) END`
+}
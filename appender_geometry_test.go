@@ -0,0 +1,96 @@
+package duckdb
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppenderGeometry(t *testing.T) {
+	// Emulate the GEOMETRY column and the conversion functions of the spatial extension.
+	c, db, conn, a := prepareAppender(t, `CREATE MACRO ST_GeomFromWKB(wkb) AS 'wkb:' || CAST(wkb AS VARCHAR);
+		CREATE MACRO ST_GeomFromText(wkt) AS 'wkt:' || wkt;
+		CREATE TABLE test (id INTEGER, geom VARCHAR)`)
+	defer cleanupAppender(t, c, db, conn, a)
+
+	geometry, err := a.geometryColumns()
+	require.NoError(t, err)
+	require.Empty(t, geometry)
+	require.NoError(t, a.destroy())
+	a.geometry = []string{"geom"}
+	require.NoError(t, a.open())
+
+	wkb := []byte{0x01, 0x01, 0x00, 0x00, 0x00}
+	require.NoError(t, a.AppendRow(int32(1), wkb))
+	require.NoError(t, a.AppendRow(int32(2), "POINT (1 2)"))
+	require.NoError(t, a.AppendRow(int32(3), nil))
+	require.NoError(t, a.Flush())
+
+	// Append to a subset of the columns.
+	other, err := NewAppenderWithOptions(conn, "", "", "test", WithColumns("GEOM"))
+	require.NoError(t, err)
+	require.NoError(t, other.destroy())
+	other.geometry = []string{"geom"}
+	require.NoError(t, other.open())
+	require.NoError(t, other.AppendRow("POINT (3 4)"))
+	require.NoError(t, other.Close())
+
+	res, err := db.Query(`SELECT CAST(geom AS VARCHAR) FROM test ORDER BY id NULLS LAST`)
+	require.NoError(t, err)
+	defer closeRowsWrapper(t, res)
+
+	var values []any
+	for res.Next() {
+		var v any
+		require.NoError(t, res.Scan(&v))
+		values = append(values, v)
+	}
+	require.NoError(t, res.Err())
+	require.Equal(t, []any{`wkb:\x01\x01\x00\x00\x00`, "wkt:POINT (1 2)", nil, "wkt:POINT (3 4)"}, values)
+}
+
+func TestAppenderGeometrySpatial(t *testing.T) {
+	c := newConnectorWrapper(t, ``, nil)
+	defer closeConnectorWrapper(t, c)
+	db := sql.OpenDB(c)
+	defer closeDbWrapper(t, db)
+
+	if _, err := db.Exec(`INSTALL spatial; LOAD spatial`); err != nil {
+		t.Skipf("could not load the spatial extension: %v", err)
+	}
+	_, err := db.Exec(`CREATE TABLE test (id INTEGER, geom GEOMETRY)`)
+	require.NoError(t, err)
+
+	conn := openDriverConnWrapper(t, c)
+	defer closeDriverConnWrapper(t, &conn)
+	a, err := NewAppender(conn, "", "", "test")
+	require.NoError(t, err)
+
+	// The WKB of POINT (1 2).
+	wkb := []byte{
+		0x01, 0x01, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xf0, 0x3f,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x40,
+	}
+	require.NoError(t, a.AppendRow(int32(1), wkb))
+	require.NoError(t, a.AppendRow(int32(2), "LINESTRING (0 0, 3 4)"))
+	require.NoError(t, a.AppendRow(int32(3), nil))
+	require.NoError(t, a.Close())
+
+	res, err := db.Query(`SELECT ST_AsText(geom), ST_Length(geom) FROM test ORDER BY id`)
+	require.NoError(t, err)
+	defer closeRowsWrapper(t, res)
+
+	var texts []any
+	var lengths []any
+	for res.Next() {
+		var text, length any
+		require.NoError(t, res.Scan(&text, &length))
+		texts = append(texts, text)
+		lengths = append(lengths, length)
+	}
+	require.NoError(t, res.Err())
+	require.Equal(t, []any{"POINT (1 2)", "LINESTRING (0 0, 3 4)", nil}, texts)
+	require.Equal(t, []any{float64(0), float64(5), nil}, lengths)
+}
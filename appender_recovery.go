@@ -0,0 +1,95 @@
+package duckdb
+
+import (
+	"database/sql/driver"
+
+	"github.com/marcboeker/go-duckdb/mapping"
+)
+
+// WithRecovery configures an Appender to recover from a failed flush, e.g., if a row violates a constraint.
+// Usually, a failed flush invalidates DuckDB's appender, and the rows appended since the last flush are lost.
+// With recovery, the Appender keeps its data chunks until they are flushed. After a failed flush,
+// it recreates DuckDB's appender and loads the kept rows again, so that the caller can retry the flush,
+// e.g., after resolving the conflict. Call DiscardBuffered to drop the rows instead.
+// Keeping the data chunks doubles the memory of the buffered rows.
+// AppendChunk copies the rows of a chunk value by value, like AppendRow, so that the Appender can keep them.
+func WithRecovery() AppenderOption {
+	return func(a *Appender) {
+		a.recovery = true
+	}
+}
+
+// DiscardBuffered drops the rows appended since the last flush.
+// It recreates DuckDB's appender, which also recovers an Appender invalidated by a failed flush.
+func (a *Appender) DiscardBuffered() error {
+	if a.closed {
+		return getError(errAppenderAppendAfterClose, nil)
+	}
+
+	if a.chunk != nil {
+		a.chunk.reset()
+		a.spare, a.chunk = a.chunk, nil
+		a.rowCount = 0
+	}
+	a.releaseRetained()
+	a.chunkCount = 0
+	a.chunkRows = 0
+	a.bufferedBytes = 0
+
+	mapping.AppenderDestroy(&a.appender)
+	if err := a.createAppender(); err != nil {
+		return getError(errAppenderFlush, err)
+	}
+	return nil
+}
+
+// recover recreates DuckDB's appender after a failed flush, and loads the retained data chunks again.
+func (a *Appender) recover() error {
+	mapping.AppenderDestroy(&a.appender)
+	if err := a.createAppender(); err != nil {
+		return err
+	}
+
+	for _, chunk := range a.retained {
+		rowCount := chunk.GetSize()
+		a.stats.flushedRows -= int64(rowCount)
+		if mapping.AppendDataChunk(a.appender, chunk.chunk) == mapping.StateError {
+			return a.conn.getDuckDBError(mapping.AppenderError(a.appender))
+		}
+		a.chunkCount++
+		a.chunkRows += rowCount
+		a.bufferedBytes += chunk.estimatedBytes()
+	}
+	return nil
+}
+
+// releaseRetained releases the data chunks retained since the last flush, and keeps one of them for reuse.
+func (a *Appender) releaseRetained() {
+	for _, chunk := range a.retained {
+		if a.spare == nil {
+			chunk.reset()
+			a.spare = chunk
+			continue
+		}
+		chunk.close()
+	}
+	a.retained = nil
+}
+
+// appendChunkValues copies the rows of a data chunk into the appender's data chunks, value by value.
+func (a *Appender) appendChunkValues(chunk *DataChunk) error {
+	row := make([]driver.Value, len(chunk.columns))
+	for rowIdx := 0; rowIdx < chunk.GetSize(); rowIdx++ {
+		for colIdx := range row {
+			val, err := chunk.GetValue(colIdx, rowIdx)
+			if err != nil {
+				return err
+			}
+			row[colIdx] = val
+		}
+		if err := a.appendRowSlice(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package duckdb
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppenderRecovery(t *testing.T) {
+	c := newConnectorWrapper(t, ``, nil)
+	defer closeConnectorWrapper(t, c)
+	db := sql.OpenDB(c)
+	defer closeDbWrapper(t, db)
+	conn := openDriverConnWrapper(t, c)
+	defer closeDriverConnWrapper(t, &conn)
+
+	createTable(t, db, `CREATE TABLE test (id INTEGER PRIMARY KEY, name VARCHAR)`)
+	_, err := db.Exec(`INSERT INTO test VALUES (1, 'existing')`)
+	require.NoError(t, err)
+
+	a, err := NewAppenderWithOptions(conn, "", "", "test", WithRecovery())
+	require.NoError(t, err)
+
+	// Retain rows across multiple data chunks, and rows copied from a chunk.
+	rowCount := GetDataChunkCapacity() + 10
+	for i := 0; i < rowCount; i++ {
+		require.NoError(t, a.AppendRow(int32(i+1), "row"))
+	}
+	chunk, err := a.NewDataChunk()
+	require.NoError(t, err)
+	require.NoError(t, chunk.SetValue(0, 0, int32(rowCount+1)))
+	require.NoError(t, chunk.SetValue(1, 0, "chunk"))
+	require.NoError(t, chunk.SetSize(1))
+	require.NoError(t, a.AppendChunk(chunk))
+	chunk.Close()
+
+	// The first row violates the primary key. The appender keeps its rows.
+	err = a.Flush()
+	require.ErrorIs(t, err, errAppenderFlush)
+	require.NotContains(t, err.Error(), invalidatedAppenderMsg)
+	require.Equal(t, rowCount+1, a.Stats().BufferedRows)
+	require.Equal(t, int64(rowCount+1), a.Stats().Rows)
+
+	var count int
+	require.NoError(t, db.QueryRow(`SELECT count(*) FROM test`).Scan(&count))
+	require.Equal(t, 1, count)
+
+	// Retry after resolving the conflict.
+	_, err = db.Exec(`DELETE FROM test`)
+	require.NoError(t, err)
+	require.NoError(t, a.Flush())
+	require.Equal(t, 0, a.Stats().BufferedRows)
+	require.Equal(t, int64(rowCount+1), a.Stats().Rows)
+
+	var name string
+	require.NoError(t, db.QueryRow(`SELECT count(*), max_by(name, id) FROM test`).Scan(&count, &name))
+	require.Equal(t, rowCount+1, count)
+	require.Equal(t, "chunk", name)
+
+	// Discard the rows after another failed flush.
+	require.NoError(t, a.AppendRow(int32(1), "duplicate"))
+	require.ErrorIs(t, a.Flush(), errAppenderFlush)
+	require.NoError(t, a.DiscardBuffered())
+	require.Equal(t, 0, a.Stats().BufferedRows)
+	require.NoError(t, a.AppendRow(int32(0), "new"))
+	require.NoError(t, a.Close())
+
+	require.NoError(t, db.QueryRow(`SELECT count(*) FROM test`).Scan(&count))
+	require.Equal(t, rowCount+2, count)
+
+	require.ErrorIs(t, a.DiscardBuffered(), errAppenderAppendAfterClose)
+}
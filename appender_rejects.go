@@ -0,0 +1,125 @@
+package duckdb
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+)
+
+// RejectedRow is a row that the Appender could not load into its table.
+type RejectedRow struct {
+	// Values are the values of the row, in the order of the appender's columns.
+	Values []driver.Value
+	// Err is the reason for rejecting the row.
+	Err error
+}
+
+// WithRejectedRows configures an Appender to collect the rows it cannot load into its table, instead of failing.
+// AppendRow rejects rows with values it cannot convert to the column types.
+// The Appender appends the rows to a temporary staging table, and inserts them into the target table when flushing.
+// If inserting the rows fails, e.g., because a row violates a constraint, the Appender inserts halves of the rows
+// until it isolates the failing rows, which it rejects, while the other rows are inserted.
+// Get the rejected rows with RejectedRows.
+func WithRejectedRows() AppenderOption {
+	return func(a *Appender) {
+		a.collectRejected = true
+	}
+}
+
+// RejectedRows returns the rows rejected since the last call to RejectedRows, see WithRejectedRows.
+func (a *Appender) RejectedRows() []RejectedRow {
+	rejected := a.rejected
+	a.rejected = nil
+	return rejected
+}
+
+// reject adds a copy of a row to the rejected rows.
+func (a *Appender) reject(values []driver.Value, err error) {
+	a.rejected = append(a.rejected, RejectedRow{Values: cloneValues(values), Err: err})
+}
+
+// cloneValues copies values, including the bytes of []byte values, which the caller may reuse.
+func cloneValues(values []driver.Value) []driver.Value {
+	cloned := make([]driver.Value, len(values))
+	for i, v := range values {
+		if b, ok := v.([]byte); ok {
+			v = bytes.Clone(b)
+		}
+		cloned[i] = v
+	}
+	return cloned
+}
+
+// insertStagedRows inserts the rows of the staging table with the query inserting all rows,
+// and rejects the rows failing to insert. It bisects the rows to isolate the failing rows,
+// i.e., it executes a number of statements per failing row that is logarithmic in the number of rows.
+// It deletes the rows from the staging table once it inserted or rejected them.
+func (a *Appender) insertStagedRows(ctx context.Context, query string) error {
+	rows, err := a.conn.QueryContext(ctx, `SELECT rowid, `+a.columnList()+` FROM `+a.staging+` ORDER BY rowid`, nil)
+	if err != nil {
+		return err
+	}
+
+	var staged [][]driver.Value
+	values := make([]driver.Value, len(a.types)+1)
+	for {
+		if err = rows.Next(values); err != nil {
+			break
+		}
+		staged = append(staged, cloneValues(values))
+	}
+	if errClose := rows.Close(); !errors.Is(err, io.EOF) || errClose != nil {
+		return errors.Join(err, errClose)
+	}
+
+	return a.insertStagedHalves(ctx, query, staged)
+}
+
+// insertStagedHalves inserts the halves of the staged rows, whose first values are their rowids,
+// after inserting all of them failed.
+func (a *Appender) insertStagedHalves(ctx context.Context, query string, staged [][]driver.Value) error {
+	if len(staged) < 2 {
+		return a.insertStagedRange(ctx, query, staged)
+	}
+	mid := len(staged) / 2
+	if err := a.insertStagedRange(ctx, query, staged[:mid]); err != nil {
+		return err
+	}
+	return a.insertStagedRange(ctx, query, staged[mid:])
+}
+
+// insertStagedRange inserts the staged rows, and deletes them from the staging table.
+// If inserting them fails, it inserts their halves, or rejects the row, if it is a single row.
+func (a *Appender) insertStagedRange(ctx context.Context, query string, staged [][]driver.Value) error {
+	if len(staged) == 0 {
+		return nil
+	}
+	const where = ` WHERE rowid BETWEEN ? AND ?`
+	rowids := []driver.NamedValue{
+		{Ordinal: 1, Value: staged[0][0]},
+		{Ordinal: 2, Value: staged[len(staged)-1][0]},
+	}
+
+	err := a.stagingTx(ctx, func() error {
+		if _, err := a.conn.ExecContext(ctx, query+where, rowids); err != nil {
+			return err
+		}
+		_, err := a.conn.ExecContext(ctx, `DELETE FROM `+a.staging+where, rowids)
+		return err
+	})
+	switch {
+	case err == nil:
+		return nil
+	case ctx.Err() != nil:
+		// Keep the remaining rows for the next flush.
+		return ctx.Err()
+	case len(staged) > 1:
+		return a.insertStagedHalves(ctx, query, staged)
+	}
+
+	a.reject(staged[0][1:], err)
+	_, err = a.conn.ExecContext(context.Background(), `DELETE FROM `+a.staging+where, rowids)
+	return err
+}
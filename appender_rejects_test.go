@@ -0,0 +1,193 @@
+package duckdb
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppenderRejectedRows(t *testing.T) {
+	c := newConnectorWrapper(t, ``, nil)
+	defer closeConnectorWrapper(t, c)
+	db := sql.OpenDB(c)
+	defer closeDbWrapper(t, db)
+	conn := openDriverConnWrapper(t, c)
+	defer closeDriverConnWrapper(t, &conn)
+
+	createTable(t, db, `CREATE TABLE test (id INTEGER PRIMARY KEY, name VARCHAR NOT NULL, score INTEGER CHECK (score >= 0))`)
+	_, err := db.Exec(`INSERT INTO test VALUES (1, 'existing', 0)`)
+	require.NoError(t, err)
+
+	a, err := NewAppenderWithOptions(conn, "", "", "test", WithRejectedRows())
+	require.NoError(t, err)
+
+	require.NoError(t, a.AppendRow(int32(2), "a", int32(1)))
+	// Conversion errors.
+	require.NoError(t, a.AppendRow(int32(3), "b", "not a number"))
+	require.NoError(t, a.AppendRow(int32(3)))
+	// Constraint violations.
+	require.NoError(t, a.AppendRow(int32(1), "duplicate", int32(1)))
+	require.NoError(t, a.AppendRow(int32(4), nil, int32(1)))
+	require.NoError(t, a.AppendRow(int32(5), "negative", int32(-1)))
+	require.NoError(t, a.AppendRow(int32(6), "b", int32(2)))
+
+	rejected := a.RejectedRows()
+	require.Len(t, rejected, 2)
+	require.Equal(t, []driver.Value{int32(3), "b", "not a number"}, rejected[0].Values)
+	testError(t, rejected[0].Err, errAppenderAppendRow.Error(), castErrMsg)
+	require.Equal(t, []driver.Value{int32(3)}, rejected[1].Values)
+	testError(t, rejected[1].Err, errAppenderAppendRow.Error(), columnCountErrMsg)
+
+	require.NoError(t, a.Flush())
+	rejected = a.RejectedRows()
+	require.Len(t, rejected, 3)
+	require.Equal(t, []driver.Value{int32(1), "duplicate", int32(1)}, rejected[0].Values)
+	require.ErrorContains(t, rejected[0].Err, "violates primary key constraint")
+	require.Equal(t, []driver.Value{int32(4), nil, int32(1)}, rejected[1].Values)
+	require.ErrorContains(t, rejected[1].Err, "NOT NULL constraint failed")
+	require.Equal(t, []driver.Value{int32(5), "negative", int32(-1)}, rejected[2].Values)
+	require.ErrorContains(t, rejected[2].Err, "CHECK constraint failed")
+	require.Empty(t, a.RejectedRows())
+
+	// The appender continues after rejecting rows.
+	require.NoError(t, a.AppendRow(int32(7), "c", int32(3)))
+	require.NoError(t, a.Close())
+	require.Empty(t, a.RejectedRows())
+
+	var ids []int32
+	res, err := db.Query(`SELECT id FROM test ORDER BY id`)
+	require.NoError(t, err)
+	defer closeRowsWrapper(t, res)
+	for res.Next() {
+		var id int32
+		require.NoError(t, res.Scan(&id))
+		ids = append(ids, id)
+	}
+	require.NoError(t, res.Err())
+	require.Equal(t, []int32{1, 2, 6, 7}, ids)
+}
+
+func TestAppenderRejectedBlobRows(t *testing.T) {
+	c := newConnectorWrapper(t, ``, nil)
+	defer closeConnectorWrapper(t, c)
+	db := sql.OpenDB(c)
+	defer closeDbWrapper(t, db)
+	conn := openDriverConnWrapper(t, c)
+	defer closeDriverConnWrapper(t, &conn)
+
+	createTable(t, db, `CREATE TABLE test (id INTEGER PRIMARY KEY, payload BLOB)`)
+	_, err := db.Exec(`INSERT INTO test VALUES (1, 'existing'), (2, 'existing')`)
+	require.NoError(t, err)
+
+	a, err := NewAppenderWithOptions(conn, "", "", "test", WithRejectedRows())
+	require.NoError(t, err)
+
+	payload := []byte("reused")
+	require.NoError(t, a.AppendRow(int32(1), []byte("first")))
+	require.NoError(t, a.AppendRow(int32(3), []byte("ok")))
+	require.NoError(t, a.AppendRow(int32(2), []byte("second")))
+	require.NoError(t, a.AppendRow(int32(4), []byte("okcond")))
+	require.NoError(t, a.AppendRow(int32(5), payload, "too many"))
+	copy(payload, "change")
+
+	rejected := a.RejectedRows()
+	require.Len(t, rejected, 1)
+	require.Equal(t, []driver.Value{int32(5), []byte("reused"), "too many"}, rejected[0].Values)
+
+	require.NoError(t, a.Flush())
+	rejected = a.RejectedRows()
+	require.Len(t, rejected, 2)
+	require.Equal(t, []driver.Value{int32(1), []byte("first")}, rejected[0].Values)
+	require.Equal(t, []driver.Value{int32(2), []byte("second")}, rejected[1].Values)
+	require.NoError(t, a.Close())
+}
+
+func TestAppenderRejectedRowsBisect(t *testing.T) {
+	c := newConnectorWrapper(t, ``, nil)
+	defer closeConnectorWrapper(t, c)
+	db := sql.OpenDB(c)
+	defer closeDbWrapper(t, db)
+	conn := openDriverConnWrapper(t, c)
+	defer closeDriverConnWrapper(t, &conn)
+
+	createTable(t, db, `CREATE TABLE test (id INTEGER PRIMARY KEY, score INTEGER CHECK (score >= 0))`)
+	a, err := NewAppenderWithOptions(conn, "", "", "test", WithRejectedRows())
+	require.NoError(t, err)
+
+	// Reject some of the rows of multiple full chunks.
+	rowCount := GetDataChunkCapacity()*2 + 100
+	var failing []driver.Value
+	for i := range rowCount {
+		score := int32(i)
+		if i%1000 == 7 || i == rowCount-1 {
+			score = -1
+			failing = append(failing, int32(i))
+		}
+		require.NoError(t, a.AppendRow(int32(i), score))
+	}
+	require.NoError(t, a.Close())
+
+	rejected := a.RejectedRows()
+	require.Len(t, rejected, len(failing))
+	for i, row := range rejected {
+		require.Equal(t, failing[i], row.Values[0])
+		require.ErrorContains(t, row.Err, "CHECK constraint failed")
+	}
+
+	var count int
+	require.NoError(t, db.QueryRow(`SELECT count(*) FROM test`).Scan(&count))
+	require.Equal(t, rowCount-len(failing), count)
+}
+
+func TestAppenderRejectedRowNulls(t *testing.T) {
+	c := newConnectorWrapper(t, ``, nil)
+	defer closeConnectorWrapper(t, c)
+	db := sql.OpenDB(c)
+	defer closeDbWrapper(t, db)
+	conn := openDriverConnWrapper(t, c)
+	defer closeDriverConnWrapper(t, &conn)
+
+	createTable(t, db, `CREATE TABLE test (a INTEGER, b INTEGER)`)
+	a, err := NewAppenderWithOptions(conn, "", "", "test", WithRejectedRows())
+	require.NoError(t, err)
+
+	// The rows after a rejected row do not keep its NULL values.
+	require.NoError(t, a.AppendRow(nil, "x"))
+	require.NoError(t, a.AppendRow(int32(1), int32(2)))
+	require.NoError(t, a.AppendRows([][]driver.Value{{nil, "y"}, {int32(3), int32(4)}}))
+	require.Len(t, a.RejectedRows(), 2)
+	require.NoError(t, a.Close())
+
+	res, err := db.Query(`SELECT a, b FROM test ORDER BY a`)
+	require.NoError(t, err)
+	defer closeRowsWrapper(t, res)
+	var values [][]any
+	for res.Next() {
+		var x, y any
+		require.NoError(t, res.Scan(&x, &y))
+		values = append(values, []any{x, y})
+	}
+	require.NoError(t, res.Err())
+	require.Equal(t, [][]any{{int32(1), int32(2)}, {int32(3), int32(4)}}, values)
+
+	// The same applies to the rows after a failing row of a TypedAppender.
+	createTable(t, db, `CREATE TYPE mood AS ENUM ('happy'); CREATE TABLE typed (a INTEGER, b mood)`)
+	type row struct {
+		A *int32
+		B string
+	}
+	typed, err := NewTypedAppender[row](conn, "", "", "typed")
+	require.NoError(t, err)
+	require.Error(t, typed.Append(row{B: "sad"}))
+	one := int32(1)
+	require.NoError(t, typed.Append(row{A: &one, B: "happy"}))
+	require.NoError(t, typed.Close())
+
+	var x sql.NullInt32
+	var y string
+	require.NoError(t, db.QueryRow(`SELECT a, b::VARCHAR FROM typed`).Scan(&x, &y))
+	require.Equal(t, sql.NullInt32{Int32: 1, Valid: true}, x)
+	require.Equal(t, "happy", y)
+}
@@ -0,0 +1,89 @@
+package duckdb
+
+import "time"
+
+// AppenderStats contains statistics about an Appender.
+type AppenderStats struct {
+	// Rows is the number of appended rows, including the buffered rows.
+	Rows int64
+	// BufferedRows is the number of rows appended since the last flush.
+	BufferedRows int
+	// BufferedChunks is the number of data chunks holding the rows appended since the last flush.
+	BufferedChunks int
+	// BufferedBytes is the estimated size of the rows appended since the last flush.
+	BufferedBytes int64
+	// Chunks is the number of data chunks handed to DuckDB.
+	Chunks int64
+	// Flushes is the number of flushes, including failed flushes and the flush when closing the Appender.
+	Flushes int
+	// FlushDuration is the total duration of all flushes.
+	FlushDuration time.Duration
+	// LastFlushDuration is the duration of the last flush.
+	LastFlushDuration time.Duration
+}
+
+// appenderCounters holds the counters of an Appender's statistics.
+type appenderCounters struct {
+	flushedRows       int64
+	chunks            int64
+	flushes           int
+	flushDuration     time.Duration
+	lastFlushDuration time.Duration
+}
+
+// WithFlushCallback sets a callback that the Appender invokes after each flush,
+// with the Appender's statistics and the flush's error, if any.
+// The Appender invokes it on the goroutine flushing the Appender.
+func WithFlushCallback(callback func(stats AppenderStats, err error)) AppenderOption {
+	return func(a *Appender) {
+		a.flushCallback = callback
+	}
+}
+
+// Stats returns the statistics of the Appender.
+func (a *Appender) Stats() AppenderStats {
+	buffered := a.bufferedRows()
+	return AppenderStats{
+		Rows:              a.stats.flushedRows + int64(buffered),
+		BufferedRows:      buffered,
+		BufferedChunks:    a.bufferedChunks(),
+		BufferedBytes:     a.bufferedSize(),
+		Chunks:            a.stats.chunks,
+		Flushes:           a.stats.flushes,
+		FlushDuration:     a.stats.flushDuration,
+		LastFlushDuration: a.stats.lastFlushDuration,
+	}
+}
+
+// Len returns the number of rows appended since the last flush.
+func (a *Appender) Len() int {
+	return a.bufferedRows()
+}
+
+// BufferedChunks returns the number of data chunks holding the rows appended since the last flush.
+func (a *Appender) BufferedChunks() int {
+	return a.bufferedChunks()
+}
+
+// BufferedBytes returns the estimated size of the rows appended since the last flush.
+// The estimate includes the data chunks and the length of VARCHAR and BLOB values.
+func (a *Appender) BufferedBytes() int64 {
+	return a.bufferedSize()
+}
+
+// Stats returns the statistics of the underlying Appender.
+func (t *TypedAppender[T]) Stats() AppenderStats {
+	return t.a.Stats()
+}
+
+// recordFlush updates the flush statistics, and invokes the flush callback.
+func (a *Appender) recordFlush(start time.Time, err error) {
+	d := time.Since(start)
+	a.stats.flushes++
+	a.stats.flushDuration += d
+	a.stats.lastFlushDuration = d
+
+	if a.flushCallback != nil {
+		a.flushCallback(a.Stats(), err)
+	}
+}
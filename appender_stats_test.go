@@ -0,0 +1,75 @@
+package duckdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppenderStats(t *testing.T) {
+	c, db, conn, a := prepareAppender(t, `CREATE TABLE test (id INTEGER, name VARCHAR)`)
+	defer cleanupAppender(t, c, db, conn, a)
+
+	require.Equal(t, AppenderStats{}, a.Stats())
+
+	rowCount := GetDataChunkCapacity() + 10
+	for i := 0; i < rowCount; i++ {
+		require.NoError(t, a.AppendRow(int32(i), "name"))
+	}
+	stats := a.Stats()
+	require.Equal(t, int64(rowCount), stats.Rows)
+	require.Equal(t, rowCount, stats.BufferedRows)
+	require.Equal(t, 2, stats.BufferedChunks)
+	require.Greater(t, stats.BufferedBytes, int64(0))
+	require.Equal(t, int64(1), stats.Chunks)
+	require.Equal(t, 0, stats.Flushes)
+	require.Equal(t, rowCount, a.Len())
+	require.Equal(t, 2, a.BufferedChunks())
+	require.Equal(t, stats.BufferedBytes, a.BufferedBytes())
+
+	require.NoError(t, a.Flush())
+	stats = a.Stats()
+	require.Equal(t, int64(rowCount), stats.Rows)
+	require.Equal(t, 0, stats.BufferedRows)
+	require.Equal(t, 0, stats.BufferedChunks)
+	require.Equal(t, int64(0), stats.BufferedBytes)
+	require.Equal(t, 0, a.Len())
+	require.Equal(t, 0, a.BufferedChunks())
+	require.Equal(t, int64(0), a.BufferedBytes())
+	require.Equal(t, int64(2), stats.Chunks)
+	require.Equal(t, 1, stats.Flushes)
+	require.Equal(t, stats.FlushDuration, stats.LastFlushDuration)
+}
+
+func TestAppenderFlushCallback(t *testing.T) {
+	c := newConnectorWrapper(t, ``, nil)
+	defer closeConnectorWrapper(t, c)
+	conn := openDriverConnWrapper(t, c)
+	defer closeDriverConnWrapper(t, &conn)
+
+	_, err := conn.(*Conn).ExecContext(t.Context(), `CREATE TABLE test (id INTEGER PRIMARY KEY)`, nil)
+	require.NoError(t, err)
+
+	var calls []AppenderStats
+	var errs []error
+	a, err := NewAppenderWithOptions(conn, "", "", "test", WithFlushCallback(func(stats AppenderStats, err error) {
+		calls = append(calls, stats)
+		errs = append(errs, err)
+	}))
+	require.NoError(t, err)
+
+	require.NoError(t, a.AppendRow(int32(1)))
+	require.NoError(t, a.AppendRow(int32(2)))
+	require.NoError(t, a.Flush())
+	require.NoError(t, a.AppendRow(int32(1)))
+	require.Error(t, a.Close())
+
+	require.Len(t, calls, 2)
+	require.Equal(t, int64(2), calls[0].Rows)
+	require.Equal(t, 1, calls[0].Flushes)
+	require.NoError(t, errs[0])
+	require.Equal(t, int64(3), calls[1].Rows)
+	require.Equal(t, 2, calls[1].Flushes)
+	require.GreaterOrEqual(t, calls[1].FlushDuration, calls[1].LastFlushDuration)
+	require.ErrorContains(t, errs[1], "violates primary key constraint")
+}
@@ -5,11 +5,14 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"math/big"
 	"math/rand"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -181,6 +184,160 @@ func TestAppendChunks(t *testing.T) {
 	require.Equal(t, rowCount, i)
 }
 
+func TestAppenderChunkReuse(t *testing.T) {
+	c, db, conn, a := prepareAppender(t, `CREATE TABLE test (id BIGINT, name VARCHAR, l INTEGER[])`)
+	defer cleanupAppender(t, c, db, conn, a)
+
+	// Only the first data chunk contains NULL values.
+	capacity := GetDataChunkCapacity()
+	for i := 0; i < capacity*3; i++ {
+		if i < capacity && i%2 == 0 {
+			require.NoError(t, a.AppendRow(int64(i), nil, nil))
+			continue
+		}
+		require.NoError(t, a.AppendRow(int64(i), fmt.Sprintf("name_%d", i), []int32{int32(i), int32(i)}))
+	}
+	chunk := a.chunk
+	require.NoError(t, a.Flush())
+	require.Same(t, chunk, a.spare)
+	require.NoError(t, a.AppendRow(int64(-1), "reused", []int32{-1}))
+	require.Same(t, chunk, a.chunk)
+	require.NoError(t, a.Flush())
+
+	var count, nulls, listSum int64
+	row := db.QueryRow(`SELECT count(*), count(*) FILTER (name IS NULL), sum(list_sum(l))::BIGINT FROM test`)
+	require.NoError(t, row.Scan(&count, &nulls, &listSum))
+	require.Equal(t, int64(capacity*3+1), count)
+	require.Equal(t, int64(capacity/2), nulls)
+
+	expected := int64(-1)
+	for i := 0; i < capacity*3; i++ {
+		if i >= capacity || i%2 != 0 {
+			expected += int64(2 * i)
+		}
+	}
+	require.Equal(t, expected, listSum)
+
+	var name string
+	require.NoError(t, db.QueryRow(`SELECT name FROM test WHERE id = ?`, capacity*2).Scan(&name))
+	require.Equal(t, fmt.Sprintf("name_%d", capacity*2), name)
+}
+
+func TestAppenderNullHelpers(t *testing.T) {
+	c, db, conn, a := prepareAppender(t, `CREATE TABLE test (id BIGINT, s STRUCT(a INTEGER), v VARCHAR)`)
+	defer cleanupAppender(t, c, db, conn, a)
+
+	require.NoError(t, a.AppendNullRow())
+	require.NoError(t, a.AppendRow(int64(1), map[string]any{"a": int32(1)}, "x"))
+
+	chunk, err := a.NewDataChunk()
+	require.NoError(t, err)
+	defer chunk.Close()
+	for i := 0; i < 200; i++ {
+		require.NoError(t, chunk.SetValue(0, i, int64(i+2)))
+		require.NoError(t, chunk.SetValue(1, i, map[string]any{"a": int32(i)}))
+		require.NoError(t, chunk.SetValue(2, i, "y"))
+	}
+	require.NoError(t, chunk.SetNullRange(1, 10, 130))
+	require.NoError(t, chunk.SetNullRange(2, 64, 64))
+	require.NoError(t, chunk.SetSize(200))
+	require.NoError(t, a.AppendChunk(chunk))
+
+	err = chunk.SetNullRange(3, 0, 1)
+	testError(t, err, errAPI.Error(), columnCountErrMsg)
+	err = chunk.SetNullRange(0, GetDataChunkCapacity(), 1)
+	testError(t, err, errAPI.Error(), errVectorSize.Error())
+	require.NoError(t, a.Flush())
+
+	var count, structNulls, varcharNulls, minNull, maxNull int
+	require.NoError(t, db.QueryRow(`SELECT count(*), count(*) FILTER (s IS NULL), count(*) FILTER (v IS NULL),
+		min(id) FILTER (s IS NULL), max(id) FILTER (s IS NULL) FROM test WHERE id IS NOT NULL`).Scan(
+		&count, &structNulls, &varcharNulls, &minNull, &maxNull))
+	require.Equal(t, 201, count)
+	require.Equal(t, 130, structNulls)
+	require.Equal(t, 64, varcharNulls)
+	require.Equal(t, 12, minNull)
+	require.Equal(t, 141, maxNull)
+
+	var nullRows int
+	require.NoError(t, db.QueryRow(`SELECT count(*) FROM test WHERE id IS NULL AND s IS NULL AND v IS NULL`).Scan(&nullRows))
+	require.Equal(t, 1, nullRows)
+}
+
+func TestAppenderAppendChunk(t *testing.T) {
+	c, db, conn, a := prepareAppender(t, `CREATE TABLE test (id BIGINT, name VARCHAR); CREATE TABLE other (d DOUBLE, i INTEGER); CREATE TABLE small (i INTEGER)`)
+	defer cleanupAppender(t, c, db, conn, a)
+
+	chunk, err := a.NewDataChunk()
+	require.NoError(t, err)
+	defer chunk.Close()
+	require.Equal(t, GetDataChunkCapacity(), chunk.GetSize())
+
+	require.NoError(t, a.AppendRow(int64(0), "row"))
+	for i := 0; i < 3; i++ {
+		require.NoError(t, chunk.SetValue(0, i, int64(i+1)))
+		require.NoError(t, chunk.SetValue(1, i, fmt.Sprintf("chunk_%d", i+1)))
+	}
+	require.NoError(t, chunk.SetSize(3))
+	require.NoError(t, a.AppendChunk(chunk))
+	require.Equal(t, 4, a.Stats().BufferedRows)
+
+	// Reuse the chunk.
+	require.NoError(t, chunk.SetValue(0, 0, int64(4)))
+	require.NoError(t, chunk.SetValue(1, 0, nil))
+	require.NoError(t, chunk.SetSize(1))
+	require.NoError(t, a.AppendChunk(chunk))
+	require.NoError(t, a.AppendRow(int64(5), "row"))
+	require.NoError(t, a.Flush())
+
+	res, err := db.Query(`SELECT id, name FROM test ORDER BY rowid`)
+	require.NoError(t, err)
+	defer closeRowsWrapper(t, res)
+
+	var rows [][2]any
+	for res.Next() {
+		var r [2]any
+		require.NoError(t, res.Scan(&r[0], &r[1]))
+		rows = append(rows, r)
+	}
+	require.NoError(t, res.Err())
+	require.Equal(t, [][2]any{
+		{int64(0), "row"},
+		{int64(1), "chunk_1"},
+		{int64(2), "chunk_2"},
+		{int64(3), "chunk_3"},
+		{int64(4), nil},
+		{int64(5), "row"},
+	}, rows)
+
+	// DuckDB casts chunks of other types.
+	otherAppender, err := NewAppender(conn, "", "", "other")
+	require.NoError(t, err)
+	defer closeAppenderWrapper(t, otherAppender)
+	otherChunk, err := otherAppender.NewDataChunk()
+	require.NoError(t, err)
+	defer otherChunk.Close()
+	require.NoError(t, otherChunk.SetValue(0, 0, 6.0))
+	require.NoError(t, otherChunk.SetValue(1, 0, int32(6)))
+	require.NoError(t, otherChunk.SetSize(1))
+	require.NoError(t, a.AppendChunk(otherChunk))
+	require.NoError(t, a.Flush())
+
+	var name string
+	require.NoError(t, db.QueryRow(`SELECT name FROM test WHERE id = 6`).Scan(&name))
+	require.Equal(t, "6", name)
+
+	// The chunk's column count must match the table's column count.
+	smallAppender, err := NewAppender(conn, "", "", "small")
+	require.NoError(t, err)
+	defer closeAppenderWrapper(t, smallAppender)
+	smallChunk, err := smallAppender.NewDataChunk()
+	require.NoError(t, err)
+	defer smallChunk.Close()
+	err = a.AppendChunk(smallChunk)
+	testError(t, err, errAppenderAppendRow.Error(), columnCountErrMsg)
+}
+
 func TestAppenderList(t *testing.T) {
 	c, db, conn, a := prepareAppender(t, `
 	CREATE TABLE test (
@@ -216,6 +373,405 @@ func TestAppenderList(t *testing.T) {
 	require.Equal(t, 10, i)
 }
 
+func TestAppenderAppendColumns(t *testing.T) {
+	c, db, conn, a := prepareAppender(t, `CREATE TABLE test (id BIGINT, score DOUBLE, small INTEGER, name VARCHAR, tags VARCHAR[])`)
+	defer cleanupAppender(t, c, db, conn, a)
+
+	// Span multiple data chunks.
+	rowCount := GetDataChunkCapacity()*2 + 10
+	ids := make([]int64, rowCount)
+	scores := make([]float64, rowCount)
+	smalls := make([]int16, rowCount)
+	names := make([]string, rowCount)
+	tags := make([]any, rowCount)
+	for i := 0; i < rowCount; i++ {
+		ids[i] = int64(i)
+		scores[i] = float64(i) / 2
+		smalls[i] = int16(i % 100)
+		names[i] = fmt.Sprintf("name_%d", i)
+		if i%2 == 0 {
+			tags[i] = []string{"even"}
+		}
+	}
+
+	require.NoError(t, a.AppendRow(int64(-1), 0.5, int32(1), "row", nil))
+	require.NoError(t, a.AppendColumns(ids, scores, smalls, names, tags))
+	require.NoError(t, a.AppendColumns(ids[:3], scores[:3], smalls[:3], names[:3], tags[:3]))
+
+	// Invalid columns.
+	err := a.AppendColumns(ids, scores)
+	testError(t, err, errAppenderAppendRow.Error(), columnCountErrMsg)
+	err = a.AppendColumns(ids, scores, smalls, names[:1], tags)
+	testError(t, err, errAppenderAppendRow.Error(), invalidInputErrMsg)
+	err = a.AppendColumns(ids, scores, smalls, "name", tags)
+	testError(t, err, errAppenderAppendRow.Error(), invalidInputErrMsg)
+	require.NoError(t, a.Flush())
+
+	// Verify results.
+	var count, idSum, evenCount int64
+	row := db.QueryRow(`SELECT count(*), sum(id)::BIGINT, count(tags) FROM test`)
+	require.NoError(t, row.Scan(&count, &idSum, &evenCount))
+	require.Equal(t, int64(rowCount+4), count)
+	require.Equal(t, int64(rowCount*(rowCount-1)/2-1+3), idSum)
+
+	var name string
+	var score float64
+	require.NoError(t, db.QueryRow(`SELECT name, score FROM test WHERE id = ?`, rowCount-1).Scan(&name, &score))
+	require.Equal(t, fmt.Sprintf("name_%d", rowCount-1), name)
+	require.Equal(t, float64(rowCount-1)/2, score)
+	require.Equal(t, int64(rowCount/2+2), evenCount)
+}
+
+func TestAppenderAppendRowMap(t *testing.T) {
+	c, db, conn, a := prepareAppender(t, `CREATE TABLE test (id INTEGER, "Name" VARCHAR, score DOUBLE)`)
+	defer cleanupAppender(t, c, db, conn, a)
+
+	require.NoError(t, a.AppendRowMap(map[string]driver.Value{"score": 1.5, "id": int32(1), "Name": "a"}))
+	require.NoError(t, a.AppendRowMap(map[string]driver.Value{"NAME": "b", "ID": int32(2)}))
+	require.NoError(t, a.AppendRowMap(map[string]driver.Value{}))
+
+	err := a.AppendRowMap(map[string]driver.Value{"id": int32(3), "unknown": 1})
+	testError(t, err, errAppenderAppendRow.Error(), invalidInputErrMsg, "unknown")
+	err = a.AppendRowMap(map[string]driver.Value{"name": "c", "NAME": "d"})
+	testError(t, err, errAppenderAppendRow.Error(), duplicateNameErrMsg)
+	require.NoError(t, a.Flush())
+
+	// Verify results.
+	res, err := db.QueryContext(context.Background(), `SELECT * FROM test ORDER BY id NULLS LAST`)
+	require.NoError(t, err)
+	defer closeRowsWrapper(t, res)
+
+	var rows [][3]any
+	for res.Next() {
+		var r [3]any
+		require.NoError(t, res.Scan(&r[0], &r[1], &r[2]))
+		rows = append(rows, r)
+	}
+	require.Equal(t, [][3]any{{int32(1), "a", 1.5}, {int32(2), "b", nil}, {nil, nil, nil}}, rows)
+}
+
+func TestAppenderTrustedTypes(t *testing.T) {
+	c, db, conn, a := prepareAppender(t, `CREATE TABLE test (b BOOLEAN, i INTEGER, u UBIGINT, f FLOAT, s VARCHAR, bl BLOB, j JSON, ts TIMESTAMP)`)
+	defer cleanupAppender(t, c, db, conn, a)
+
+	trusted, err := NewAppenderWithOptions(conn, "", "", "test", WithTrustedTypes())
+	require.NoError(t, err)
+
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	require.NoError(t, trusted.AppendRow(true, int32(1), uint64(2), float32(1.5), "s", []byte{1}, map[string]any{"a": 1}, ts))
+	// Values of other types go through the regular conversion.
+	require.NoError(t, trusted.AppendRow(nil, int8(2), 3, 2.5, []byte("t"), "x", nil, nil))
+	err = trusted.AppendRow(true, "x", uint64(2), float32(1.5), "s", []byte{1}, nil, ts)
+	testError(t, err, errAppenderAppendRow.Error(), castErrMsg)
+	require.NoError(t, trusted.Close())
+
+	res, err := db.Query(`SELECT b, i, u, f, s, bl, j::VARCHAR, ts FROM test ORDER BY i`)
+	require.NoError(t, err)
+	defer closeRowsWrapper(t, res)
+
+	var rows [][8]any
+	for res.Next() {
+		var r [8]any
+		require.NoError(t, res.Scan(&r[0], &r[1], &r[2], &r[3], &r[4], &r[5], &r[6], &r[7]))
+		rows = append(rows, r)
+	}
+	require.NoError(t, res.Err())
+	require.Equal(t, [][8]any{
+		{true, int32(1), uint64(2), float32(1.5), "s", []byte{1}, `{"a":1}`, ts},
+		{nil, int32(2), uint64(3), float32(2.5), "t", []byte("x"), nil, nil},
+	}, rows)
+}
+
+func TestAppenderAppendRows(t *testing.T) {
+	c, db, conn, a := prepareAppender(t, `CREATE TABLE test (id INTEGER, name VARCHAR)`)
+	defer cleanupAppender(t, c, db, conn, a)
+
+	rows := make([][]driver.Value, GetDataChunkCapacity()+10)
+	for i := range rows {
+		rows[i] = []driver.Value{int32(i), fmt.Sprintf("name_%d", i)}
+	}
+	require.NoError(t, a.AppendRows(rows))
+	require.NoError(t, a.AppendRows(nil))
+	require.Equal(t, len(rows), a.Len())
+
+	// The rows before the failing row are loaded.
+	err := a.AppendRows([][]driver.Value{{int32(-1), "a"}, {int32(-2)}, {int32(-3), "c"}})
+	testError(t, err, errAppenderAppendRow.Error(), columnCountErrMsg, rowIndexErrMsg+": 1")
+	err = a.AppendRows([][]driver.Value{{"x", "b"}})
+	testError(t, err, errAppenderAppendRow.Error(), castErrMsg, rowIndexErrMsg+": 0")
+	require.NoError(t, a.Flush())
+
+	var count, maxID, minID int
+	require.NoError(t, db.QueryRow(`SELECT count(*), max(id), min(id) FROM test`).Scan(&count, &maxID, &minID))
+	require.Equal(t, len(rows)+1, count)
+	require.Equal(t, len(rows)-1, maxID)
+	require.Equal(t, -1, minID)
+}
+
+func TestAppenderAppendRowWithMap(t *testing.T) {
+	c, db, conn, a := prepareAppender(t, `CREATE TABLE test (id INTEGER, "Name" VARCHAR, doc JSON)`)
+	defer cleanupAppender(t, c, db, conn, a)
+
+	require.NoError(t, a.AppendRow(map[string]any{"ID": int32(1), "name": "a", "doc": map[string]any{"k": "v"}}))
+	require.NoError(t, a.AppendRow(map[string]driver.Value{"id": int32(2)}))
+	err := a.AppendRow(map[string]any{"unknown": 1})
+	testError(t, err, errAppenderAppendRow.Error(), invalidInputErrMsg, "unknown")
+	require.NoError(t, a.Flush())
+
+	res, err := db.Query(`SELECT id, "Name", doc::VARCHAR FROM test ORDER BY id`)
+	require.NoError(t, err)
+	defer closeRowsWrapper(t, res)
+
+	var rows [][3]any
+	for res.Next() {
+		var r [3]any
+		require.NoError(t, res.Scan(&r[0], &r[1], &r[2]))
+		rows = append(rows, r)
+	}
+	require.NoError(t, res.Err())
+	require.Equal(t, [][3]any{{int32(1), "a", `{"k":"v"}`}, {int32(2), nil, nil}}, rows)
+
+	// Match the column names exactly.
+	exact, err := NewAppenderWithOptions(conn, "", "", "test", WithExactColumnNames())
+	require.NoError(t, err)
+	require.NoError(t, exact.AppendRow(map[string]any{"id": int32(3), "Name": "b"}))
+	err = exact.AppendRow(map[string]any{"ID": int32(4)})
+	testError(t, err, errAppenderAppendRow.Error(), invalidInputErrMsg, "ID")
+	err = exact.SetDefault("name", "x")
+	testError(t, err, errAppenderSetDefault.Error(), invalidInputErrMsg, "name")
+	require.NoError(t, exact.Close())
+
+	var name string
+	require.NoError(t, db.QueryRow(`SELECT "Name" FROM test WHERE id = 3`).Scan(&name))
+	require.Equal(t, "b", name)
+}
+
+func TestAppenderAppendRowWithMapSingleColumn(t *testing.T) {
+	c, db, conn, a := prepareAppender(t, `CREATE TABLE test (s STRUCT(id INTEGER))`)
+	defer cleanupAppender(t, c, db, conn, a)
+
+	// A map appends to the STRUCT column.
+	require.NoError(t, a.AppendRow(map[string]any{"id": int32(1)}))
+	require.NoError(t, a.Flush())
+
+	var id int32
+	require.NoError(t, db.QueryRow(`SELECT s.id FROM test`).Scan(&id))
+	require.Equal(t, int32(1), id)
+}
+
+func TestAppenderFlushContext(t *testing.T) {
+	c, db, conn, a := prepareAppender(t, `CREATE TABLE test (id INTEGER)`)
+	defer closeConnectorWrapper(t, c)
+	defer closeDbWrapper(t, db)
+	defer closeDriverConnWrapper(t, &conn)
+
+	require.NoError(t, a.AppendRow(int32(1)))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// The appender keeps its rows, if the context is done before flushing.
+	require.ErrorIs(t, a.FlushContext(ctx), context.Canceled)
+	require.NoError(t, a.FlushContext(context.Background()))
+
+	var count int
+	require.NoError(t, db.QueryRow(`SELECT count(*) FROM test`).Scan(&count))
+	require.Equal(t, 1, count)
+
+	// Closing with a done context discards the buffered rows.
+	require.NoError(t, a.AppendRow(int32(2)))
+	require.ErrorIs(t, a.CloseContext(ctx), context.Canceled)
+	testError(t, a.CloseContext(context.Background()), errAppenderDoubleClose.Error())
+
+	require.NoError(t, db.QueryRow(`SELECT count(*) FROM test`).Scan(&count))
+	require.Equal(t, 1, count)
+
+	// The connection remains usable.
+	_, err := conn.(*Conn).ExecContext(context.Background(), `INSERT INTO test VALUES (3)`, nil)
+	require.NoError(t, err)
+}
+
+func TestAppenderSetDefault(t *testing.T) {
+	c, db, conn, a := prepareAppender(t, `CREATE TABLE test (id INTEGER, source VARCHAR, row_id BIGINT)`)
+	defer cleanupAppender(t, c, db, conn, a)
+
+	var next int64
+	require.NoError(t, a.SetDefault("SOURCE", "import"))
+	require.NoError(t, a.SetDefault("row_id", func() driver.Value {
+		next++
+		return next
+	}))
+	err := a.SetDefault("unknown", 1)
+	testError(t, err, errAppenderSetDefault.Error(), invalidInputErrMsg, "unknown")
+
+	require.NoError(t, a.AppendRowMap(map[string]driver.Value{"id": int32(1)}))
+	require.NoError(t, a.AppendRowMap(map[string]driver.Value{"id": int32(2), "source": nil}))
+	require.NoError(t, a.SetDefault("source", nil))
+	require.NoError(t, a.AppendRowMap(map[string]driver.Value{"id": int32(3)}))
+	// AppendRow does not use the default values.
+	require.NoError(t, a.AppendRow(int32(4), "row", int64(42)))
+	require.NoError(t, a.Flush())
+
+	res, err := db.Query(`SELECT * FROM test ORDER BY id`)
+	require.NoError(t, err)
+	defer closeRowsWrapper(t, res)
+
+	var rows [][3]any
+	for res.Next() {
+		var r [3]any
+		require.NoError(t, res.Scan(&r[0], &r[1], &r[2]))
+		rows = append(rows, r)
+	}
+	require.NoError(t, res.Err())
+	require.Equal(t, [][3]any{
+		{int32(1), "import", int64(1)},
+		{int32(2), nil, int64(2)},
+		{int32(3), nil, int64(3)},
+		{int32(4), "row", int64(42)},
+	}, rows)
+}
+
+func TestAppenderWithColumns(t *testing.T) {
+	c := newConnectorWrapper(t, ``, nil)
+	defer closeConnectorWrapper(t, c)
+	db := sql.OpenDB(c)
+	defer closeDbWrapper(t, db)
+	conn := openDriverConnWrapper(t, c)
+	defer closeDriverConnWrapper(t, &conn)
+
+	createTable(t, db, `CREATE TABLE test (
+		id INTEGER PRIMARY KEY,
+		name VARCHAR,
+		created VARCHAR DEFAULT 'now',
+		note VARCHAR
+	)`)
+
+	a, err := NewAppenderWithOptions(conn, "", "", "test", WithColumns("name", "id"))
+	require.NoError(t, err)
+	require.NoError(t, a.AppendRow("a", int32(1)))
+	require.NoError(t, a.AppendRowMap(map[string]driver.Value{"ID": int32(2)}))
+	err = a.AppendRow("c", int32(3), "extra")
+	testError(t, err, errAppenderAppendRow.Error(), columnCountErrMsg)
+	require.NoError(t, a.Close())
+
+	// The conflict modes support subsets of columns.
+	a, err = NewAppenderWithOptions(conn, "", "", "test", WithColumns("id", "name"), WithConflictMode(ConflictReplace))
+	require.NoError(t, err)
+	require.NoError(t, a.AppendRow(int32(2), "b"))
+	require.NoError(t, a.AppendRow(int32(3), "c"))
+	require.NoError(t, a.Close())
+
+	// Unknown columns fail.
+	_, err = NewAppenderWithOptions(conn, "", "", "test", WithColumns("unknown"))
+	testError(t, err, errAppenderCreation.Error())
+
+	res, err := db.Query(`SELECT id, name, created, note FROM test ORDER BY id`)
+	require.NoError(t, err)
+	defer closeRowsWrapper(t, res)
+
+	var rows [][4]any
+	for res.Next() {
+		var r [4]any
+		require.NoError(t, res.Scan(&r[0], &r[1], &r[2], &r[3]))
+		rows = append(rows, r)
+	}
+	require.Equal(t, [][4]any{
+		{int32(1), "a", "now", nil},
+		{int32(2), "b", "now", nil},
+		{int32(3), "c", "now", nil},
+	}, rows)
+}
+
+func TestAppenderMaxBufferedBytes(t *testing.T) {
+	c := newConnectorWrapper(t, ``, nil)
+	defer closeConnectorWrapper(t, c)
+	db := sql.OpenDB(c)
+	defer closeDbWrapper(t, db)
+	conn := openDriverConnWrapper(t, c)
+	defer closeDriverConnWrapper(t, &conn)
+
+	createTable(t, db, `CREATE TABLE test (id INTEGER, payload VARCHAR)`)
+	a, err := NewAppenderWithOptions(conn, "", "", "test", WithMaxBufferedBytes(1<<20))
+	require.NoError(t, err)
+
+	payload := strings.Repeat("x", 10*1024)
+	for i := 0; i < 1000; i++ {
+		require.NoError(t, a.AppendRow(int32(i), payload))
+		require.Less(t, a.bufferedBytes, int64(1<<20))
+	}
+
+	// The appender flushed the rows before closing.
+	var count int
+	require.NoError(t, db.QueryRow(`SELECT count(*) FROM test`).Scan(&count))
+	require.Greater(t, count, 900)
+	require.NoError(t, a.Close())
+	require.NoError(t, db.QueryRow(`SELECT count(*) FROM test`).Scan(&count))
+	require.Equal(t, 1000, count)
+
+	// Without a budget, the appender buffers all rows.
+	a, err = NewAppenderWithOptions(conn, "", "", "test")
+	require.NoError(t, err)
+	for i := 0; i < 1000; i++ {
+		require.NoError(t, a.AppendRow(int32(i), payload))
+	}
+	require.NoError(t, db.QueryRow(`SELECT count(*) FROM test`).Scan(&count))
+	require.Equal(t, 1000, count)
+	require.NoError(t, a.Close())
+}
+
+func TestAppenderStructTags(t *testing.T) {
+	type address struct {
+		Street string `duckdb:"street" db:"ignored"`
+		Zip    *int32 `db:"zip"`
+	}
+	type person struct {
+		Name    string
+		Address *address `duckdb:"addr"`
+		Tags    []string
+		Secret  string `db:"-"`
+		hidden  int
+	}
+
+	c, db, conn, a := prepareAppender(t, `CREATE TABLE test (
+		p STRUCT(name VARCHAR, addr STRUCT(street VARCHAR, zip INT), tags VARCHAR[])
+	)`)
+	defer cleanupAppender(t, c, db, conn, a)
+
+	zip := int32(10115)
+	require.NoError(t, a.AppendRow(person{
+		Name:    "a",
+		Address: &address{Street: "main", Zip: &zip},
+		Tags:    []string{"x", "y"},
+		Secret:  "s",
+		hidden:  1,
+	}))
+	require.NoError(t, a.AppendRow(&person{Name: "b", Address: &address{Street: "side"}}))
+	require.NoError(t, a.AppendRow(person{Name: "c"}))
+	require.NoError(t, a.AppendRow((*person)(nil)))
+	require.NoError(t, a.Flush())
+
+	res, err := db.Query(`SELECT p.name, p.addr.street, p.addr.zip, len(p.tags), p IS NULL FROM test`)
+	require.NoError(t, err)
+	defer closeRowsWrapper(t, res)
+
+	var rows [][5]any
+	for res.Next() {
+		var r [5]any
+		require.NoError(t, res.Scan(&r[0], &r[1], &r[2], &r[3], &r[4]))
+		rows = append(rows, r)
+	}
+	require.NoError(t, res.Err())
+	require.Equal(t, [][5]any{
+		{"a", "main", int32(10115), int64(2), false},
+		{"b", "side", nil, int64(0), false},
+		{"c", nil, nil, int64(0), false},
+		{nil, nil, nil, nil, true},
+	}, rows)
+
+	// Struct types missing a field of the STRUCT fail.
+	err = a.AppendRow(struct{ Name string }{Name: "d"})
+	testError(t, err, errAppenderAppendRow.Error(), structFieldErrMsg)
+}
+
 func TestAppenderArray(t *testing.T) {
 	c, db, conn, a := prepareAppender(t, `CREATE TABLE test (string_array VARCHAR[3])`)
 	defer cleanupAppender(t, c, db, conn, a)
@@ -243,6 +799,43 @@ func TestAppenderArray(t *testing.T) {
 	require.Equal(t, 2*count, i)
 }
 
+func TestAppenderNestedArray(t *testing.T) {
+	c, db, conn, a := prepareAppender(t, `CREATE TABLE test (matrix FLOAT[2][3], cube INT[2][2][2])`)
+	defer cleanupAppender(t, c, db, conn, a)
+
+	matrix := [3][2]float32{{1, 2}, {3, 4}, {5, 6}}
+	cube := [2][2][2]int32{{{1, 2}, {3, 4}}, {{5, 6}, {7, 8}}}
+	require.NoError(t, a.AppendRow(matrix, cube))
+	require.NoError(t, a.AppendRow([][]float32{{1, 2}, {3, 4}, {5, 6}}, [][][]int32{{{1, 2}, {3, 4}}, {{5, 6}, {7, 8}}}))
+	require.NoError(t, a.AppendRow([]any{[]float32{1, 2}, nil, [2]float32{5, 6}}, nil))
+
+	// The nested ARRAYs must have the correct size.
+	err := a.AppendRow([][]float32{{1, 2, 3}, {3, 4}, {5, 6}}, cube)
+	testError(t, err, errAppenderAppendRow.Error(), invalidInputErrMsg)
+	require.NoError(t, a.Flush())
+
+	// Verify results.
+	res, err := db.QueryContext(context.Background(), `SELECT * FROM test`)
+	require.NoError(t, err)
+	defer closeRowsWrapper(t, res)
+
+	i := 0
+	for res.Next() {
+		var m Composite[[3][2]float32]
+		var cb Composite[*[2][2][2]int32]
+		require.NoError(t, res.Scan(&m, &cb))
+		if i < 2 {
+			require.Equal(t, matrix, m.Get())
+			require.Equal(t, cube, *cb.Get())
+		} else {
+			require.Equal(t, [3][2]float32{{1, 2}, {}, {5, 6}}, m.Get())
+			require.Nil(t, cb.Get())
+		}
+		i++
+	}
+	require.Equal(t, 3, i)
+}
+
 func TestAppenderNested(t *testing.T) {
 	c, db, conn, a := prepareAppender(t, createNestedDataTableSQL)
 	defer cleanupAppender(t, c, db, conn, a)
@@ -494,6 +1087,40 @@ func TestAppenderUUID(t *testing.T) {
 	}
 }
 
+func TestAppenderGoogleUUID(t *testing.T) {
+	c, db, conn, a := prepareAppender(t, `CREATE TABLE test (id UUID, str VARCHAR)`)
+	defer cleanupAppender(t, c, db, conn, a)
+
+	id := uuid.New()
+	otherId := uuid.New()
+	require.NoError(t, a.AppendRow(id, id))
+	require.NoError(t, a.AppendRow(&otherId, &otherId))
+	require.NoError(t, a.AppendRow((*uuid.UUID)(nil), (*uuid.UUID)(nil)))
+	require.NoError(t, a.Flush())
+
+	// Verify results.
+	res, err := db.QueryContext(context.Background(), `SELECT id, str FROM test ORDER BY rowid`)
+	require.NoError(t, err)
+	defer closeRowsWrapper(t, res)
+
+	expected := []*uuid.UUID{&id, &otherId, nil}
+	i := 0
+	for res.Next() {
+		var r *uuid.UUID
+		var str *string
+		require.NoError(t, res.Scan(&r, &str))
+		if expected[i] == nil {
+			require.Nil(t, r)
+			require.Nil(t, str)
+		} else {
+			require.Equal(t, *expected[i], *r)
+			require.Equal(t, expected[i].String(), *str)
+		}
+		i++
+	}
+	require.Equal(t, len(expected), i)
+}
+
 func newAppenderHugeIntTest[T numericType](val T, c *Connector, db *sql.DB, a *Appender) func(t *testing.T) {
 	return func(t *testing.T) {
 		typeName := reflect.TypeOf(val).String()
@@ -546,6 +1173,107 @@ func TestAppenderTsNs(t *testing.T) {
 	require.Equal(t, ts, r)
 }
 
+func TestAppenderTimestampNS(t *testing.T) {
+	c, db, conn, a := prepareAppender(t, `CREATE TABLE test (id INTEGER, ns TIMESTAMP_NS, us TIMESTAMP)`)
+	defer cleanupAppender(t, c, db, conn, a)
+
+	// The full range of TIMESTAMP_NS, including the years 1677 and 2262.
+	minNS := TimestampNS(-math.MaxInt64 + 1)
+	maxNS := TimestampNS(math.MaxInt64 - 1)
+	require.NoError(t, a.AppendRow(int32(1), minNS, minNS))
+	require.NoError(t, a.AppendRow(int32(2), maxNS.Time(), maxNS))
+	require.NoError(t, a.AppendRow(int32(3), time.Date(1677, time.December, 31, 0, 0, 0, 1, time.UTC), nil))
+
+	err := a.AppendRow(int32(4), time.Date(1677, time.January, 1, 0, 0, 0, 0, time.UTC), nil)
+	testError(t, err, errAppenderAppendRow.Error(), convertErrMsg)
+	err = a.AppendRow(int32(4), time.Date(2262, time.December, 31, 0, 0, 0, 0, time.UTC), nil)
+	testError(t, err, errAppenderAppendRow.Error(), convertErrMsg)
+	require.NoError(t, a.Flush())
+
+	res, err := db.Query(`SELECT ns, us FROM test ORDER BY id`)
+	require.NoError(t, err)
+	defer closeRowsWrapper(t, res)
+
+	var values []TimestampNS
+	var micros []time.Time
+	for res.Next() {
+		var ns TimestampNS
+		var us sql.NullTime
+		require.NoError(t, res.Scan(&ns, &us))
+		values = append(values, ns)
+		micros = append(micros, us.Time)
+	}
+	require.NoError(t, res.Err())
+	require.Equal(t, []TimestampNS{minNS, maxNS, TimestampNS(time.Date(1677, time.December, 31, 0, 0, 0, 1, time.UTC).UnixNano())}, values)
+	require.Equal(t, minNS.Time().Truncate(time.Microsecond), micros[0])
+	require.Equal(t, maxNS.Time().Truncate(time.Microsecond), micros[1])
+
+	// Bind and interpolate TimestampNS values.
+	var ns TimestampNS
+	require.NoError(t, db.QueryRow(`SELECT ?::TIMESTAMP_NS`, minNS).Scan(&ns))
+	require.Equal(t, minNS, ns)
+	require.NoError(t, db.QueryRowContext(WithInterpolation(context.Background()), `SELECT ?`, maxNS).Scan(&ns))
+	require.Equal(t, maxNS, ns)
+
+	// Scan, bind, and interpolate the infinities.
+	for _, inf := range []TimestampNS{TimestampNSInfinity, TimestampNSNegInfinity} {
+		var str string
+		require.NoError(t, db.QueryRow(`SELECT ?::TIMESTAMP_NS, ?::TIMESTAMP_NS::VARCHAR`, inf, inf).Scan(&ns, &str))
+		require.Equal(t, inf, ns)
+		require.Contains(t, str, "infinity")
+		require.NoError(t, db.QueryRowContext(WithInterpolation(context.Background()), `SELECT ?`, inf).Scan(&ns))
+		require.Equal(t, inf, ns)
+	}
+	require.NoError(t, db.QueryRow(`SELECT '-infinity'::TIMESTAMP_NS`).Scan(&ns))
+	require.Equal(t, TimestampNSNegInfinity, ns)
+}
+
+func TestAppenderEnum(t *testing.T) {
+	// 300 values require a USMALLINT dictionary index.
+	values := make([]string, 300)
+	for i := range values {
+		values[i] = QuoteLiteral(fmt.Sprintf("v%d", i))
+	}
+	c, db, conn, a := prepareAppender(t, `CREATE TYPE big_enum AS ENUM (`+strings.Join(values, ", ")+`);
+		CREATE TYPE small_enum AS ENUM ('a', 'b');
+		CREATE TABLE test (id INTEGER, big big_enum, small small_enum)`)
+	defer cleanupAppender(t, c, db, conn, a)
+
+	dict, err := a.EnumDictionary(1)
+	require.NoError(t, err)
+	require.Len(t, dict, 300)
+	require.Equal(t, uint32(299), dict["v299"])
+	_, err = a.EnumDictionary(0)
+	testError(t, err, errAPI.Error(), invalidInputErrMsg)
+	_, err = a.EnumDictionary(3)
+	testError(t, err, errAPI.Error(), columnCountErrMsg)
+
+	require.NoError(t, a.AppendRow(int32(1), "v299", "b"))
+	require.NoError(t, a.AppendRow(int32(2), dict["v256"], uint32(0)))
+	require.NoError(t, a.AppendRow(int32(3), nil, nil))
+
+	err = a.AppendRow(int32(4), "unknown", "a")
+	testError(t, err, errAppenderAppendRow.Error(), castErrMsg)
+	err = a.AppendRow(int32(4), "v1", uint32(2))
+	testError(t, err, errAppenderAppendRow.Error(), invalidInputErrMsg)
+	err = a.AppendRow(int32(4), 1, "a")
+	testError(t, err, errAppenderAppendRow.Error(), castErrMsg)
+	require.NoError(t, a.Flush())
+
+	res, err := db.Query(`SELECT big, small FROM test ORDER BY id`)
+	require.NoError(t, err)
+	defer closeRowsWrapper(t, res)
+
+	var rows [][2]any
+	for res.Next() {
+		var r [2]any
+		require.NoError(t, res.Scan(&r[0], &r[1]))
+		rows = append(rows, r)
+	}
+	require.NoError(t, res.Err())
+	require.Equal(t, [][2]any{{"v299", "b"}, {"v256", "a"}, {nil, nil}}, rows)
+}
+
 func TestAppenderDate(t *testing.T) {
 	c, db, conn, a := prepareAppender(t, `CREATE TABLE test (date DATE)`)
 	defer cleanupAppender(t, c, db, conn, a)
@@ -729,6 +1457,156 @@ func TestAppenderDecimal(t *testing.T) {
 	require.Equal(t, 3, i)
 }
 
+type testMoney struct {
+	cents int64
+}
+
+func (m testMoney) Value() (driver.Value, error) {
+	return m.cents, nil
+}
+
+type testUserID string
+
+func (id *testUserID) Value() (driver.Value, error) {
+	if *id == "" {
+		return nil, errors.New("empty user ID")
+	}
+	return "user-" + string(*id), nil
+}
+
+func TestAppenderValuer(t *testing.T) {
+	c, db, conn, a := prepareAppender(t, `CREATE TABLE test (id INTEGER, cents BIGINT, user_id VARCHAR, l BIGINT[])`)
+	defer cleanupAppender(t, c, db, conn, a)
+
+	id := testUserID("a")
+	empty := testUserID("")
+	require.NoError(t, a.AppendRow(int32(1), testMoney{cents: 150}, &id, []testMoney{{cents: 1}, {cents: 2}}))
+	require.NoError(t, a.AppendRow(int32(2), &testMoney{cents: 5}, (*testUserID)(nil), nil))
+	err := a.AppendRow(int32(3), testMoney{}, &empty, nil)
+	testError(t, err, errAppenderAppendRow.Error(), "empty user ID", indexErrMsg)
+	require.NoError(t, a.Flush())
+
+	res, err := db.Query(`SELECT cents, user_id, l::VARCHAR FROM test ORDER BY id`)
+	require.NoError(t, err)
+	defer closeRowsWrapper(t, res)
+
+	var rows [][3]any
+	for res.Next() {
+		var r [3]any
+		require.NoError(t, res.Scan(&r[0], &r[1], &r[2]))
+		rows = append(rows, r)
+	}
+	require.NoError(t, res.Err())
+	require.Equal(t, [][3]any{
+		{int64(150), "user-a", "[1, 2]"},
+		{int64(5), nil, nil},
+	}, rows)
+}
+
+func TestAppenderNullValues(t *testing.T) {
+	c, db, conn, a := prepareAppender(t, `CREATE TABLE test (
+		id INTEGER,
+		s VARCHAR,
+		i BIGINT,
+		f DOUBLE,
+		b BOOLEAN,
+		ts TIMESTAMP,
+		h HUGEINT,
+		l INTEGER[]
+	)`)
+	defer cleanupAppender(t, c, db, conn, a)
+
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	i := int64(42)
+	require.NoError(t, a.AppendRow(
+		int32(1),
+		sql.NullString{String: "a", Valid: true},
+		sql.NullInt64{Int64: 7, Valid: true},
+		sql.NullFloat64{Float64: 1.5, Valid: true},
+		sql.NullBool{Bool: true, Valid: true},
+		sql.NullTime{Time: ts, Valid: true},
+		big.NewInt(3),
+		[]*int32{nil, new(int32)},
+	))
+	require.NoError(t, a.AppendRow(
+		int32(2),
+		sql.NullString{},
+		sql.NullInt64{},
+		sql.NullFloat64{},
+		sql.NullBool{},
+		sql.NullTime{},
+		(*big.Int)(nil),
+		[]sql.NullInt32{{Int32: 5, Valid: true}, {}},
+	))
+	require.NoError(t, a.AppendRow(
+		int32(3),
+		(*string)(nil),
+		&i,
+		sql.Null[float64]{V: 2.5, Valid: true},
+		sql.Null[bool]{},
+		(*time.Time)(nil),
+		nil,
+		nil,
+	))
+	require.NoError(t, a.AppendRowMap(map[string]driver.Value{"id": int32(4), "s": sql.NullString{}, "i": &i}))
+	require.NoError(t, a.Flush())
+
+	res, err := db.Query(`SELECT s, i, f, b, ts, h::VARCHAR, l::VARCHAR FROM test ORDER BY id`)
+	require.NoError(t, err)
+	defer closeRowsWrapper(t, res)
+
+	var rows [][7]any
+	for res.Next() {
+		var r [7]any
+		require.NoError(t, res.Scan(&r[0], &r[1], &r[2], &r[3], &r[4], &r[5], &r[6]))
+		rows = append(rows, r)
+	}
+	require.NoError(t, res.Err())
+	require.Equal(t, [][7]any{
+		{"a", int64(7), 1.5, true, ts, "3", "[NULL, 0]"},
+		{nil, nil, nil, nil, nil, nil, "[5, NULL]"},
+		{nil, int64(42), 2.5, nil, nil, nil, nil},
+		{nil, int64(42), nil, nil, nil, nil, nil},
+	}, rows)
+}
+
+func TestAppenderDecimalValues(t *testing.T) {
+	c, db, conn, a := prepareAppender(t, `CREATE TABLE test (id INTEGER, small DECIMAL(4, 2), large DECIMAL(38, 10))`)
+	defer cleanupAppender(t, c, db, conn, a)
+
+	bigValue, ok := new(big.Int).SetString("1234567890123456789012345678", 10)
+	require.True(t, ok)
+
+	require.NoError(t, a.AppendRow(int32(1), Decimal{Width: 4, Scale: 2, Value: big.NewInt(1234)}, Decimal{Width: 38, Scale: 0, Value: bigValue}))
+	require.NoError(t, a.AppendRow(int32(2), Decimal{Width: 5, Scale: 3, Value: big.NewInt(-1235)}, big.NewRat(1, 3)))
+	require.NoError(t, a.AppendRow(int32(3), "-0.125", big.NewFloat(2.5)))
+	// Integers are unscaled values.
+	require.NoError(t, a.AppendRow(int32(4), int64(7), big.NewInt(1)))
+	require.NoError(t, a.AppendRow(int32(5), "1", 0.1))
+	require.NoError(t, a.AppendRow(int32(6), nil, nil))
+	require.NoError(t, a.Flush())
+
+	res, err := db.Query(`SELECT small::VARCHAR, large::VARCHAR FROM test ORDER BY id`)
+	require.NoError(t, err)
+	defer closeRowsWrapper(t, res)
+
+	var rows [][2]any
+	for res.Next() {
+		var r [2]any
+		require.NoError(t, res.Scan(&r[0], &r[1]))
+		rows = append(rows, r)
+	}
+	require.NoError(t, res.Err())
+	require.Equal(t, [][2]any{
+		{"12.34", "1234567890123456789012345678.0000000000"},
+		{"-1.24", "0.3333333333"},
+		{"-0.13", "2.5000000000"},
+		{"0.07", "0.0000000001"},
+		{"1.00", "0.1000000000"},
+		{nil, nil},
+	}, rows)
+}
+
 func TestAppenderStrings(t *testing.T) {
 	c, db, conn, a := prepareAppender(t, `
 	CREATE TABLE test (str VARCHAR)`)
@@ -806,6 +1684,146 @@ func TestAppendToCatalog(t *testing.T) {
 	require.Equal(t, 1, i)
 }
 
+func TestAppenderConflictMode(t *testing.T) {
+	c := newConnectorWrapper(t, ``, nil)
+	defer closeConnectorWrapper(t, c)
+	db := sql.OpenDB(c)
+	defer closeDbWrapper(t, db)
+	_, err := db.Exec(`CREATE TABLE test (id INTEGER PRIMARY KEY, val VARCHAR); INSERT INTO test VALUES (1, 'old')`)
+	require.NoError(t, err)
+
+	conn := openDriverConnWrapper(t, c)
+	defer closeDriverConnWrapper(t, &conn)
+
+	tests := []struct {
+		mode     ConflictMode
+		expected string
+	}{
+		{ConflictIgnore, "old"},
+		{ConflictReplace, "new"},
+	}
+	for _, tc := range tests {
+		a, errAppender := NewAppenderWithOptions(conn, "", "", "test", WithConflictMode(tc.mode))
+		require.NoError(t, errAppender)
+
+		require.NoError(t, a.AppendRow(int32(1), "new"))
+		require.NoError(t, a.AppendRow(int32(2), "new"))
+		require.NoError(t, a.Flush())
+		require.NoError(t, a.AppendRow(int32(3), "new"))
+		require.NoError(t, a.Close())
+
+		var val string
+		require.NoError(t, db.QueryRow(`SELECT val FROM test WHERE id = 1`).Scan(&val))
+		require.Equal(t, tc.expected, val)
+
+		var count int
+		require.NoError(t, db.QueryRow(`SELECT count(*) FROM test`).Scan(&count))
+		require.Equal(t, 3, count)
+		_, err = db.Exec(`UPDATE test SET val = 'old' WHERE id = 1`)
+		require.NoError(t, err)
+	}
+
+	// The staging tables have been dropped.
+	var count int
+	require.NoError(t, db.QueryRow(`SELECT count(*) FROM duckdb_tables() WHERE temporary`).Scan(&count))
+	require.Equal(t, 0, count)
+
+	// Duplicate keys within a single flush do not fail.
+	for _, opt := range []AppenderOption{OnConflictReplace(), OnConflictIgnore()} {
+		a, errAppender := NewAppenderWithOptions(conn, "", "", "test", opt)
+		require.NoError(t, errAppender)
+		require.NoError(t, a.AppendRow(int32(4), "a"))
+		require.NoError(t, a.AppendRow(int32(4), "b"))
+		require.NoError(t, a.Close())
+		require.NoError(t, db.QueryRow(`SELECT count(*) FROM test WHERE id = 4`).Scan(&count))
+		require.Equal(t, 1, count)
+	}
+
+	// The default mode fails on conflicts.
+	a, err := NewAppenderWithOptions(conn, "", "", "test", WithConflictMode(ConflictError))
+	require.NoError(t, err)
+	require.NoError(t, a.AppendRow(int32(1), "new"))
+	require.ErrorContains(t, a.Close(), "violates primary key constraint")
+}
+
+func TestAppenderConflictModeRetry(t *testing.T) {
+	c := newConnectorWrapper(t, ``, nil)
+	defer closeConnectorWrapper(t, c)
+	db := sql.OpenDB(c)
+	defer closeDbWrapper(t, db)
+	_, err := db.Exec(`CREATE TABLE test (id INTEGER PRIMARY KEY, val VARCHAR NOT NULL)`)
+	require.NoError(t, err)
+
+	conn := openDriverConnWrapper(t, c)
+	defer closeDriverConnWrapper(t, &conn)
+
+	a, err := NewAppenderWithOptions(conn, "", "", "test", OnConflictReplace())
+	require.NoError(t, err)
+	require.NoError(t, a.AppendRow(int32(1), "a"))
+	require.NoError(t, a.AppendRow(int32(2), nil))
+
+	// Inserting the staged rows fails, and keeps them for the next flush.
+	err = a.Flush()
+	require.ErrorIs(t, err, errAppenderFlush)
+	require.ErrorContains(t, err, "NOT NULL constraint failed")
+
+	_, err = db.Exec(`ALTER TABLE test ALTER val DROP NOT NULL`)
+	require.NoError(t, err)
+	require.NoError(t, a.AppendRow(int32(3), "c"))
+	require.NoError(t, a.Flush())
+	require.NoError(t, a.Close())
+
+	var count int
+	require.NoError(t, db.QueryRow(`SELECT count(*) FROM test`).Scan(&count))
+	require.Equal(t, 3, count)
+}
+
+func TestAppenderConflictModeTx(t *testing.T) {
+	c := newConnectorWrapper(t, ``, nil)
+	defer closeConnectorWrapper(t, c)
+	db := sql.OpenDB(c)
+	defer closeDbWrapper(t, db)
+	_, err := db.Exec(`CREATE TABLE test (id INTEGER PRIMARY KEY, val VARCHAR); INSERT INTO test VALUES (1, 'a')`)
+	require.NoError(t, err)
+
+	conn := openDriverConnWrapper(t, c)
+	defer closeDriverConnWrapper(t, &conn)
+
+	// Within a transaction of the connection, inserting the staged rows is part of that transaction.
+	tx, err := conn.(driver.ConnBeginTx).BeginTx(context.Background(), driver.TxOptions{})
+	require.NoError(t, err)
+	a, err := NewAppenderWithOptions(conn, "", "", "test", OnConflictReplace())
+	require.NoError(t, err)
+	require.NoError(t, a.AppendRow(int32(1), "b"))
+	require.NoError(t, a.AppendRow(int32(2), "c"))
+	require.NoError(t, a.Close())
+	require.NoError(t, tx.Rollback())
+
+	var vals []string
+	res, err := db.Query(`SELECT val FROM test ORDER BY id`)
+	require.NoError(t, err)
+	defer closeRowsWrapper(t, res)
+	for res.Next() {
+		var val string
+		require.NoError(t, res.Scan(&val))
+		vals = append(vals, val)
+	}
+	require.NoError(t, res.Err())
+	require.Equal(t, []string{"a"}, vals)
+
+	// Otherwise, each flush inserts the staged rows and clears the staging table in its own transaction.
+	a, err = NewAppenderWithOptions(conn, "", "", "test", OnConflictReplace())
+	require.NoError(t, err)
+	require.NoError(t, a.AppendRow(int32(1), "b"))
+	require.NoError(t, a.Flush())
+	require.NoError(t, a.AppendRow(int32(2), "c"))
+	require.NoError(t, a.Close())
+
+	var count int
+	require.NoError(t, db.QueryRow(`SELECT count(*) FROM test WHERE val != 'a'`).Scan(&count))
+	require.Equal(t, 2, count)
+}
+
 var jsonInputs = [][]byte{
 	[]byte(`{"c1": 42, "l1": [1, 2, 3], "s1": {"a": 101, "b": ["hello", "world"]}, "l2": [{"a": [{"a": [4.2, 7.9]}]}]}`),
 	[]byte(`{"c1": null, "l1": [null, 2, null], "s1": {"a": null, "b": ["hello", null]}, "l2": [{"a": [{"a": [null, 7.9]}]}]}`),
@@ -869,6 +1887,144 @@ func TestAppenderWithJSON(t *testing.T) {
 	require.Equal(t, len(jsonInputs), i)
 }
 
+func TestAppenderDuration(t *testing.T) {
+	c, db, conn, a := prepareAppender(t, `CREATE TABLE test (id INTEGER, i INTERVAL, l INTERVAL[])`)
+	defer cleanupAppender(t, c, db, conn, a)
+
+	require.NoError(t, a.AppendRow(int32(1), 25*time.Hour+1500*time.Nanosecond, []time.Duration{time.Second}))
+
+	// The connection's duration conversion applies to the appended rows.
+	a.conn.durationConversion = DurationToDays
+	require.NoError(t, a.AppendRow(int32(2), 25*time.Hour, nil))
+	require.NoError(t, a.Flush())
+
+	res, err := db.Query(`SELECT i FROM test ORDER BY id`)
+	require.NoError(t, err)
+	defer closeRowsWrapper(t, res)
+
+	var intervals []Interval
+	for res.Next() {
+		var i Interval
+		require.NoError(t, res.Scan(&i))
+		intervals = append(intervals, i)
+	}
+	require.NoError(t, res.Err())
+	require.Equal(t, []Interval{
+		{Micros: (25*time.Hour + time.Microsecond).Microseconds()},
+		{Days: 1, Micros: time.Hour.Microseconds()},
+	}, intervals)
+
+	var l Composite[[]Interval]
+	require.NoError(t, db.QueryRow(`SELECT l FROM test WHERE id = 1`).Scan(&l))
+	require.Equal(t, []Interval{{Micros: time.Second.Microseconds()}}, l.Get())
+}
+
+type testJSONMarshaler struct {
+	raw string
+}
+
+func (m *testJSONMarshaler) MarshalJSON() ([]byte, error) {
+	return []byte(m.raw), nil
+}
+
+func TestAppenderJSONColumn(t *testing.T) {
+	c, db, conn, a := prepareAppender(t, `CREATE TABLE test (id INTEGER, j JSON)`)
+	defer cleanupAppender(t, c, db, conn, a)
+
+	require.NoError(t, a.AppendRow(int32(1), json.RawMessage(`{"n": 12345678901234567890123, "f": 1.10}`)))
+	require.NoError(t, a.AppendRow(int32(2), &testJSONMarshaler{raw: `[1, 2.50]`}))
+	require.NoError(t, a.AppendRow(int32(3), map[string]any{"a": 1}))
+	require.NoError(t, a.AppendRow(int32(4), json.RawMessage(nil)))
+	require.NoError(t, a.AppendRow(int32(5), (*testJSONMarshaler)(nil)))
+
+	err := a.AppendRow(int32(6), json.RawMessage(`{"invalid"`))
+	testError(t, err, errAppenderAppendRow.Error(), invalidInputErrMsg)
+	err = a.AppendRow(int32(6), &testJSONMarshaler{raw: `nope`})
+	testError(t, err, errAppenderAppendRow.Error(), invalidInputErrMsg)
+	require.NoError(t, a.Flush())
+
+	res, err := db.Query(`SELECT j::VARCHAR FROM test ORDER BY id`)
+	require.NoError(t, err)
+	defer closeRowsWrapper(t, res)
+
+	var values []any
+	for res.Next() {
+		var v any
+		require.NoError(t, res.Scan(&v))
+		values = append(values, v)
+	}
+	require.NoError(t, res.Err())
+	require.Equal(t, []any{
+		`{"n": 12345678901234567890123, "f": 1.10}`,
+		`[1, 2.50]`,
+		`{"a":1}`,
+		`null`,
+		nil,
+	}, values)
+}
+
+func BenchmarkAppenderAppendRow(b *testing.B) {
+	c, db, conn, a := prepareAppender(b, `CREATE TABLE test (id BIGINT, score DOUBLE, name VARCHAR)`)
+	defer cleanupAppender(b, c, db, conn, a)
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		if err := a.AppendRow(int64(n), float64(n), "name"); err != nil {
+			b.Error(err)
+		}
+	}
+}
+
+func BenchmarkAppenderAppendRowTrusted(b *testing.B) {
+	c, db, conn, a := prepareAppender(b, `CREATE TABLE test (id BIGINT, score DOUBLE, name VARCHAR)`)
+	defer cleanupAppender(b, c, db, conn, a)
+
+	trusted, err := NewAppenderWithOptions(conn, "", "", "test", WithTrustedTypes())
+	require.NoError(b, err)
+	defer closeAppenderWrapper(b, trusted)
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		if err = trusted.AppendRow(int64(n), float64(n), "name"); err != nil {
+			b.Error(err)
+		}
+	}
+}
+
+func BenchmarkAppenderAppendRows(b *testing.B) {
+	c, db, conn, a := prepareAppender(b, `CREATE TABLE test (id BIGINT, score DOUBLE, name VARCHAR)`)
+	defer cleanupAppender(b, c, db, conn, a)
+
+	rows := make([][]driver.Value, 1000)
+	for i := range rows {
+		rows[i] = []driver.Value{int64(i), float64(i), "name"}
+	}
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		if err := a.AppendRows(rows); err != nil {
+			b.Error(err)
+		}
+	}
+}
+
+func BenchmarkAppenderAppendColumns(b *testing.B) {
+	c, db, conn, a := prepareAppender(b, `CREATE TABLE test (id BIGINT, score DOUBLE, flag BOOLEAN)`)
+	defer cleanupAppender(b, c, db, conn, a)
+
+	const rowCount = 100000
+	ids := make([]int64, rowCount)
+	scores := make([]float64, rowCount)
+	flags := make([]bool, rowCount)
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		if err := a.AppendColumns(ids, scores, flags); err != nil {
+			b.Error(err)
+		}
+	}
+}
+
 func BenchmarkAppenderNested(b *testing.B) {
 	c, db, conn, a := prepareAppender(b, createNestedDataTableSQL)
 	defer cleanupAppender(b, c, db, conn, a)
@@ -55,6 +55,13 @@ struct ArrowArrayStream {
 };
 
 #endif  // ARROW_C_DATA_INTERFACE
+
+// releaseArrowArrayStream releases an exported stream, which DuckDB does not release after scanning it.
+static void releaseArrowArrayStream(struct ArrowArrayStream* stream) {
+	if (stream->release != NULL) {
+		stream->release(stream);
+	}
+}
 */
 import "C"
 
@@ -63,6 +70,8 @@ import (
 	"database/sql/driver"
 	"errors"
 	"fmt"
+	"io"
+	"sync/atomic"
 	"unsafe"
 
 	"github.com/marcboeker/go-duckdb/arrowmapping"
@@ -71,6 +80,7 @@ import (
 	"github.com/apache/arrow-go/v18/arrow"
 	"github.com/apache/arrow-go/v18/arrow/array"
 	"github.com/apache/arrow-go/v18/arrow/cdata"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
 )
 
 // Arrow exposes DuckDB Apache Arrow interface.
@@ -92,9 +102,82 @@ func NewArrowFromConn(driverConn driver.Conn) (*Arrow, error) {
 	return &Arrow{conn: conn}, nil
 }
 
+// QueryDirectArrow is the Apache Arrow counterpart of QueryDirect.
+// It executes the query on the driver connection and returns its result as an array.RecordReader.
+func (conn *Conn) QueryDirectArrow(ctx context.Context, query string, args ...any) (array.RecordReader, error) {
+	a, err := NewArrowFromConn(conn)
+	if err != nil {
+		return nil, err
+	}
+	return a.QueryContext(ctx, query, args...)
+}
+
+// QueryArrow executes the query on the driver connection and streams its result as an array.RecordReader.
+// Unlike QueryDirectArrow, it converts each record batch when the reader advances to it,
+// so that only the current record is held in Go memory. The reader keeps DuckDB's result until it is released.
+// If the context is done, the reader stops, and its Err method returns the context's error.
+func (conn *Conn) QueryArrow(ctx context.Context, query string, args ...any) (array.RecordReader, error) {
+	a, err := NewArrowFromConn(conn)
+	if err != nil {
+		return nil, err
+	}
+	return a.queryRecordReader(ctx, query, args)
+}
+
+// WriteArrowIPC executes the query on the driver connection and writes its result to w in the Arrow IPC stream format.
+// It passes the record batches of DuckDB's Arrow export to the IPC writer as they are fetched,
+// without converting the values of each row. An empty result writes a stream holding only the schema.
+// If fetching or writing a record batch fails, it returns the error without ending the stream,
+// so that readers of the stream do not mistake the written batches for the whole result.
+func (conn *Conn) WriteArrowIPC(ctx context.Context, w io.Writer, query string, args ...any) error {
+	rdr, err := conn.QueryArrow(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rdr.Release()
+
+	writer := ipc.NewWriter(w, ipc.WithSchema(rdr.Schema()))
+	for rdr.Next() {
+		if err = writer.Write(rdr.Record()); err != nil {
+			return err
+		}
+	}
+	if err = rdr.Err(); err != nil {
+		return err
+	}
+	return writer.Close()
+}
+
 // QueryContext prepares statements, executes them, returns Apache Arrow array.RecordReader as a result of the last
 // executed statement. Arguments are bound to the last statement.
 func (a *Arrow) QueryContext(ctx context.Context, query string, args ...any) (array.RecordReader, error) {
+	rdr, err := a.queryRecordReader(ctx, query, args)
+	if err != nil {
+		return nil, err
+	}
+	defer rdr.Release()
+
+	var recs []arrow.Record
+	defer func() {
+		for _, r := range recs {
+			r.Release()
+		}
+	}()
+
+	for rdr.Next() {
+		rec := rdr.Record()
+		rec.Retain()
+		recs = append(recs, rec)
+	}
+	if err = rdr.Err(); err != nil {
+		return nil, err
+	}
+
+	return array.NewRecordReader(rdr.Schema(), recs)
+}
+
+// queryRecordReader executes the statements of the query and returns a reader of the result of the last statement.
+func (a *Arrow) queryRecordReader(ctx context.Context, query string, args []any) (*arrowRecordReader, error) {
 	if a.conn.closed {
 		return nil, errClosedCon
 	}
@@ -131,39 +214,102 @@ func (a *Arrow) QueryContext(ctx context.Context, query string, args ...any) (ar
 	if err != nil {
 		return nil, err
 	}
-	defer arrowmapping.DestroyArrow(res)
 
 	sc, err := a.queryArrowSchema(res)
 	if err != nil {
+		arrowmapping.DestroyArrow(res)
 		return nil, err
 	}
 
-	var recs []arrow.Record
-	defer func() {
-		for _, r := range recs {
-			r.Release()
-		}
-	}()
+	rdr := &arrowRecordReader{
+		a:        a,
+		ctx:      ctx,
+		res:      res,
+		schema:   sc,
+		rowCount: uint64(arrowmapping.ArrowRowCount(*res)),
+	}
+	rdr.refCount.Store(1)
+	return rdr, nil
+}
 
-	rowCount := uint64(arrowmapping.ArrowRowCount(*res))
-	var retrievedRows uint64
-	for retrievedRows < rowCount {
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		default:
-		}
+// arrowRecordReader implements array.RecordReader for the Arrow result of a query.
+// It fetches and converts the record batches one at a time.
+type arrowRecordReader struct {
+	refCount atomic.Int64
 
-		rec, err := a.queryArrowArray(res, sc)
-		if err != nil {
-			return nil, err
-		}
+	a      *Arrow
+	ctx    context.Context
+	res    *arrowmapping.Arrow
+	schema *arrow.Schema
 
-		recs = append(recs, rec)
-		retrievedRows += uint64(rec.NumRows())
+	rowCount      uint64
+	retrievedRows uint64
+	rec           arrow.Record
+	err           error
+}
+
+// Retain increases the reference count of the reader.
+func (r *arrowRecordReader) Retain() {
+	r.refCount.Add(1)
+}
+
+// Release decreases the reference count of the reader.
+// It releases the current record and destroys DuckDB's result once the count reaches zero.
+func (r *arrowRecordReader) Release() {
+	if r.refCount.Add(-1) != 0 {
+		return
 	}
+	if r.rec != nil {
+		r.rec.Release()
+		r.rec = nil
+	}
+	if r.res != nil {
+		arrowmapping.DestroyArrow(r.res)
+		r.res = nil
+	}
+}
+
+// Schema returns the schema of the result.
+func (r *arrowRecordReader) Schema() *arrow.Schema {
+	return r.schema
+}
+
+// Next fetches the next record batch. It returns false once all rows have been fetched, or if an error occurred.
+func (r *arrowRecordReader) Next() bool {
+	if r.rec != nil {
+		r.rec.Release()
+		r.rec = nil
+	}
+	if r.err != nil || r.res == nil || r.retrievedRows >= r.rowCount {
+		return false
+	}
+	if err := r.ctx.Err(); err != nil {
+		r.err = err
+		return false
+	}
+
+	rec, err := r.a.queryArrowArray(r.res, r.schema)
+	if err != nil {
+		r.err = err
+		return false
+	}
+	if rec.NumRows() == 0 {
+		rec.Release()
+		return false
+	}
+	r.rec = rec
+	r.retrievedRows += uint64(rec.NumRows())
+	return true
+}
 
-	return array.NewRecordReader(sc, recs)
+// Record returns the current record batch. It is valid until the next call to Next or Release.
+func (r *arrowRecordReader) Record() arrow.Record {
+	return r.rec
+}
+
+// Err returns the error that stopped the reader, if any.
+func (r *arrowRecordReader) Err() error {
+	return r.err
 }
 
 // queryArrowSchema fetches the internal arrow schema from the arrow result.
@@ -248,24 +394,84 @@ func (a *Arrow) anyArgsToNamedArgs(args []any) []driver.NamedValue {
 
 // RegisterView registers an Arrow record reader as a view with the given name in DuckDB.
 // The returned release function must be called to release the memory once the view is no longer needed.
+// It drops the view, unless the connection is closed, which drops it.
 func (a *Arrow) RegisterView(reader array.RecordReader, name string) (release func(), err error) {
 	if a.conn.closed {
 		return nil, errClosedCon
 	}
 
 	stream := C.calloc(1, C.sizeof_struct_ArrowArrayStream)
-	release = func() {
+	cdata.ExportRecordReader(reader, (*cdata.CArrowArrayStream)(stream))
+	return a.registerStream(stream, name)
+}
+
+// registerStream registers a C stream as a view with the given name in DuckDB.
+// It takes over the stream, and releases and frees it when releasing the view.
+func (a *Arrow) registerStream(stream unsafe.Pointer, name string) (release func(), err error) {
+	releaseStream := func() {
+		releaseArrowStream(stream)
 		C.free(stream)
 	}
-	cdata.ExportRecordReader(reader, (*cdata.CArrowArrayStream)(stream))
 
 	arrowStream := arrowmapping.ArrowStream{
-		Ptr: unsafe.Pointer(stream),
+		Ptr: stream,
 	}
 	if arrowmapping.ArrowScan(a.conn.conn, name, arrowStream) == mapping.StateError {
-		release()
+		releaseStream()
 		return nil, errors.New("duckdb_arrow_scan")
 	}
 
+	release = func() {
+		// The view must not scan the released stream.
+		if !a.conn.closed {
+			_, _ = a.conn.ExecContext(context.Background(), `DROP VIEW IF EXISTS `+QuoteIdentifier(name), nil)
+		}
+		releaseStream()
+	}
 	return release, nil
 }
+
+// releaseArrowStream calls the release callback of a C stream, if it is not released yet.
+func releaseArrowStream(stream unsafe.Pointer) {
+	C.releaseArrowArrayStream((*C.struct_ArrowArrayStream)(stream))
+}
+
+// RegisterArrowView registers in-memory Arrow data as a view with the given name on the driver connection,
+// so that queries can combine it with DuckDB tables. The data is an arrow.Record, an arrow.Table,
+// or an array.RecordReader. The view scans the data without copying it.
+// A view of a record or table can be queried repeatedly, and each scan reads all records, e.g., in a self-join.
+// A view of a record reader can be scanned only once, and later scans fail.
+// The returned release function must be called to release the data once the view is no longer needed.
+func RegisterArrowView(driverConn driver.Conn, name string, data any) (release func(), err error) {
+	a, err := NewArrowFromConn(driverConn)
+	if err != nil {
+		return nil, err
+	}
+	if a.conn.closed {
+		return nil, errClosedCon
+	}
+
+	var source *arrowViewSource
+	switch d := data.(type) {
+	case arrow.Record:
+		d.Retain()
+		source = newArrowViewSource(d.Schema(), []arrow.Record{d})
+	case arrow.Table:
+		tr := array.NewTableReader(d, 0)
+		defer tr.Release()
+		var recs []arrow.Record
+		for tr.Next() {
+			// The table reader releases its records when advancing.
+			rec := tr.Record()
+			rec.Retain()
+			recs = append(recs, rec)
+		}
+		source = newArrowViewSource(d.Schema(), recs)
+	case array.RecordReader:
+		d.Retain()
+		source = newArrowViewReaderSource(d)
+	default:
+		return nil, unsupportedTypeError(fmt.Sprintf("%T", data))
+	}
+	return a.registerStream(newArrowViewStream(source), name)
+}
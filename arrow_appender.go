@@ -0,0 +1,107 @@
+//go:build duckdb_arrow
+
+package duckdb
+
+import (
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+)
+
+// AppendRecord loads the rows of an Apache Arrow record into the appender.
+// The record's columns map to the table's columns by their position.
+// Primitive columns without NULL values are copied directly into the appender's data chunks.
+// Other columns are converted value by value.
+// Supported Arrow types are BOOL, (U)INT8-64, FLOAT, DOUBLE, (LARGE_)STRING, (LARGE_)BINARY,
+// TIMESTAMP, DATE32, DATE64, and DECIMAL128.
+// If AppendRecord returns an error, some of the rows may have been loaded partially.
+func (a *Appender) AppendRecord(rec arrow.Record) error {
+	if a.closed {
+		return getError(errAppenderAppendAfterClose, nil)
+	}
+
+	columns := make([]any, rec.NumCols())
+	for i, arr := range rec.Columns() {
+		column, err := arrowColumn(arr)
+		if err != nil {
+			return getError(errAppenderAppendRow, addIndexToError(err, i+1))
+		}
+		columns[i] = column
+	}
+	return a.AppendColumns(columns...)
+}
+
+// arrowColumn converts an Arrow array to a Go slice that AppendColumns accepts.
+func arrowColumn(arr arrow.Array) (any, error) {
+	switch arr := arr.(type) {
+	case *array.Boolean:
+		if arr.NullN() == 0 {
+			values := make([]bool, arr.Len())
+			for i := range values {
+				values[i] = arr.Value(i)
+			}
+			return values, nil
+		}
+		return arrowValues(arr, arr.Value), nil
+	case *array.Int8:
+		return arrowPrimitive(arr, arr.Int8Values()), nil
+	case *array.Int16:
+		return arrowPrimitive(arr, arr.Int16Values()), nil
+	case *array.Int32:
+		return arrowPrimitive(arr, arr.Int32Values()), nil
+	case *array.Int64:
+		return arrowPrimitive(arr, arr.Int64Values()), nil
+	case *array.Uint8:
+		return arrowPrimitive(arr, arr.Uint8Values()), nil
+	case *array.Uint16:
+		return arrowPrimitive(arr, arr.Uint16Values()), nil
+	case *array.Uint32:
+		return arrowPrimitive(arr, arr.Uint32Values()), nil
+	case *array.Uint64:
+		return arrowPrimitive(arr, arr.Uint64Values()), nil
+	case *array.Float32:
+		return arrowPrimitive(arr, arr.Float32Values()), nil
+	case *array.Float64:
+		return arrowPrimitive(arr, arr.Float64Values()), nil
+	case *array.String:
+		return arrowValues(arr, arr.Value), nil
+	case *array.LargeString:
+		return arrowValues(arr, arr.Value), nil
+	case *array.Binary:
+		return arrowValues(arr, arr.Value), nil
+	case *array.LargeBinary:
+		return arrowValues(arr, arr.Value), nil
+	case *array.Timestamp:
+		unit := arr.DataType().(*arrow.TimestampType).Unit
+		return arrowValues(arr, func(i int) any { return arr.Value(i).ToTime(unit) }), nil
+	case *array.Date32:
+		return arrowValues(arr, func(i int) any { return arr.Value(i).ToTime() }), nil
+	case *array.Date64:
+		return arrowValues(arr, func(i int) any { return arr.Value(i).ToTime() }), nil
+	case *array.Decimal128:
+		t := arr.DataType().(*arrow.Decimal128Type)
+		return arrowValues(arr, func(i int) any {
+			return Decimal{Width: uint8(t.Precision), Scale: uint8(t.Scale), Value: arr.Value(i).BigInt()}
+		}), nil
+	}
+	return nil, unsupportedTypeError(arr.DataType().String())
+}
+
+// arrowPrimitive returns the values of a primitive Arrow array.
+// It returns the values as they are, if the array does not contain NULL values.
+func arrowPrimitive[T any](arr arrow.Array, values []T) any {
+	if arr.NullN() == 0 {
+		return values
+	}
+	return arrowValues(arr, func(i int) T { return values[i] })
+}
+
+// arrowValues returns the values of an Arrow array, with nil for NULL values.
+func arrowValues[T any](arr arrow.Array, value func(int) T) []any {
+	values := make([]any, arr.Len())
+	for i := range values {
+		if arr.IsValid(i) {
+			values[i] = value(i)
+		}
+	}
+	return values
+}
@@ -3,6 +3,7 @@
 package duckdb
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"database/sql/driver"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/apache/arrow-go/v18/arrow"
 	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
 	"github.com/apache/arrow-go/v18/arrow/memory"
 	"github.com/stretchr/testify/require"
 )
@@ -44,6 +46,80 @@ func TestArrow(t *testing.T) {
 		require.NoError(t, rdr.Err())
 	})
 
+	t.Run("query direct", func(t *testing.T) {
+		c := newConnectorWrapper(t, ``, nil)
+		defer closeConnectorWrapper(t, c)
+
+		innerConn := openDriverConnWrapper(t, c)
+		defer closeDriverConnWrapper(t, &innerConn)
+
+		rdr, err := innerConn.(*Conn).QueryDirectArrow(context.Background(), `SELECT * FROM generate_series(1, ?)`, 10)
+		require.NoError(t, err)
+		defer rdr.Release()
+
+		for rdr.Next() {
+			require.Equal(t, int64(10), rdr.Record().NumRows())
+		}
+		require.NoError(t, rdr.Err())
+	})
+
+	t.Run("query arrow", func(t *testing.T) {
+		c := newConnectorWrapper(t, ``, nil)
+		defer closeConnectorWrapper(t, c)
+
+		innerConn := openDriverConnWrapper(t, c)
+		defer closeDriverConnWrapper(t, &innerConn)
+
+		rdr, err := innerConn.(*Conn).QueryArrow(context.Background(),
+			`CREATE TABLE t AS SELECT * FROM generate_series(1, 10000) s(i); SELECT i FROM t WHERE i > ?`, 100)
+		require.NoError(t, err)
+		defer rdr.Release()
+		require.Equal(t, "i", rdr.Schema().Field(0).Name)
+
+		var batches, totalRows int64
+		for rdr.Next() {
+			rec := rdr.Record()
+			if batches == 0 {
+				require.Equal(t, int64(101), rec.Column(0).(*array.Int64).Value(0))
+			}
+			batches++
+			totalRows += rec.NumRows()
+		}
+		require.NoError(t, rdr.Err())
+		require.Equal(t, int64(9900), totalRows)
+		require.Greater(t, batches, int64(1))
+		require.False(t, rdr.Next())
+
+		// The connection remains usable while a reader is open.
+		rdr, err = innerConn.(*Conn).QueryArrow(context.Background(), `SELECT 1 AS one WHERE false`)
+		require.NoError(t, err)
+		require.False(t, rdr.Next())
+		require.NoError(t, rdr.Err())
+		rdr.Release()
+
+		_, err = innerConn.(*Conn).QueryArrow(context.Background(), `SELECT * FROM does_not_exist`)
+		require.Error(t, err)
+	})
+
+	t.Run("query arrow with cancelled context", func(t *testing.T) {
+		c := newConnectorWrapper(t, ``, nil)
+		defer closeConnectorWrapper(t, c)
+
+		innerConn := openDriverConnWrapper(t, c)
+		defer closeDriverConnWrapper(t, &innerConn)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		rdr, err := innerConn.(*Conn).QueryArrow(ctx, `SELECT * FROM generate_series(1, 10000)`)
+		require.NoError(t, err)
+		defer rdr.Release()
+
+		require.True(t, rdr.Next())
+		cancel()
+		require.False(t, rdr.Next())
+		require.ErrorIs(t, rdr.Err(), context.Canceled)
+		require.Nil(t, rdr.Record())
+	})
+
 	t.Run("select long series", func(t *testing.T) {
 		c := newConnectorWrapper(t, ``, nil)
 		defer closeConnectorWrapper(t, c)
@@ -242,3 +318,206 @@ func TestArrowClosedConn(t *testing.T) {
 	})
 	require.Error(t, err)
 }
+
+func TestArrowAppendRecord(t *testing.T) {
+	c, db, conn, a := prepareAppender(t, `CREATE TABLE test (i INTEGER, f DOUBLE, s VARCHAR, b BOOLEAN)`)
+	defer cleanupAppender(t, c, db, conn, a)
+
+	pool := memory.NewGoAllocator()
+	schema := arrow.NewSchema(
+		[]arrow.Field{
+			{Name: "i", Type: arrow.PrimitiveTypes.Int32},
+			{Name: "f", Type: arrow.PrimitiveTypes.Float64},
+			{Name: "s", Type: arrow.BinaryTypes.String},
+			{Name: "b", Type: arrow.FixedWidthTypes.Boolean},
+		},
+		nil,
+	)
+
+	b := array.NewRecordBuilder(pool, schema)
+	defer b.Release()
+
+	b.Field(0).(*array.Int32Builder).AppendValues([]int32{1, 2, 3}, []bool{true, false, true})
+	b.Field(1).(*array.Float64Builder).AppendValues([]float64{1.5, 2.5, 3.5}, nil)
+	b.Field(2).(*array.StringBuilder).AppendValues([]string{"a", "b", "c"}, nil)
+	b.Field(3).(*array.BooleanBuilder).AppendValues([]bool{true, false, true}, nil)
+
+	rec := b.NewRecord()
+	defer rec.Release()
+
+	require.NoError(t, a.AppendRecord(rec))
+	require.NoError(t, a.Flush())
+
+	res, err := db.Query(`SELECT i, f, s, b FROM test ORDER BY f`)
+	require.NoError(t, err)
+	defer closeRowsWrapper(t, res)
+
+	var rows [][4]any
+	for res.Next() {
+		var r [4]any
+		require.NoError(t, res.Scan(&r[0], &r[1], &r[2], &r[3]))
+		rows = append(rows, r)
+	}
+	require.NoError(t, res.Err())
+	require.Equal(t, [][4]any{
+		{int32(1), 1.5, "a", true},
+		{nil, 2.5, "b", false},
+		{int32(3), 3.5, "c", true},
+	}, rows)
+
+	// Unsupported Arrow types fail.
+	lb := array.NewListBuilder(pool, arrow.PrimitiveTypes.Int32)
+	defer lb.Release()
+	lb.AppendNull()
+	list := lb.NewArray()
+	defer list.Release()
+
+	listSchema := arrow.NewSchema([]arrow.Field{{Name: "l", Type: list.DataType()}}, nil)
+	listRec := array.NewRecord(listSchema, []arrow.Array{list}, 1)
+	defer listRec.Release()
+	err = a.AppendRecord(listRec)
+	testError(t, err, errAppenderAppendRow.Error(), unsupportedTypeErrMsg)
+}
+
+func TestRegisterArrowView(t *testing.T) {
+	c := newConnectorWrapper(t, ``, nil)
+	defer closeConnectorWrapper(t, c)
+
+	innerConn := openDriverConnWrapper(t, c)
+	defer closeDriverConnWrapper(t, &innerConn)
+	conn := innerConn.(*Conn)
+
+	_, err := conn.ExecContext(context.Background(), `CREATE TABLE names AS SELECT * FROM (VALUES (1, 'a'), (4, 'd')) t(id, name)`, nil)
+	require.NoError(t, err)
+
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{{Name: "id", Type: arrow.PrimitiveTypes.Int64}}, nil)
+	b := array.NewRecordBuilder(pool, schema)
+	defer b.Release()
+	b.Field(0).(*array.Int64Builder).AppendValues([]int64{1, 2, 3}, nil)
+	rec1 := b.NewRecord()
+	defer rec1.Release()
+	b.Field(0).(*array.Int64Builder).AppendValues([]int64{4, 5}, nil)
+	rec2 := b.NewRecord()
+	defer rec2.Release()
+
+	sum := func(query string) int64 {
+		rdr, errQuery := conn.QueryArrow(context.Background(), query)
+		require.NoError(t, errQuery)
+		defer rdr.Release()
+		require.True(t, rdr.Next())
+		return rdr.Record().Column(0).(*array.Int64).Value(0)
+	}
+
+	t.Run("record", func(t *testing.T) {
+		release, err := RegisterArrowView(innerConn, "record_view", rec1)
+		require.NoError(t, err)
+
+		// The view can be queried repeatedly, including after a query that stops early.
+		require.Equal(t, int64(3), sum(`SELECT count(*) FROM record_view`))
+		require.Equal(t, int64(1), sum(`SELECT id FROM record_view LIMIT 1`))
+		require.Equal(t, int64(6), sum(`SELECT sum(id)::BIGINT FROM record_view`))
+
+		// Each scan of the view reads all records.
+		require.Equal(t, int64(3), sum(`SELECT count(*) FROM record_view a JOIN record_view b USING (id)`))
+		require.Equal(t, int64(9), sum(`SELECT count(*) FROM record_view a, record_view b`))
+		require.Equal(t, int64(6), sum(`SELECT count(*) FROM (SELECT * FROM record_view UNION ALL SELECT * FROM record_view)`))
+
+		// Releasing the data drops the view.
+		release()
+		_, err = conn.QueryArrow(context.Background(), `SELECT * FROM record_view`)
+		require.ErrorContains(t, err, "record_view")
+	})
+
+	t.Run("table", func(t *testing.T) {
+		tbl := array.NewTableFromRecords(schema, []arrow.Record{rec1, rec2})
+		release, err := RegisterArrowView(innerConn, "table_view", tbl)
+		tbl.Release()
+		require.NoError(t, err)
+		defer release()
+
+		require.Equal(t, int64(15), sum(`SELECT sum(id)::BIGINT FROM table_view`))
+		require.Equal(t, int64(2), sum(`SELECT count(*) FROM table_view JOIN names USING (id)`))
+		require.Equal(t, int64(5), sum(`SELECT count(*) FROM table_view`))
+		require.Equal(t, int64(5), sum(`SELECT count(*) FROM table_view a JOIN table_view b USING (id)`))
+	})
+
+	t.Run("record reader", func(t *testing.T) {
+		rdr, err := array.NewRecordReader(schema, []arrow.Record{rec1, rec2})
+		require.NoError(t, err)
+		release, err := RegisterArrowView(innerConn, "reader_view", rdr)
+		rdr.Release()
+		require.NoError(t, err)
+		defer release()
+
+		require.Equal(t, int64(15), sum(`SELECT sum(id)::BIGINT FROM reader_view`))
+
+		// Later scans of the reader fail.
+		_, err = conn.QueryArrow(context.Background(), `SELECT count(*) FROM reader_view`)
+		require.ErrorContains(t, err, errArrowViewScanned.Error())
+	})
+
+	t.Run("unsupported data", func(t *testing.T) {
+		release, err := RegisterArrowView(innerConn, "invalid_view", []int64{1, 2})
+		require.ErrorContains(t, err, unsupportedTypeErrMsg)
+		require.Nil(t, release)
+	})
+}
+
+func TestWriteArrowIPC(t *testing.T) {
+	c := newConnectorWrapper(t, ``, nil)
+	defer closeConnectorWrapper(t, c)
+
+	innerConn := openDriverConnWrapper(t, c)
+	defer closeDriverConnWrapper(t, &innerConn)
+	conn := innerConn.(*Conn)
+
+	t.Run("result", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := conn.WriteArrowIPC(context.Background(), &buf,
+			`SELECT i, 'name_' || i AS name FROM generate_series(1, ?) s(i)`, 5000)
+		require.NoError(t, err)
+
+		r, err := ipc.NewReader(&buf)
+		require.NoError(t, err)
+		defer r.Release()
+		require.Equal(t, []string{"i", "name"}, []string{r.Schema().Field(0).Name, r.Schema().Field(1).Name})
+
+		var rows int64
+		for r.Next() {
+			rec := r.Record()
+			if rows == 0 {
+				require.Equal(t, int64(1), rec.Column(0).(*array.Int64).Value(0))
+				require.Equal(t, "name_1", rec.Column(1).ValueStr(0))
+			}
+			rows += rec.NumRows()
+		}
+		require.NoError(t, r.Err())
+		require.Equal(t, int64(5000), rows)
+	})
+
+	t.Run("empty result", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, conn.WriteArrowIPC(context.Background(), &buf, `SELECT 1 AS one WHERE false`))
+
+		r, err := ipc.NewReader(&buf)
+		require.NoError(t, err)
+		defer r.Release()
+		require.Equal(t, "one", r.Schema().Field(0).Name)
+		require.False(t, r.Next())
+		require.NoError(t, r.Err())
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.Error(t, conn.WriteArrowIPC(context.Background(), &buf, `SELECT * FROM does_not_exist`))
+		require.Zero(t, buf.Len())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := conn.WriteArrowIPC(ctx, &buf, `SELECT * FROM generate_series(1, 10)`)
+		require.ErrorIs(t, err, context.Canceled)
+	})
+}
@@ -0,0 +1,233 @@
+//go:build duckdb_arrow
+
+package duckdb
+
+/*
+#include <errno.h>
+#include <stdint.h>
+#include <stdlib.h>
+
+// arrow_view_stream and arrow_view_array have the layout of the ArrowArrayStream and ArrowArray structs.
+typedef struct {
+	int (*get_schema)(void *, void *);
+	int (*get_next)(void *, void *);
+	char *(*get_last_error)(void *);
+	void (*release)(void *);
+	void *private_data;
+} arrow_view_stream;
+
+typedef struct {
+	int64_t length;
+	int64_t null_count;
+	int64_t offset;
+	int64_t n_buffers;
+	int64_t n_children;
+	const void **buffers;
+	void **children;
+	void *dictionary;
+	void (*release)(void *);
+	void *private_data;
+} arrow_view_array;
+
+// For the function definitions see https://golang.org/issue/19837.
+int arrow_view_get_schema(void *, void *);
+int arrow_view_get_next(void *, void *);
+char *arrow_view_get_last_error(void *);
+void arrow_view_release(void *);
+typedef int (*arrow_view_get_t)(void *, void *);
+typedef char *(*arrow_view_get_last_error_t)(void *);
+typedef void (*arrow_view_release_t)(void *);
+*/
+import "C"
+
+import (
+	"errors"
+	"runtime"
+	"runtime/cgo"
+	"sync/atomic"
+	"unsafe"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/cdata"
+)
+
+var errArrowViewScanned = errors.New("the view of a record reader can only be scanned once")
+
+// arrowViewSource is the data of a view registered with RegisterArrowView.
+// DuckDB scans a view with a copy of the view's stream, which starts with the private data of the view.
+// When DuckDB requests the first record of a copy, the copy starts a new scan of the data,
+// so that each scan, e.g., of a self-join, reads all records.
+type arrowViewSource struct {
+	refCount atomic.Int64
+
+	schema *arrow.Schema
+	recs   []arrow.Record
+	// reader reads the data, if it is not in memory. Only the first scan can read it.
+	reader  array.RecordReader
+	scanned atomic.Bool
+}
+
+// newArrowViewSource returns the source of the retained records, which it releases once the source is released.
+func newArrowViewSource(schema *arrow.Schema, recs []arrow.Record) *arrowViewSource {
+	s := &arrowViewSource{schema: schema, recs: recs}
+	s.refCount.Store(1)
+	return s
+}
+
+// newArrowViewReaderSource returns the source of the retained reader, which it releases once the source is released.
+func newArrowViewReaderSource(reader array.RecordReader) *arrowViewSource {
+	s := &arrowViewSource{schema: reader.Schema(), reader: reader}
+	s.refCount.Store(1)
+	return s
+}
+
+// release decreases the reference count of the source, and releases its data once the count reaches zero.
+func (s *arrowViewSource) release() {
+	if s.refCount.Add(-1) != 0 {
+		return
+	}
+	for _, rec := range s.recs {
+		rec.Release()
+	}
+	if s.reader != nil {
+		s.reader.Release()
+	}
+	s.recs, s.reader = nil, nil
+}
+
+// arrowViewScan is the state of a single scan of a view.
+type arrowViewScan struct {
+	source *arrowViewSource
+	idx    int
+	errMsg *C.char
+
+	// The private data and the release callback of DuckDB's stream copy.
+	data    unsafe.Pointer
+	release C.arrow_view_release_t
+}
+
+// next returns the next record of the scan, or nil, if the scan is complete.
+func (s *arrowViewScan) next() (arrow.Record, error) {
+	if s.source.reader == nil {
+		if s.idx >= len(s.source.recs) {
+			return nil, nil
+		}
+		s.idx++
+		return s.source.recs[s.idx-1], nil
+	}
+
+	if s.idx == 0 && !s.source.scanned.CompareAndSwap(false, true) {
+		return nil, errArrowViewScanned
+	}
+	s.idx++
+	if s.source.reader.Next() {
+		return s.source.reader.Record(), nil
+	}
+	return nil, s.source.reader.Err()
+}
+
+func (s *arrowViewScan) setError(err error) {
+	C.free(unsafe.Pointer(s.errMsg))
+	s.errMsg = C.CString(err.Error())
+}
+
+// newArrowViewStream returns a C stream of the source for registering a view.
+// The stream takes over the reference of the caller to the source.
+func newArrowViewStream(source *arrowViewSource) unsafe.Pointer {
+	stream := (*C.arrow_view_stream)(C.calloc(1, C.sizeof_arrow_view_stream))
+	stream.get_schema = C.arrow_view_get_t(C.arrow_view_get_schema)
+	stream.get_next = C.arrow_view_get_t(C.arrow_view_get_next)
+	stream.get_last_error = C.arrow_view_get_last_error_t(C.arrow_view_get_last_error)
+	stream.release = C.arrow_view_release_t(C.arrow_view_release)
+	stream.private_data = pinArrowView(source)
+	return unsafe.Pointer(stream)
+}
+
+// pinArrowView returns a pointer to a pinned handle of an *arrowViewSource or *arrowViewScan.
+func pinArrowView(v any) unsafe.Pointer {
+	value := pinnedValue[any]{
+		pinner: &runtime.Pinner{},
+		value:  v,
+	}
+	h := cgo.NewHandle(value)
+	value.pinner.Pin(&h)
+	return unsafe.Pointer(&h)
+}
+
+func unpinArrowView(data unsafe.Pointer) {
+	h := (*cgo.Handle)(data)
+	h.Value().(unpinner).unpin()
+	h.Delete()
+}
+
+//export arrow_view_get_schema
+func arrow_view_get_schema(streamPtr unsafe.Pointer, out unsafe.Pointer) C.int {
+	stream := (*C.arrow_view_stream)(streamPtr)
+	var schema *arrow.Schema
+	switch v := getPinned[any](stream.private_data).(type) {
+	case *arrowViewSource:
+		schema = v.schema
+	case *arrowViewScan:
+		schema = v.source.schema
+	}
+	cdata.ExportArrowSchema(schema, (*cdata.CArrowSchema)(out))
+	return 0
+}
+
+//export arrow_view_get_next
+func arrow_view_get_next(streamPtr unsafe.Pointer, out unsafe.Pointer) C.int {
+	stream := (*C.arrow_view_stream)(streamPtr)
+	var scan *arrowViewScan
+	switch v := getPinned[any](stream.private_data).(type) {
+	case *arrowViewSource:
+		// The first request of a copy of the view's stream starts a new scan.
+		v.refCount.Add(1)
+		scan = &arrowViewScan{source: v, data: stream.private_data, release: stream.release}
+		stream.private_data = pinArrowView(scan)
+		stream.release = C.arrow_view_release_t(C.arrow_view_release)
+	case *arrowViewScan:
+		scan = v
+	}
+
+	rec, err := scan.next()
+	if err != nil {
+		scan.setError(err)
+		return C.EIO
+	}
+	if rec == nil {
+		// A released array marks the end of the stream.
+		(*C.arrow_view_array)(out).release = nil
+		return 0
+	}
+	cdata.ExportArrowRecordBatch(rec, (*cdata.CArrowArray)(out), nil)
+	return 0
+}
+
+//export arrow_view_get_last_error
+func arrow_view_get_last_error(streamPtr unsafe.Pointer) *C.char {
+	stream := (*C.arrow_view_stream)(streamPtr)
+	if scan, ok := getPinned[any](stream.private_data).(*arrowViewScan); ok {
+		return scan.errMsg
+	}
+	return nil
+}
+
+//export arrow_view_release
+func arrow_view_release(streamPtr unsafe.Pointer) {
+	stream := (*C.arrow_view_stream)(streamPtr)
+	data := stream.private_data
+	switch v := getPinned[any](data).(type) {
+	case *arrowViewSource:
+		stream.private_data, stream.release = nil, nil
+		unpinArrowView(data)
+		v.release()
+	case *arrowViewScan:
+		// Hand the copy back to DuckDB's release callback.
+		stream.private_data, stream.release = v.data, v.release
+		unpinArrowView(data)
+		C.free(unsafe.Pointer(v.errMsg))
+		v.source.release()
+		releaseArrowStream(streamPtr)
+	}
+}
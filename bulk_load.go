@@ -0,0 +1,298 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/marcboeker/go-duckdb/mapping"
+)
+
+// BulkLoadOptions configures BulkLoad.
+type BulkLoadOptions struct {
+	// Catalog and Schema of the target table. Empty values refer to the defaults.
+	Catalog string
+	Schema  string
+	// CreateTable creates the target table from T, if it does not exist yet.
+	// Otherwise, BulkLoad validates that the existing table matches the columns of T.
+	CreateTable bool
+	// BatchSize is the number of rows to append before flushing the appender.
+	// Zero or a negative value flushes once, after appending all rows.
+	BatchSize int
+}
+
+// BulkLoadStats contains statistics about a finished BulkLoad.
+type BulkLoadStats struct {
+	// Rows is the number of appended rows.
+	Rows int64
+	// Batches is the number of flushed batches.
+	Batches int
+	// Duration is the total time spent loading the rows.
+	Duration time.Duration
+}
+
+// BulkLoad appends all rows to a table with an Appender.
+// T must be a struct. Each exported field maps to one column, in the order of declaration.
+// The `duckdb` or `db` struct tag overrides the column name, and `db:"-"` skips a field.
+// Nil pointer fields are appended as NULL values.
+func BulkLoad[T any](ctx context.Context, db *sql.DB, table string, rows []T, opts BulkLoadOptions) (BulkLoadStats, error) {
+	start := time.Now()
+	stats := BulkLoadStats{}
+
+	fields, err := structColumns(reflect.TypeFor[T]())
+	if err != nil {
+		return stats, getError(errBulkLoad, err)
+	}
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return stats, err
+	}
+	defer conn.Close()
+
+	if opts.CreateTable {
+		if err = createTableFromColumns(ctx, conn, opts.Catalog, opts.Schema, table, fields); err != nil {
+			return stats, getError(errBulkLoad, err)
+		}
+	} else if err = validateTableColumns(ctx, conn, opts.Catalog, opts.Schema, table, fields); err != nil {
+		return stats, getError(errBulkLoad, err)
+	}
+
+	err = conn.Raw(func(driverConn any) error {
+		a, errAppender := NewAppender(driverConn.(driver.Conn), opts.Catalog, opts.Schema, table)
+		if errAppender != nil {
+			return errAppender
+		}
+
+		errAppend := bulkAppend(ctx, a, rows, fields, opts.BatchSize, &stats)
+		errClose := a.Close()
+		if errAppend != nil {
+			return errAppend
+		}
+		if errClose != nil {
+			return errClose
+		}
+
+		// Close flushes all remaining rows.
+		if opts.BatchSize <= 0 || stats.Rows%int64(opts.BatchSize) != 0 {
+			stats.Batches++
+		}
+		return nil
+	})
+
+	stats.Duration = time.Since(start)
+	return stats, err
+}
+
+func bulkAppend[T any](ctx context.Context, a *Appender, rows []T, fields []structColumn, batchSize int, stats *BulkLoadStats) error {
+	values := make([]driver.Value, len(fields))
+	for i := range rows {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		rv := reflect.ValueOf(&rows[i]).Elem()
+		for j, f := range fields {
+			val, err := structColumnValue(rv.FieldByIndex(f.index))
+			if err != nil {
+				return getError(errAppenderAppendRow, err)
+			}
+			values[j] = val
+		}
+		if err := a.AppendRow(values...); err != nil {
+			return err
+		}
+		stats.Rows++
+
+		if batchSize > 0 && stats.Rows%int64(batchSize) == 0 {
+			if err := a.Flush(); err != nil {
+				return err
+			}
+			stats.Batches++
+		}
+	}
+	return nil
+}
+
+// structColumn maps an exported struct field to a table column.
+type structColumn struct {
+	name  string
+	index []int
+	info  TypeInfo
+}
+
+func structColumns(t reflect.Type) ([]structColumn, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, castError(t.String(), reflect.Struct.String())
+	}
+
+	var columns []structColumn
+	names := map[string]bool{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, ok := structFieldName(field)
+		if !ok {
+			continue
+		}
+
+		key := strings.ToLower(name)
+		if names[key] {
+			return nil, duplicateNameError(name)
+		}
+		names[key] = true
+
+		info, err := typeInfoFromGoType(field.Type)
+		if err != nil {
+			return nil, fmt.Errorf("%w: field %s", err, field.Name)
+		}
+		columns = append(columns, structColumn{name: name, index: field.Index, info: info})
+	}
+
+	if len(columns) == 0 {
+		return nil, errNoStructColumns
+	}
+	return columns, nil
+}
+
+// structFieldName returns the column name of a struct field, and false, if the field is unexported or skipped.
+// The `duckdb` struct tag takes precedence over the `db` struct tag.
+func structFieldName(field reflect.StructField) (string, bool) {
+	if !field.IsExported() {
+		return "", false
+	}
+	for _, key := range []string{"duckdb", "db"} {
+		if tag, ok := field.Tag.Lookup(key); ok {
+			return tag, tag != "-"
+		}
+	}
+	return field.Name, true
+}
+
+func structColumnValue(v reflect.Value) (driver.Value, error) {
+	return unwrapValue(v.Interface())
+}
+
+var (
+	reflectTypeTime     = reflect.TypeFor[time.Time]()
+	reflectTypeBigInt   = reflect.TypeFor[*big.Int]()
+	reflectTypeInterval = reflect.TypeFor[Interval]()
+	reflectTypeUUID     = reflect.TypeFor[UUID]()
+	reflectTypeBytes    = reflect.TypeFor[[]byte]()
+)
+
+// typeInfoFromGoType returns the TypeInfo of the column type matching a Go type.
+func typeInfoFromGoType(t reflect.Type) (TypeInfo, error) {
+	switch t {
+	case reflectTypeTime:
+		return NewTypeInfo(TYPE_TIMESTAMP)
+	case reflectTypeBigInt:
+		return NewTypeInfo(TYPE_HUGEINT)
+	case reflectTypeInterval:
+		return NewTypeInfo(TYPE_INTERVAL)
+	case reflectTypeUUID:
+		return NewTypeInfo(TYPE_UUID)
+	case reflectTypeBytes:
+		return NewTypeInfo(TYPE_BLOB)
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return NewTypeInfo(TYPE_BOOLEAN)
+	case reflect.Int8:
+		return NewTypeInfo(TYPE_TINYINT)
+	case reflect.Int16:
+		return NewTypeInfo(TYPE_SMALLINT)
+	case reflect.Int32:
+		return NewTypeInfo(TYPE_INTEGER)
+	case reflect.Int64, reflect.Int:
+		return NewTypeInfo(TYPE_BIGINT)
+	case reflect.Uint8:
+		return NewTypeInfo(TYPE_UTINYINT)
+	case reflect.Uint16:
+		return NewTypeInfo(TYPE_USMALLINT)
+	case reflect.Uint32:
+		return NewTypeInfo(TYPE_UINTEGER)
+	case reflect.Uint64, reflect.Uint:
+		return NewTypeInfo(TYPE_UBIGINT)
+	case reflect.Float32:
+		return NewTypeInfo(TYPE_FLOAT)
+	case reflect.Float64:
+		return NewTypeInfo(TYPE_DOUBLE)
+	case reflect.String:
+		return NewTypeInfo(TYPE_VARCHAR)
+	case reflect.Pointer:
+		return typeInfoFromGoType(t.Elem())
+	case reflect.Slice:
+		child, err := typeInfoFromGoType(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return NewListInfo(child)
+	case reflect.Array:
+		child, err := typeInfoFromGoType(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return NewArrayInfo(child, uint64(t.Len()))
+	case reflect.Struct:
+		columns, err := structColumns(t)
+		if err != nil {
+			return nil, err
+		}
+		entries := make([]StructEntry, len(columns))
+		for i, c := range columns {
+			if entries[i], err = NewStructEntry(c.info, c.name); err != nil {
+				return nil, err
+			}
+		}
+		return NewStructInfo(entries[0], entries[1:]...)
+	}
+
+	return nil, unsupportedTypeError(t.String())
+}
+
+func createTableFromColumns(ctx context.Context, conn *sql.Conn, catalog, schema, table string, columns []structColumn) error {
+	_, err := conn.ExecContext(ctx, createTableQuery(catalog, schema, table, columns))
+	return err
+}
+
+// createTableQuery returns the CREATE TABLE IF NOT EXISTS statement of a table with the columns.
+func createTableQuery(catalog, schema, table string, columns []structColumn) string {
+	defs := make([]string, len(columns))
+	for i, c := range columns {
+		lt := c.info.logicalType()
+		defs[i] = QuoteIdentifier(c.name) + " " + logicalTypeName(lt)
+		mapping.DestroyLogicalType(&lt)
+	}
+
+	return fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (%s)`,
+		QuoteQualified(catalog, schema, table), strings.Join(defs, ", "))
+}
+
+func validateTableColumns(ctx context.Context, conn *sql.Conn, catalog, schema, table string, columns []structColumn) error {
+	query := fmt.Sprintf(`SELECT * FROM %s LIMIT 0`, QuoteQualified(catalog, schema, table))
+	res, err := conn.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer res.Close()
+
+	names, err := res.Columns()
+	if err != nil {
+		return err
+	}
+	if len(names) != len(columns) {
+		return columnCountError(len(columns), len(names))
+	}
+	for i, name := range names {
+		if !strings.EqualFold(name, columns[i].name) {
+			return structFieldError(columns[i].name, name)
+		}
+	}
+	return nil
+}
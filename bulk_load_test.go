@@ -0,0 +1,93 @@
+package duckdb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type bulkLoadRow struct {
+	ID      int64 `db:"id"`
+	Name    string
+	Score   *float64
+	Tags    []string
+	Nested  simpleStruct
+	Created time.Time
+	Ignored string `db:"-"`
+}
+
+func TestBulkLoad(t *testing.T) {
+	db := openDbWrapper(t, ``)
+	defer closeDbWrapper(t, db)
+
+	score := 4.5
+	ts := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	var rows []bulkLoadRow
+	for i := 0; i < 2500; i++ {
+		row := bulkLoadRow{
+			ID:      int64(i),
+			Name:    "row",
+			Tags:    []string{"a", "b"},
+			Nested:  simpleStruct{A: int32(i), B: "nested"},
+			Created: ts,
+		}
+		if i%2 == 0 {
+			row.Score = &score
+		}
+		rows = append(rows, row)
+	}
+
+	stats, err := BulkLoad(context.Background(), db, "bulk", rows, BulkLoadOptions{CreateTable: true, BatchSize: 1000})
+	require.NoError(t, err)
+	require.Equal(t, int64(2500), stats.Rows)
+	require.Equal(t, 3, stats.Batches)
+
+	var count, nullCount int
+	require.NoError(t, db.QueryRow(`SELECT count(*), count(*) FILTER (WHERE Score IS NULL) FROM bulk`).Scan(&count, &nullCount))
+	require.Equal(t, 2500, count)
+	require.Equal(t, 1250, nullCount)
+
+	var typeName string
+	require.NoError(t, db.QueryRow(`SELECT data_type FROM information_schema.columns WHERE table_name = 'bulk' AND column_name = 'Nested'`).Scan(&typeName))
+	require.Equal(t, `STRUCT(a INTEGER, B VARCHAR)`, typeName)
+
+	var created time.Time
+	var nestedA int32
+	require.NoError(t, db.QueryRow(`SELECT Created, Nested.a FROM bulk WHERE id = 42`).Scan(&created, &nestedA))
+	require.Equal(t, ts, created)
+	require.Equal(t, int32(42), nestedA)
+
+	// Append to the existing table.
+	stats, err = BulkLoad(context.Background(), db, "bulk", rows[:10], BulkLoadOptions{})
+	require.NoError(t, err)
+	require.Equal(t, int64(10), stats.Rows)
+	require.Equal(t, 1, stats.Batches)
+	require.NoError(t, db.QueryRow(`SELECT count(*) FROM bulk`).Scan(&count))
+	require.Equal(t, 2510, count)
+}
+
+func TestErrBulkLoad(t *testing.T) {
+	db := openDbWrapper(t, ``)
+	defer closeDbWrapper(t, db)
+	createTable(t, db, `CREATE TABLE other (a INTEGER)`)
+
+	_, err := BulkLoad(context.Background(), db, "other", []bulkLoadRow{{}}, BulkLoadOptions{})
+	testError(t, err, errBulkLoad.Error(), columnCountErrMsg)
+
+	_, err = BulkLoad(context.Background(), db, "other", []int{1}, BulkLoadOptions{})
+	testError(t, err, errBulkLoad.Error(), castErrMsg)
+
+	type noFields struct {
+		a int
+	}
+	_, err = BulkLoad(context.Background(), db, "other", []noFields{{}}, BulkLoadOptions{CreateTable: true})
+	testError(t, err, errBulkLoad.Error(), errNoStructColumns.Error())
+
+	type renamed struct {
+		B int32
+	}
+	_, err = BulkLoad(context.Background(), db, "other", []renamed{{}}, BulkLoadOptions{})
+	testError(t, err, errBulkLoad.Error(), structFieldErrMsg)
+}
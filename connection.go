@@ -4,9 +4,14 @@ import (
 	"context"
 	"database/sql"
 	"database/sql/driver"
+	"encoding/json"
 	"errors"
+	"math"
 	"math/big"
+	"reflect"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/marcboeker/go-duckdb/mapping"
 )
 
@@ -16,21 +21,230 @@ type Conn struct {
 	conn   mapping.Connection
 	closed bool
 	tx     bool
+
+	// defaultQueryTimeout applies to all statements whose context has no deadline.
+	defaultQueryTimeout time.Duration
+	// durationConversion defines how time.Duration arguments and appended values convert to an INTERVAL.
+	durationConversion DurationConversion
+	// orderedMaps defines whether rows return MAP values as OrderedMap.
+	orderedMaps bool
+	// timeLocation is the location of the time.Time values of scanned timestamps, if not nil.
+	timeLocation *time.Location
+	// timeTZOffsets defines whether rows return TIMETZ values with their stored UTC offset.
+	timeTZOffsets bool
+	// nonFiniteFloats defines how rows and arguments handle NaN and infinite FLOAT and DOUBLE values.
+	nonFiniteFloats NonFiniteFloats
+	// textCasts defines whether rows scan values into *string with the text of DuckDB's VARCHAR cast.
+	textCasts bool
+	// compositeOptions configures how rows decode composite values into Go structs, slices, and Composite,
+	// i.e., the field naming and the decode hooks.
+	compositeOptions compositeOptions
+	// readOnly defines whether the connection refuses statements that can modify the database,
+	// e.g., the read connections of a Coordinator.
+	readOnly bool
+
+	// id is the connection's unique ID within its Connector.
+	id uint64
+	// label names the connection in errors.
+	label string
+}
+
+// ID returns the connection's unique ID within its Connector.
+func (conn *Conn) ID() uint64 {
+	return conn.id
+}
+
+// Label returns the connection's label.
+func (conn *Conn) Label() string {
+	return conn.label
+}
+
+// SetLabel sets the connection's label, e.g., to the name of the task using the connection.
+func (conn *Conn) SetLabel(label string) {
+	conn.label = label
+}
+
+// GetConnLabel returns the label of a connection.
+func GetConnLabel(c *sql.Conn) (string, error) {
+	var label string
+	err := c.Raw(func(driverConn any) error {
+		conn, ok := driverConn.(*Conn)
+		if !ok {
+			return getError(errInvalidCon, nil)
+		}
+		label = conn.label
+		return nil
+	})
+	return label, err
+}
+
+// SetConnLabel sets the label of a connection.
+func SetConnLabel(c *sql.Conn, label string) error {
+	return c.Raw(func(driverConn any) error {
+		conn, ok := driverConn.(*Conn)
+		if !ok {
+			return getError(errInvalidCon, nil)
+		}
+		conn.label = label
+		return nil
+	})
+}
+
+// getDuckDBError returns a DuckDB error with the connection's label.
+func (conn *Conn) getDuckDBError(errMsg string) error {
+	err := getDuckDBError(errMsg).(*Error)
+	err.ConnLabel = conn.label
+	return err
+}
+
+// interpolate renders the arguments into the query, if the context enables interpolation.
+func (conn *Conn) interpolate(ctx context.Context, query string, args []driver.NamedValue) (string, []driver.NamedValue, error) {
+	if len(args) == 0 || !interpolationEnabled(ctx) {
+		return query, args, nil
+	}
+	query, err := interpolateParams(query, args)
+	if err != nil {
+		return "", nil, getError(errCouldNotBind, err)
+	}
+	return query, nil, nil
 }
 
 // CheckNamedValue implements the driver.NamedValueChecker interface.
+// It binds time.Duration values as INTERVAL, and Null values as NULL or their values.
+// It binds unsigned integers exceeding math.MaxInt64 as UBIGINT, and netip.Addr and netip.Prefix values as text.
+// It handles NaN and infinite floats according to the connection's NonFiniteFloats policy.
+// It keeps json.Marshaler values and values that the default converter rejects, e.g., maps and structs,
+// so that they can be marshaled to JSON when binding them to JSON parameters.
 func (conn *Conn) CheckNamedValue(nv *driver.NamedValue) error {
-	switch nv.Value.(type) {
-	case *big.Int, Interval:
+	if v, ok, err := handlerValue(nv.Value); ok {
+		if err != nil {
+			return err
+		}
+		nv.Value = v
+		return conn.CheckNamedValue(nv)
+	}
+
+	// The default converter converts unsigned integers to int64, so it rejects or wraps those exceeding math.MaxInt64.
+	if u, ok := largeUint64(nv.Value); ok {
+		nv.Value = u
+		return nil
+	}
+
+	if conn.nonFiniteFloats != NonFiniteFloatsPassThrough {
+		if f, ok := floatValue(nv.Value); ok {
+			if err := nonFiniteFloatError(f); err != nil {
+				if conn.nonFiniteFloats == NonFiniteFloatsError {
+					return err
+				}
+				nv.Value = nil
+				return nil
+			}
+		}
+	}
+
+	if text, ok, err := inetText(nv.Value); ok {
+		if err != nil {
+			return err
+		}
+		nv.Value = text
+		return nil
+	}
+
+	switch v := nv.Value.(type) {
+	case *big.Int, Interval, TimestampNS, Decimal, UUID:
+		return nil
+	case uuid.UUID:
+		nv.Value = UUID(v)
+		return nil
+	case *UUID:
+		if v == nil {
+			nv.Value = nil
+			return nil
+		}
+		nv.Value = *v
+		return nil
+	case time.Duration:
+		nv.Value = IntervalFromDuration(v, conn.durationConversion)
+		return nil
+	case nullable:
+		// Check the value of the Null, which need not be a driver.Value.
+		nv.Value = v.nullable()
+		return conn.CheckNamedValue(nv)
+	case driver.Valuer:
+		return driver.ErrSkip
+	}
+
+	if str, ok, err := enumValue(nv.Value); ok {
+		if err != nil {
+			return err
+		}
+		nv.Value = str
+		return nil
+	}
+
+	if driver.IsValue(nv.Value) {
+		return driver.ErrSkip
+	}
+	if _, ok := nv.Value.(json.Marshaler); ok {
+		return nil
+	}
+	if _, err := driver.DefaultParameterConverter.ConvertValue(nv.Value); err != nil {
 		return nil
 	}
 	return driver.ErrSkip
 }
 
+// floatValue returns the value of a float, or of a non-nil pointer to a float, or of a valid sql.NullFloat64 or sql.Null[T].
+func floatValue(val any) (float64, bool) {
+	if v, ok := val.(sql.NullFloat64); ok {
+		return v.Float64, v.Valid
+	}
+	rv := reflect.ValueOf(val)
+	switch rv.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	case reflect.Pointer:
+		if !rv.IsNil() {
+			return floatValue(rv.Elem().Interface())
+		}
+	case reflect.Struct:
+		if isSQLNull(rv.Type()) && rv.FieldByName("Valid").Bool() {
+			return floatValue(rv.FieldByName("V").Interface())
+		}
+	}
+	return 0, false
+}
+
+// largeUint64 returns an unsigned integer exceeding math.MaxInt64 as uint64.
+// The integer can be of a named type, or the value of a non-nil pointer or valid sql.Null[T].
+func largeUint64(val any) (uint64, bool) {
+	rv := reflect.ValueOf(val)
+	switch rv.Kind() {
+	case reflect.Uint, reflect.Uint64:
+		if u := rv.Uint(); u > math.MaxInt64 {
+			return u, true
+		}
+	case reflect.Pointer:
+		if !rv.IsNil() {
+			return largeUint64(rv.Elem().Interface())
+		}
+	case reflect.Struct:
+		if isSQLNull(rv.Type()) && rv.FieldByName("Valid").Bool() {
+			return largeUint64(rv.FieldByName("V").Interface())
+		}
+	}
+	return 0, false
+}
+
 // ExecContext executes a query that doesn't return rows, such as an INSERT or UPDATE.
 // It implements the driver.ExecerContext interface.
 func (conn *Conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
-	prepared, err := conn.prepareStmts(ctx, query)
+	query, args, err := conn.interpolate(ctx, query, args)
+	if err != nil {
+		return nil, err
+	}
+
+	prepared, rowsAffected, err := conn.prepareStmtsWithResults(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -47,12 +261,19 @@ func (conn *Conn) ExecContext(ctx context.Context, query string, args []driver.N
 		return nil, errClose
 	}
 
-	return res, nil
+	r := res.(*Result)
+	r.rowsAffected = append(rowsAffected, r.rowsAffected...)
+	return r, nil
 }
 
 // QueryContext executes a query that may return rows, such as a SELECT.
 // It implements the driver.QueryerContext interface.
 func (conn *Conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	query, args, err := conn.interpolate(ctx, query, args)
+	if err != nil {
+		return nil, err
+	}
+
 	prepared, err := conn.prepareStmts(ctx, query)
 	if err != nil {
 		return nil, err
@@ -147,7 +368,7 @@ func (conn *Conn) extractStmts(query string) (*mapping.ExtractedStatements, mapp
 		errMsg := mapping.ExtractStatementsError(stmts)
 		mapping.DestroyExtracted(&stmts)
 		if errMsg != "" {
-			return nil, 0, getDuckDBError(errMsg)
+			return nil, 0, conn.getDuckDBError(errMsg)
 		}
 		return nil, 0, errEmptyQuery
 	}
@@ -159,41 +380,66 @@ func (conn *Conn) prepareExtractedStmt(extractedStmts mapping.ExtractedStatement
 	var stmt mapping.PreparedStatement
 	state := mapping.PrepareExtractedStatement(conn.conn, extractedStmts, i, &stmt)
 	if state == mapping.StateError {
-		err := getDuckDBError(mapping.PrepareError(stmt))
+		err := conn.getDuckDBError(mapping.PrepareError(stmt))
 		mapping.DestroyPrepare(&stmt)
 		return nil, err
 	}
+	if conn.readOnly && !isReadOnlyStmtType(StmtType(mapping.PreparedStatementType(stmt))) {
+		mapping.DestroyPrepare(&stmt)
+		return nil, getError(errReadOnlyConn, nil)
+	}
 
 	return &Stmt{conn: conn, preparedStmt: &stmt}, nil
 }
 
+// isReadOnlyStmtType returns true, if a read-only connection can execute statements of the type.
+// EXPLAIN is not read-only, as EXPLAIN ANALYZE executes its statement.
+func isReadOnlyStmtType(t StmtType) bool {
+	switch t {
+	case STATEMENT_TYPE_SELECT, STATEMENT_TYPE_SET, STATEMENT_TYPE_TRANSACTION:
+		return true
+	default:
+		return false
+	}
+}
+
 func (conn *Conn) prepareStmts(ctx context.Context, query string) (*Stmt, error) {
+	stmt, _, err := conn.prepareStmtsWithResults(ctx, query)
+	return stmt, err
+}
+
+// prepareStmtsWithResults executes all statements of a query, except the last one, which it prepares.
+// It returns the number of rows affected by each executed statement.
+func (conn *Conn) prepareStmtsWithResults(ctx context.Context, query string) (*Stmt, []int64, error) {
 	if conn.closed {
-		return nil, errClosedCon
+		return nil, nil, errClosedCon
 	}
 
 	stmts, count, errExtract := conn.extractStmts(query)
 	if errExtract != nil {
-		return nil, errExtract
+		return nil, nil, errExtract
 	}
 	defer mapping.DestroyExtracted(stmts)
 
+	var rowsAffected []int64
 	for i := mapping.IdxT(0); i < count-1; i++ {
 		preparedStmt, err := conn.prepareExtractedStmt(*stmts, i)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
-		// Execute the statement without any arguments and ignore the result.
-		_, execErr := preparedStmt.ExecContext(ctx, nil)
+		// Execute the statement without any arguments.
+		res, execErr := preparedStmt.ExecContext(ctx, nil)
 		closeErr := preparedStmt.Close()
 		if execErr != nil {
-			return nil, execErr
+			return nil, nil, execErr
 		}
 		if closeErr != nil {
-			return nil, closeErr
+			return nil, nil, closeErr
 		}
+		rowsAffected = append(rowsAffected, res.(*Result).rowsAffected...)
 	}
 
-	return conn.prepareExtractedStmt(*stmts, count-1)
+	stmt, err := conn.prepareExtractedStmt(*stmts, count-1)
+	return stmt, rowsAffected, err
 }
@@ -0,0 +1,102 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+)
+
+// CoordinatorOptions configures a Coordinator.
+type CoordinatorOptions struct {
+	// Reader is an optional separate Connector for the read connections,
+	// e.g., a Connector opened with access_mode=read_only.
+	// If nil, the read connections use the writer's Connector, and refuse statements
+	// that can modify the database, i.e., they only execute SELECT, SET, and transaction statements.
+	Reader driver.Connector
+	// MaxReaders is the maximum number of open read connections.
+	// Zero or a negative value means no limit.
+	MaxReaders int
+}
+
+// Coordinator manages a single write connection and a pool of read connections to a database.
+// It routes Exec calls to the write connection, which serializes all writes,
+// and Query calls to the read connections, which run concurrently.
+// The read connections share the writer's database, i.e., they see the writes
+// that the write connection committed, but they cannot write themselves.
+// Use Writer for queries that modify the database and return rows, e.g., INSERT ... RETURNING,
+// or for transactions.
+type Coordinator struct {
+	writer *sql.DB
+	reader *sql.DB
+}
+
+// NewCoordinator returns a new Coordinator for the database of the writer Connector.
+// The Coordinator takes ownership of its Connectors and closes them when closing the Coordinator.
+func NewCoordinator(writer *Connector, opts CoordinatorOptions) *Coordinator {
+	w := sql.OpenDB(writer)
+	w.SetMaxOpenConns(1)
+
+	reader := opts.Reader
+	if reader == nil {
+		// Closing the writer's sql.DB closes the shared Connector.
+		reader = readerConnector{writer}
+	}
+	r := sql.OpenDB(reader)
+	if opts.MaxReaders > 0 {
+		r.SetMaxOpenConns(opts.MaxReaders)
+	}
+
+	return &Coordinator{writer: w, reader: r}
+}
+
+// Writer returns the sql.DB of the write connection.
+func (c *Coordinator) Writer() *sql.DB {
+	return c.writer
+}
+
+// Reader returns the sql.DB of the read connections.
+func (c *Coordinator) Reader() *sql.DB {
+	return c.reader
+}
+
+// ExecContext executes a query on the write connection.
+func (c *Coordinator) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return c.writer.ExecContext(ctx, query, args...)
+}
+
+// QueryContext executes a query on one of the read connections.
+func (c *Coordinator) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return c.reader.QueryContext(ctx, query, args...)
+}
+
+// QueryRowContext executes a query that returns at most one row on one of the read connections.
+func (c *Coordinator) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return c.reader.QueryRowContext(ctx, query, args...)
+}
+
+// Close closes the read connections, the write connection, and the Connectors.
+func (c *Coordinator) Close() error {
+	errReader := c.reader.Close()
+	errWriter := c.writer.Close()
+	return errors.Join(errReader, errWriter)
+}
+
+// readerConnector opens read-only connections with a Connector without taking its ownership,
+// i.e., sql.DB does not close the underlying Connector.
+type readerConnector struct {
+	c *Connector
+}
+
+func (s readerConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := s.c.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	conn.(*Conn).readOnly = true
+	return conn, nil
+}
+
+func (s readerConnector) Driver() driver.Driver {
+	return s.c.Driver()
+}
@@ -0,0 +1,80 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoordinator(t *testing.T) {
+	c := newConnectorWrapper(t, ``, nil)
+	coordinator := NewCoordinator(c, CoordinatorOptions{MaxReaders: 4})
+	defer func() {
+		require.NoError(t, coordinator.Close())
+		require.True(t, c.closed)
+	}()
+
+	require.Equal(t, 1, coordinator.Writer().Stats().MaxOpenConnections)
+	require.Equal(t, 4, coordinator.Reader().Stats().MaxOpenConnections)
+
+	_, err := coordinator.ExecContext(context.Background(), `CREATE TABLE coordinated (i INTEGER)`)
+	require.NoError(t, err)
+
+	const n = 16
+	errCh := make(chan error, 2*n)
+	for i := range n {
+		go func() {
+			_, errExec := coordinator.ExecContext(context.Background(), `INSERT INTO coordinated VALUES (?)`, i)
+			errCh <- errExec
+		}()
+		go func() {
+			errCh <- queryCoordinated(coordinator)
+		}()
+	}
+	for range 2 * n {
+		require.NoError(t, <-errCh)
+	}
+
+	var count int
+	require.NoError(t, coordinator.QueryRowContext(context.Background(), `SELECT count(*) FROM coordinated`).Scan(&count))
+	require.Equal(t, n, count)
+
+	// The read connections refuse to modify the database.
+	_, err = coordinator.Reader().Exec(`INSERT INTO coordinated VALUES (42)`)
+	testError(t, err, errReadOnlyConn.Error())
+	_, err = coordinator.Reader().Exec(`CREATE TABLE other (i INTEGER)`)
+	testError(t, err, errReadOnlyConn.Error())
+	_, err = coordinator.QueryContext(context.Background(), `EXPLAIN ANALYZE DELETE FROM coordinated`)
+	testError(t, err, errReadOnlyConn.Error())
+
+	conn, err := coordinator.Reader().Conn(context.Background())
+	require.NoError(t, err)
+	err = conn.Raw(func(driverConn any) error {
+		_, errAppender := NewAppenderFromConn(driverConn.(driver.Conn), "", "coordinated")
+		return errAppender
+	})
+	testError(t, err, errAppenderCreation.Error(), errReadOnlyConn.Error())
+	require.NoError(t, conn.Close())
+
+	// The read connections still execute settings and transactions.
+	tx, err := coordinator.Reader().Begin()
+	require.NoError(t, err)
+	_, err = tx.Exec(`SET VARIABLE answer = 42`)
+	require.NoError(t, err)
+	require.NoError(t, tx.QueryRow(`SELECT count(*) FROM coordinated`).Scan(&count))
+	require.Equal(t, n, count)
+	require.NoError(t, tx.Commit())
+}
+
+func queryCoordinated(coordinator *Coordinator) error {
+	res, err := coordinator.QueryContext(context.Background(), `SELECT i FROM coordinated`)
+	if err != nil {
+		return err
+	}
+	for res.Next() {
+	}
+	return errors.Join(res.Err(), res.Close())
+}
@@ -0,0 +1,75 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// CopyOptions configures CopyTable.
+type CopyOptions struct {
+	// CreateTarget creates the target table from the source table's schema.
+	// Otherwise, CopyTable inserts the rows into the existing target table.
+	CreateTarget bool
+	// OrReplace replaces an existing target table, if CreateTarget is set.
+	// Otherwise, it replaces existing target rows that conflict on the primary key.
+	OrReplace bool
+	// Predicate is an optional SQL expression that filters the copied rows, e.g., "ts >= '2024-01-01'".
+	Predicate string
+	// Progress is called with the query's progress while copying the rows, if not nil.
+	// DuckDB only tracks the progress if the connection enables it, e.g., with SET enable_progress_bar = true.
+	Progress func(QueryProgress)
+}
+
+// CopyTable copies the rows of a table to another table, e.g., from a remote database to a local cache file.
+// The tables can be in different attached databases, and their names can be qualified, e.g., "lake.main.events".
+// The table names and the predicate are inserted into the query as-is.
+// It returns the number of copied rows.
+func CopyTable(ctx context.Context, c *sql.Conn, fromQualified, toQualified string, opts CopyOptions) (int64, error) {
+	source := `SELECT * FROM ` + fromQualified
+	if opts.Predicate != "" {
+		source += ` WHERE ` + opts.Predicate
+	}
+
+	var query string
+	switch {
+	case opts.CreateTarget && opts.OrReplace:
+		query = fmt.Sprintf(`CREATE OR REPLACE TABLE %s AS %s`, toQualified, source)
+	case opts.CreateTarget:
+		query = fmt.Sprintf(`CREATE TABLE %s AS %s`, toQualified, source)
+	case opts.OrReplace:
+		query = fmt.Sprintf(`INSERT OR REPLACE INTO %s %s`, toQualified, source)
+	default:
+		query = fmt.Sprintf(`INSERT INTO %s %s`, toQualified, source)
+	}
+
+	var count int64
+	err := c.Raw(func(driverConn any) error {
+		conn, ok := driverConn.(*Conn)
+		if !ok {
+			return errInvalidCon
+		}
+		p, err := conn.PendingQueryContext(ctx, query, nil)
+		if err != nil {
+			return err
+		}
+		defer p.Close()
+
+		if err = p.wait(ctx, opts.Progress); err != nil {
+			return err
+		}
+		res, err := p.Result()
+		if err != nil {
+			return err
+		}
+		count, err = res.RowsAffected()
+		return err
+	})
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return 0, ctxErr
+		}
+		return 0, getError(errCopyTable, err)
+	}
+	return count, nil
+}
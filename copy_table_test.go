@@ -0,0 +1,78 @@
+package duckdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyTable(t *testing.T) {
+	db := openDbWrapper(t, ``)
+	defer closeDbWrapper(t, db)
+	conn := openConnWrapper(t, db, context.Background())
+	defer closeConnWrapper(t, conn)
+
+	_, err := conn.ExecContext(context.Background(), `ATTACH ':memory:' AS cache; SET enable_progress_bar = true`)
+	require.NoError(t, err)
+	_, err = conn.ExecContext(context.Background(), `CREATE TABLE src AS SELECT range::INTEGER AS id, range % 10 AS v FROM range(10000000)`)
+	require.NoError(t, err)
+
+	var calls int
+	count, err := CopyTable(context.Background(), conn, "memory.main.src", "cache.main.dst", CopyOptions{
+		CreateTarget: true,
+		Progress: func(progress QueryProgress) {
+			calls++
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(10000000), count)
+	require.Positive(t, calls)
+
+	// The target exists.
+	_, err = CopyTable(context.Background(), conn, "src", "cache.dst", CopyOptions{CreateTarget: true})
+	testError(t, err, errCopyTable.Error(), "already exists")
+
+	count, err = CopyTable(context.Background(), conn, "src", "cache.dst", CopyOptions{CreateTarget: true, OrReplace: true, Predicate: "v = 1"})
+	require.NoError(t, err)
+	require.Equal(t, int64(1000000), count)
+
+	count, err = CopyTable(context.Background(), conn, "src", "cache.dst", CopyOptions{Predicate: "v = 2"})
+	require.NoError(t, err)
+	require.Equal(t, int64(1000000), count)
+
+	var total int64
+	require.NoError(t, conn.QueryRowContext(context.Background(), `SELECT count(*) FROM cache.dst`).Scan(&total))
+	require.Equal(t, int64(2000000), total)
+
+	// Replace conflicting rows.
+	_, err = conn.ExecContext(context.Background(), `CREATE TABLE cache.keyed (id INTEGER PRIMARY KEY, v BIGINT)`)
+	require.NoError(t, err)
+	_, err = conn.ExecContext(context.Background(), `INSERT INTO cache.keyed VALUES (1, 42)`)
+	require.NoError(t, err)
+	count, err = CopyTable(context.Background(), conn, "src", "cache.keyed", CopyOptions{OrReplace: true, Predicate: "id < 5"})
+	require.NoError(t, err)
+	require.Equal(t, int64(5), count)
+
+	var v int64
+	require.NoError(t, conn.QueryRowContext(context.Background(), `SELECT v FROM cache.keyed WHERE id = 1`).Scan(&v))
+	require.Equal(t, int64(1), v)
+}
+
+func TestErrCopyTable(t *testing.T) {
+	db := openDbWrapper(t, ``)
+	defer closeDbWrapper(t, db)
+	conn := openConnWrapper(t, db, context.Background())
+	defer closeConnWrapper(t, conn)
+
+	_, err := CopyTable(context.Background(), conn, "does_not_exist", "dst", CopyOptions{CreateTarget: true})
+	testError(t, err, errCopyTable.Error(), "does_not_exist")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = CopyTable(ctx, conn, "range(10000000000)", "dst", CopyOptions{
+		CreateTarget: true,
+		Progress:     func(QueryProgress) {},
+	})
+	require.ErrorIs(t, err, context.Canceled)
+}
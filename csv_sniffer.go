@@ -0,0 +1,107 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+)
+
+// SniffCSVOptions configures SniffCSV.
+type SniffCSVOptions struct {
+	// SampleSize is the number of rows to sample for detecting the dialect and types.
+	// Zero uses DuckDB's default, and -1 samples the entire file.
+	SampleSize int
+}
+
+// CSVColumn is a column detected by SniffCSV.
+type CSVColumn struct {
+	// Name of the column.
+	Name string
+	// Type is the name of the column's detected DuckDB type, e.g., BIGINT.
+	Type string
+}
+
+// CSVSchema contains the dialect and the columns of a CSV file detected by SniffCSV.
+type CSVSchema struct {
+	Delimiter string
+	Quote     string
+	Escape    string
+	// NewLine is the escaped new line delimiter, e.g., \n.
+	NewLine string
+	// Comment is the comment character, or empty, if the file has no comments.
+	Comment   string
+	SkipRows  uint32
+	HasHeader bool
+	Columns   []CSVColumn
+	// DateFormat and TimestampFormat are the detected formats, if any.
+	DateFormat      string
+	TimestampFormat string
+	// ReadCSV is a read_csv query fragment with all detected options, e.g., FROM read_csv('file.csv', ...).
+	ReadCSV string
+}
+
+// SniffCSV detects the dialect and the column types of a CSV file with DuckDB's sniff_csv function.
+// The source is either the path of the file or an io.Reader.
+// SniffCSV copies the contents of an io.Reader to a temporary file.
+func SniffCSV(ctx context.Context, c *sql.Conn, source any, opts SniffCSVOptions) (CSVSchema, error) {
+	schema := CSVSchema{}
+
+	var path string
+	switch src := source.(type) {
+	case string:
+		path = src
+	case io.Reader:
+		f, err := os.CreateTemp("", "duckdb-sniff-*.csv")
+		if err != nil {
+			return schema, getError(errSniffCSV, err)
+		}
+		defer os.Remove(f.Name())
+
+		_, err = io.Copy(f, src)
+		errClose := f.Close()
+		if err != nil {
+			return schema, getError(errSniffCSV, err)
+		}
+		if errClose != nil {
+			return schema, getError(errSniffCSV, errClose)
+		}
+		path = f.Name()
+	default:
+		return schema, getError(errSniffCSV, unsupportedTypeError(fmt.Sprintf("%T", source)))
+	}
+
+	sniff := `sniff_csv(?)`
+	args := []any{path}
+	if opts.SampleSize != 0 {
+		sniff = `sniff_csv(?, sample_size = ?)`
+		args = append(args, opts.SampleSize)
+	}
+	query := `SELECT Delimiter, Quote, Escape, NewLineDelimiter, Comment, SkipRows, HasHeader, Columns,
+		DateFormat, TimestampFormat, Prompt FROM ` + sniff
+
+	var columns []any
+	var comment, dateFormat, timestampFormat sql.NullString
+	err := c.QueryRowContext(ctx, query, args...).Scan(&schema.Delimiter, &schema.Quote, &schema.Escape,
+		&schema.NewLine, &comment, &schema.SkipRows, &schema.HasHeader, &columns,
+		&dateFormat, &timestampFormat, &schema.ReadCSV)
+	if err != nil {
+		return schema, getError(errSniffCSV, err)
+	}
+
+	// DuckDB returns the NUL character if the file has no comments.
+	if comment.String != "\x00" {
+		schema.Comment = comment.String
+	}
+	schema.DateFormat = dateFormat.String
+	schema.TimestampFormat = timestampFormat.String
+
+	for _, column := range columns {
+		m := column.(map[string]any)
+		name, _ := m["name"].(string)
+		typeName, _ := m["type"].(string)
+		schema.Columns = append(schema.Columns, CSVColumn{Name: name, Type: typeName})
+	}
+	return schema, nil
+}
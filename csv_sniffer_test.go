@@ -0,0 +1,52 @@
+package duckdb
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSniffCSV(t *testing.T) {
+	db := openDbWrapper(t, ``)
+	defer closeDbWrapper(t, db)
+	conn := openConnWrapper(t, db, context.Background())
+	defer closeConnWrapper(t, conn)
+
+	content := "id;name;day\n1;'a';2024-01-01\n2;'b';2024-01-02\n"
+	path := filepath.Join(t.TempDir(), "sniff.csv")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+
+	expected := []CSVColumn{{"id", "BIGINT"}, {"name", "VARCHAR"}, {"day", "DATE"}}
+
+	schema, err := SniffCSV(context.Background(), conn, path, SniffCSVOptions{SampleSize: -1})
+	require.NoError(t, err)
+	require.Equal(t, ";", schema.Delimiter)
+	require.Equal(t, "'", schema.Quote)
+	require.Equal(t, "", schema.Comment)
+	require.True(t, schema.HasHeader)
+	require.Equal(t, expected, schema.Columns)
+	require.Equal(t, "%Y-%m-%d", schema.DateFormat)
+	require.Equal(t, "", schema.TimestampFormat)
+	require.Contains(t, schema.ReadCSV, "read_csv")
+
+	schema, err = SniffCSV(context.Background(), conn, strings.NewReader(content), SniffCSVOptions{})
+	require.NoError(t, err)
+	require.Equal(t, expected, schema.Columns)
+}
+
+func TestErrSniffCSV(t *testing.T) {
+	db := openDbWrapper(t, ``)
+	defer closeDbWrapper(t, db)
+	conn := openConnWrapper(t, db, context.Background())
+	defer closeConnWrapper(t, conn)
+
+	_, err := SniffCSV(context.Background(), conn, 42, SniffCSVOptions{})
+	testError(t, err, errSniffCSV.Error(), unsupportedTypeErrMsg)
+
+	_, err = SniffCSV(context.Background(), conn, filepath.Join(t.TempDir(), "missing.csv"), SniffCSVOptions{})
+	testError(t, err, errSniffCSV.Error(), "missing.csv")
+}
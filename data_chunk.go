@@ -2,6 +2,10 @@ package duckdb
 
 import "C"
 import (
+	"fmt"
+	"reflect"
+	"unsafe"
+
 	"github.com/marcboeker/go-duckdb/mapping"
 )
 
@@ -15,6 +19,18 @@ type DataChunk struct {
 	columnNames []string
 	// size caches the size after initialization.
 	size int
+	// readOnly is true for the data chunks of query results and UDF inputs, which must not be written.
+	readOnly bool
+}
+
+// estimatedBytes returns the estimated memory size of the data chunk.
+// It assumes 16 bytes per value, plus the size of the written VARCHAR and BLOB values.
+func (chunk *DataChunk) estimatedBytes() int64 {
+	n := int64(GetDataChunkCapacity() * len(chunk.columns) * 16)
+	for i := range chunk.columns {
+		n += int64(chunk.columns[i].writtenBytes())
+	}
+	return n
 }
 
 // GetDataChunkCapacity returns the capacity of a data chunk.
@@ -30,6 +46,9 @@ func (chunk *DataChunk) GetSize() int {
 
 // SetSize sets the internal size of the data chunk. Cannot exceed GetCapacity().
 func (chunk *DataChunk) SetSize(size int) error {
+	if chunk.readOnly {
+		return getError(errAPI, errReadOnlyChunk)
+	}
 	if size > GetDataChunkCapacity() {
 		return getError(errAPI, errVectorSize)
 	}
@@ -39,18 +58,61 @@ func (chunk *DataChunk) SetSize(size int) error {
 
 // GetValue returns a single value of a column.
 func (chunk *DataChunk) GetValue(colIdx int, rowIdx int) (any, error) {
-	if colIdx >= len(chunk.columns) {
-		return nil, getError(errAPI, columnCountError(colIdx, len(chunk.columns)))
+	if err := chunk.checkIndexes(colIdx, rowIdx); err != nil {
+		return nil, err
 	}
 	column := &chunk.columns[colIdx]
 
 	return column.getFn(column, mapping.IdxT(rowIdx)), nil
 }
 
+// ColumnCount returns the number of columns of the data chunk.
+func (chunk *DataChunk) ColumnCount() int {
+	return len(chunk.columns)
+}
+
+// ColumnType returns the type of a column.
+func (chunk *DataChunk) ColumnType(colIdx int) (Type, error) {
+	if colIdx < 0 || colIdx >= len(chunk.columns) {
+		return TYPE_INVALID, getError(errAPI, columnCountError(colIdx, len(chunk.columns)))
+	}
+	return chunk.columns[colIdx].Type, nil
+}
+
+// IsNull returns true, if the value of a column is NULL.
+func (chunk *DataChunk) IsNull(colIdx int, rowIdx int) (bool, error) {
+	if err := chunk.checkIndexes(colIdx, rowIdx); err != nil {
+		return false, err
+	}
+	return chunk.columns[colIdx].getNull(mapping.IdxT(rowIdx)), nil
+}
+
+// checkIndexes returns an error, if the column or the row does not exist.
+// The rows of a read-only data chunk, e.g., of a result, end at its size.
+// The rows of a writable data chunk end at its capacity, as its size is set after writing its rows.
+func (chunk *DataChunk) checkIndexes(colIdx int, rowIdx int) error {
+	if colIdx < 0 || colIdx >= len(chunk.columns) {
+		return getError(errAPI, columnCountError(colIdx, len(chunk.columns)))
+	}
+	if !chunk.readOnly {
+		if rowIdx < 0 || rowIdx >= GetDataChunkCapacity() {
+			return getError(errAPI, errVectorSize)
+		}
+		return nil
+	}
+	if rowIdx < 0 || rowIdx >= chunk.size {
+		return getError(errAPI, addRowIndexToError(errChunkSize, rowIdx))
+	}
+	return nil
+}
+
 // SetValue writes a single value to a column in a data chunk.
 // Note that this requires casting the type for each invocation.
 // NOTE: Custom ENUM types must be passed as string.
 func (chunk *DataChunk) SetValue(colIdx int, rowIdx int, val any) error {
+	if chunk.readOnly {
+		return getError(errAPI, errReadOnlyChunk)
+	}
 	if colIdx >= len(chunk.columns) {
 		return getError(errAPI, columnCountError(colIdx, len(chunk.columns)))
 	}
@@ -59,11 +121,105 @@ func (chunk *DataChunk) SetValue(colIdx int, rowIdx int, val any) error {
 	return column.setFn(column, mapping.IdxT(rowIdx), val)
 }
 
+// SetNullRange sets count rows of a column to NULL, starting at rowIdx.
+// It is faster than setting each value to nil, e.g., for sparse columns.
+func (chunk *DataChunk) SetNullRange(colIdx int, rowIdx int, count int) error {
+	if chunk.readOnly {
+		return getError(errAPI, errReadOnlyChunk)
+	}
+	if colIdx >= len(chunk.columns) {
+		return getError(errAPI, columnCountError(colIdx, len(chunk.columns)))
+	}
+	if rowIdx < 0 || count < 0 || rowIdx+count > GetDataChunkCapacity() {
+		return getError(errAPI, errVectorSize)
+	}
+	chunk.columns[colIdx].setNullRange(mapping.IdxT(rowIdx), count)
+	return nil
+}
+
+// GetChunkValue returns a single value of a column as T, e.g., int32 for an INTEGER column.
+// It returns the zero value of T for NULL values, see DataChunk.IsNull.
+// It fails, if the column's values are not of type T.
+func GetChunkValue[T any](chunk *DataChunk, colIdx int, rowIdx int) (T, error) {
+	var zero T
+	if err := chunk.checkIndexes(colIdx, rowIdx); err != nil {
+		return zero, err
+	}
+	column := &chunk.columns[colIdx]
+	val := column.getFn(column, mapping.IdxT(rowIdx))
+	if val == nil {
+		return zero, nil
+	}
+	v, ok := val.(T)
+	if !ok {
+		return zero, getError(errAPI, castError(fmt.Sprintf("%T", val), reflect.TypeFor[T]().String()))
+	}
+	return v, nil
+}
+
+// primitiveElementKinds maps the fixed-width types, whose values GetChunkColumn and scanPrimitiveElements copy at once,
+// to the kinds of their Go types.
+var primitiveElementKinds = map[Type]reflect.Kind{
+	TYPE_BOOLEAN:   reflect.Bool,
+	TYPE_TINYINT:   reflect.Int8,
+	TYPE_SMALLINT:  reflect.Int16,
+	TYPE_INTEGER:   reflect.Int32,
+	TYPE_BIGINT:    reflect.Int64,
+	TYPE_UTINYINT:  reflect.Uint8,
+	TYPE_USMALLINT: reflect.Uint16,
+	TYPE_UINTEGER:  reflect.Uint32,
+	TYPE_UBIGINT:   reflect.Uint64,
+	TYPE_FLOAT:     reflect.Float32,
+	TYPE_DOUBLE:    reflect.Float64,
+}
+
+// GetChunkColumn returns the values of a column as a slice of T, holding the zero value of T for NULL values.
+// It copies the values of BOOLEAN and numeric columns at once, if T has the kind of their Go type,
+// e.g., int32 or a named type of kind int32 for an INTEGER column. Otherwise, the column's values must be of type T.
+// The slice remains valid after the data chunk is released.
+func GetChunkColumn[T any](chunk *DataChunk, colIdx int) ([]T, error) {
+	if colIdx < 0 || colIdx >= len(chunk.columns) {
+		return nil, getError(errAPI, columnCountError(colIdx, len(chunk.columns)))
+	}
+	column := &chunk.columns[colIdx]
+	size := chunk.GetSize()
+	values := make([]T, size)
+
+	if kind, ok := primitiveElementKinds[column.Type]; ok && reflect.TypeFor[T]().Kind() == kind {
+		if size > 0 {
+			copy(values, unsafe.Slice((*T)(column.dataPtr), size))
+		}
+		for i := range values {
+			if column.getNull(mapping.IdxT(i)) {
+				var zero T
+				values[i] = zero
+			}
+		}
+		return values, nil
+	}
+
+	for i := range values {
+		val := column.getFn(column, mapping.IdxT(i))
+		if val == nil {
+			continue
+		}
+		v, ok := val.(T)
+		if !ok {
+			return nil, getError(errAPI, castError(fmt.Sprintf("%T", val), reflect.TypeFor[T]().String()))
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
 // SetChunkValue writes a single value to a column in a data chunk.
 // The difference with `chunk.SetValue` is that `SetChunkValue` does not
 // require casting the value to `any` (implicitly).
 // NOTE: Custom ENUM types must be passed as string.
 func SetChunkValue[T any](chunk DataChunk, colIdx int, rowIdx int, val T) error {
+	if chunk.readOnly {
+		return getError(errAPI, errReadOnlyChunk)
+	}
 	if colIdx >= len(chunk.columns) {
 		return getError(errAPI, columnCountError(colIdx, len(chunk.columns)))
 	}
@@ -73,6 +229,7 @@ func SetChunkValue[T any](chunk DataChunk, colIdx int, rowIdx int, val T) error
 func (chunk *DataChunk) initFromTypes(types []mapping.LogicalType, writable bool) error {
 	// NOTE: initFromTypes does not initialize the column names.
 	columnCount := len(types)
+	chunk.readOnly = !writable
 
 	// Initialize the callback functions to read and write values.
 	chunk.columns = make([]vector, columnCount)
@@ -99,6 +256,7 @@ func (chunk *DataChunk) initFromTypes(types []mapping.LogicalType, writable bool
 }
 
 func (chunk *DataChunk) initFromDuckDataChunk(inputChunk mapping.DataChunk, writable bool) error {
+	chunk.readOnly = !writable
 	columnCount := mapping.DataChunkGetColumnCount(inputChunk)
 	chunk.columns = make([]vector, columnCount)
 	chunk.chunk = inputChunk
@@ -124,6 +282,7 @@ func (chunk *DataChunk) initFromDuckDataChunk(inputChunk mapping.DataChunk, writ
 }
 
 func (chunk *DataChunk) initFromDuckVector(vec mapping.Vector, writable bool) error {
+	chunk.readOnly = !writable
 	columnCount := 1
 	chunk.columns = make([]vector, columnCount)
 
@@ -141,6 +300,24 @@ func (chunk *DataChunk) initFromDuckVector(vec mapping.Vector, writable bool) er
 	return nil
 }
 
+// reset clears a writable data chunk for reuse. It reinitializes the vectors,
+// as resetting the chunk replaces their buffers and validity masks.
+func (chunk *DataChunk) reset() {
+	mapping.DataChunkReset(chunk.chunk)
+	mapping.DataChunkSetSize(chunk.chunk, mapping.IdxT(GetDataChunkCapacity()))
+	for i := range chunk.columns {
+		v := mapping.DataChunkGetVector(chunk.chunk, mapping.IdxT(i))
+		chunk.columns[i].initVectors(v, true)
+		chunk.columns[i].resetWrittenBytes()
+	}
+}
+
+// Close destroys a data chunk created with Appender.NewDataChunk.
+// Do not close the data chunks that the driver passes to table and scalar UDFs.
+func (chunk *DataChunk) Close() {
+	chunk.close()
+}
+
 func (chunk *DataChunk) close() {
 	mapping.DestroyDataChunk(&chunk.chunk)
 }
@@ -0,0 +1,39 @@
+//go:build duckdb_shopspring
+
+package duckdb
+
+import (
+	"database/sql/driver"
+
+	"github.com/shopspring/decimal"
+)
+
+// ShopspringDecimal adapts a decimal.Decimal of github.com/shopspring/decimal to DECIMAL columns.
+// Scan a DECIMAL value into a decimal.Decimal d by passing (*ShopspringDecimal)(&d) to Scan,
+// and bind or append it as ShopspringDecimal(d). Use sql.Null[ShopspringDecimal] for NULL values.
+// The conversions are lossless, as long as the value fits the DECIMAL column.
+type ShopspringDecimal decimal.Decimal
+
+// Scan implements the sql.Scanner interface. It accepts DECIMAL values,
+// and the values that decimal.Decimal scans, e.g., integers, floats, and strings.
+func (d *ShopspringDecimal) Scan(v any) error {
+	if v, ok := v.(Decimal); ok {
+		*d = ShopspringDecimal(v.Shopspring())
+		return nil
+	}
+	return (*decimal.Decimal)(d).Scan(v)
+}
+
+// Value implements the driver.Valuer interface. It returns the decimal's exact string representation,
+// which DuckDB casts to the DECIMAL type of the parameter or column.
+func (d ShopspringDecimal) Value() (driver.Value, error) {
+	return decimal.Decimal(d).String(), nil
+}
+
+// Shopspring returns the DECIMAL value as a decimal.Decimal of github.com/shopspring/decimal.
+func (d *Decimal) Shopspring() decimal.Decimal {
+	if d.Value == nil {
+		return decimal.Zero
+	}
+	return decimal.NewFromBigInt(d.Value, -int32(d.Scale))
+}
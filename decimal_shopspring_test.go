@@ -0,0 +1,59 @@
+//go:build duckdb_shopspring
+
+package duckdb
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShopspringDecimal(t *testing.T) {
+	db := openDbWrapper(t, ``)
+	defer closeDbWrapper(t, db)
+
+	// Scan DECIMAL values of all internal types.
+	tests := []string{"1.5::DECIMAL(4, 1)", "-1234.5678::DECIMAL(9, 4)", "123456789012.34::DECIMAL(18, 2)", "-12345678901234567890123456.789::DECIMAL(38, 3)"}
+	expected := []string{"1.5", "-1234.5678", "123456789012.34", "-12345678901234567890123456.789"}
+	for i, test := range tests {
+		var d decimal.Decimal
+		require.NoError(t, db.QueryRow(`SELECT `+test).Scan((*ShopspringDecimal)(&d)))
+		require.Equal(t, expected[i], d.String())
+	}
+
+	// Bind values to DECIMAL parameters, and scan other types.
+	var d decimal.Decimal
+	in := decimal.RequireFromString("98765432109876543210.0123456789")
+	require.NoError(t, db.QueryRow(`SELECT ?::DECIMAL(38, 10)`, ShopspringDecimal(in)).Scan((*ShopspringDecimal)(&d)))
+	require.True(t, in.Equal(d))
+	require.NoError(t, db.QueryRow(`SELECT 42::BIGINT`).Scan((*ShopspringDecimal)(&d)))
+	require.Equal(t, "42", d.String())
+
+	// Scan NULL values.
+	var null sql.Null[ShopspringDecimal]
+	require.NoError(t, db.QueryRow(`SELECT NULL::DECIMAL(4, 1)`).Scan(&null))
+	require.False(t, null.Valid)
+	require.Error(t, db.QueryRow(`SELECT NULL::DECIMAL(4, 1)`).Scan((*ShopspringDecimal)(&d)))
+
+	// Append values.
+	c, db, conn, a := prepareAppender(t, `CREATE TABLE test (d DECIMAL(10, 3))`)
+	defer cleanupAppender(t, c, db, conn, a)
+
+	require.NoError(t, a.AppendRow(ShopspringDecimal(decimal.RequireFromString("-1234567.891"))))
+	require.NoError(t, a.AppendRow(ShopspringDecimal(decimal.New(5, -1))))
+	require.NoError(t, a.Flush())
+
+	res, err := db.Query(`SELECT d FROM test ORDER BY rowid`)
+	require.NoError(t, err)
+	defer closeRowsWrapper(t, res)
+
+	var values []string
+	for res.Next() {
+		require.NoError(t, res.Scan((*ShopspringDecimal)(&d)))
+		values = append(values, d.String())
+	}
+	require.NoError(t, res.Err())
+	require.Equal(t, []string{"-1234567.891", "0.5"}, values)
+}
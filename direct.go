@@ -0,0 +1,93 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+
+	"github.com/marcboeker/go-duckdb/mapping"
+)
+
+// DirectResult is the result of a query executed with QueryDirect.
+// Instead of converting each value to a driver.Value, it exposes the result's data chunks.
+type DirectResult struct {
+	r *rows
+}
+
+// QueryDirect executes a query on the driver connection, bypassing database/sql.
+// Like QueryContext, it executes all statements but the last one of a multi-statement query without arguments.
+// The arguments are bound to the last statement. sql.NamedArg arguments bind to named parameters.
+// It is the caller's responsibility to close the DirectResult.
+// The connection must not execute any other query until closing the DirectResult.
+func (conn *Conn) QueryDirect(ctx context.Context, query string, args ...any) (*DirectResult, error) {
+	stmt, err := conn.prepareStmts(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	nargs := make([]driver.NamedValue, len(args))
+	for i, arg := range args {
+		nargs[i] = driver.NamedValue{Ordinal: i + 1, Value: arg}
+		if named, ok := arg.(sql.NamedArg); ok {
+			nargs[i].Name = named.Name
+			nargs[i].Value = named.Value
+		}
+	}
+
+	res, err := stmt.execute(ctx, nargs, false)
+	if err != nil {
+		return nil, closeStmtOnError(stmt, err)
+	}
+
+	stmt.rows = true
+	stmt.closeOnRowsClose = true
+	return &DirectResult{r: newRowsWithStmt(*res, stmt)}, nil
+}
+
+// Columns returns the names of the result's columns.
+func (d *DirectResult) Columns() []string {
+	return d.r.Columns()
+}
+
+// ColumnTypes returns the types of the result's columns.
+func (d *DirectResult) ColumnTypes() []Type {
+	types := make([]Type, len(d.r.chunk.columnNames))
+	for i := range types {
+		types[i] = Type(mapping.ColumnType(&d.r.res, mapping.IdxT(i)))
+	}
+	return types
+}
+
+// StatementType returns the type of the executed statement.
+func (d *DirectResult) StatementType() StmtType {
+	return StmtType(mapping.ResultStatementType(d.r.res))
+}
+
+// RowsChanged returns the number of rows changed by an INSERT, UPDATE, or DELETE statement.
+func (d *DirectResult) RowsChanged() int64 {
+	return getRowsAffected(&d.r.res)
+}
+
+// NextChunk returns the next data chunk of the result, or io.EOF, if there are no more chunks.
+// The returned chunk is only valid until the next call to NextChunk or Close.
+// It is read-only: read its values with DataChunk.GetValue, GetChunkValue, and GetChunkColumn.
+func (d *DirectResult) NextChunk() (*DataChunk, error) {
+	return d.r.NextChunk()
+}
+
+// ProfilingInfo returns the profiling metrics of the executed query.
+// Profiling must be enabled on the connection, e.g., with PRAGMA enable_profiling = 'no_output'.
+func (d *DirectResult) ProfilingInfo() (ProfilingInfo, error) {
+	if d.r.stmt == nil {
+		return ProfilingInfo{}, errClosedStmt
+	}
+	return d.r.stmt.conn.getProfilingInfo()
+}
+
+// Close releases the result and its prepared statement.
+func (d *DirectResult) Close() error {
+	if d.r.stmt == nil {
+		return nil
+	}
+	return d.r.Close()
+}
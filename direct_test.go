@@ -0,0 +1,194 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryDirect(t *testing.T) {
+	c := newConnectorWrapper(t, ``, nil)
+	defer closeConnectorWrapper(t, c)
+	conn := openDriverConnWrapper(t, c)
+	defer closeDriverConnWrapper(t, &conn)
+	duckConn := conn.(*Conn)
+
+	t.Run("chunks", func(t *testing.T) {
+		res, err := duckConn.QueryDirect(context.Background(),
+			`PRAGMA enable_profiling = 'no_output'; SELECT range AS i, range::VARCHAR AS s FROM range($n)`,
+			sql.Named("n", 5000))
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"i", "s"}, res.Columns())
+		require.Equal(t, []Type{TYPE_BIGINT, TYPE_VARCHAR}, res.ColumnTypes())
+		require.Equal(t, STATEMENT_TYPE_SELECT, res.StatementType())
+
+		var rowCount int
+		for {
+			chunk, errChunk := res.NextChunk()
+			if errChunk == io.EOF {
+				break
+			}
+			require.NoError(t, errChunk)
+
+			for rowIdx := 0; rowIdx < chunk.GetSize(); rowIdx++ {
+				val, errValue := chunk.GetValue(0, rowIdx)
+				require.NoError(t, errValue)
+				require.Equal(t, int64(rowCount), val)
+				rowCount++
+			}
+		}
+		require.Equal(t, 5000, rowCount)
+
+		info, err := res.ProfilingInfo()
+		require.NoError(t, err)
+		require.NotEmpty(t, info.Metrics)
+
+		require.NoError(t, res.Close())
+		require.NoError(t, res.Close())
+		_, err = res.NextChunk()
+		require.ErrorIs(t, err, errClosedStmt)
+	})
+
+	t.Run("typed accessors", func(t *testing.T) {
+		type id int64
+		res, err := duckConn.QueryDirect(context.Background(), `SELECT i, 'v' || i AS s, i::DOUBLE / 2 AS d, [i] AS l
+			FROM range(3000) t(i) UNION ALL SELECT NULL, NULL, NULL, NULL`)
+		require.NoError(t, err)
+		defer res.Close()
+
+		var ids []id
+		var strs []string
+		var nulls int
+		for {
+			chunk, errChunk := res.NextChunk()
+			if errChunk == io.EOF {
+				break
+			}
+			require.NoError(t, errChunk)
+			require.Equal(t, 4, chunk.ColumnCount())
+			colType, errType := chunk.ColumnType(3)
+			require.NoError(t, errType)
+			require.Equal(t, TYPE_LIST, colType)
+
+			chunkIDs, errColumn := GetChunkColumn[id](chunk, 0)
+			require.NoError(t, errColumn)
+			ids = append(ids, chunkIDs...)
+			chunkStrs, errColumn := GetChunkColumn[string](chunk, 1)
+			require.NoError(t, errColumn)
+			strs = append(strs, chunkStrs...)
+
+			for rowIdx := 0; rowIdx < chunk.GetSize(); rowIdx++ {
+				isNull, errNull := chunk.IsNull(2, rowIdx)
+				require.NoError(t, errNull)
+				d, errValue := GetChunkValue[float64](chunk, 2, rowIdx)
+				require.NoError(t, errValue)
+				if isNull {
+					nulls++
+					require.Zero(t, d)
+				} else {
+					require.Equal(t, float64(chunkIDs[rowIdx])/2, d)
+				}
+			}
+
+			// The values must be of the requested type.
+			if isNull, _ := chunk.IsNull(0, 0); !isNull {
+				_, errColumn = GetChunkColumn[int32](chunk, 0)
+				require.ErrorContains(t, errColumn, castErrMsg)
+				_, errValue := GetChunkValue[string](chunk, 2, 0)
+				require.ErrorContains(t, errValue, castErrMsg)
+				l, errValue := GetChunkValue[[]any](chunk, 3, 0)
+				require.NoError(t, errValue)
+				require.Len(t, l, 1)
+			}
+			_, errValue := GetChunkValue[any](chunk, 4, 0)
+			require.ErrorIs(t, errValue, errAPI)
+
+			// The chunks of a result are read-only.
+			require.ErrorIs(t, chunk.SetValue(0, 0, int64(1)), errAPI)
+			require.ErrorContains(t, chunk.SetNullRange(0, 0, 1), errReadOnlyChunk.Error())
+			require.ErrorContains(t, chunk.SetSize(1), errReadOnlyChunk.Error())
+			require.ErrorContains(t, SetChunkValue(*chunk, 0, 0, int64(1)), errReadOnlyChunk.Error())
+		}
+		require.Len(t, ids, 3001)
+		require.Equal(t, 1, nulls)
+		for i := 0; i < 3000; i++ {
+			require.Equal(t, id(i), ids[i])
+			require.Equal(t, fmt.Sprintf("v%d", i), strs[i])
+		}
+		require.Zero(t, ids[3000])
+		require.Empty(t, strs[3000])
+	})
+
+	t.Run("rows", func(t *testing.T) {
+		driverRows, err := duckConn.QueryContext(context.Background(), `SELECT range AS i FROM range(5000)`, nil)
+		require.NoError(t, err)
+		r, ok := driverRows.(Rows)
+		require.True(t, ok)
+
+		// NextChunk skips the remaining rows of the current chunk.
+		values := make([]driver.Value, 1)
+		require.NoError(t, r.Next(values))
+		require.Equal(t, int64(0), values[0])
+		chunk, err := r.NextChunk()
+		require.NoError(t, err)
+		first, err := chunk.GetValue(0, 0)
+		require.NoError(t, err)
+		require.Equal(t, int64(GetDataChunkCapacity()), first)
+
+		// The rows of a result chunk end at its size.
+		size := chunk.GetSize()
+		_, err = chunk.GetValue(0, size)
+		require.ErrorContains(t, err, errChunkSize.Error())
+		_, err = chunk.IsNull(0, size)
+		require.ErrorContains(t, err, errChunkSize.Error())
+		_, err = GetChunkValue[int64](chunk, 0, -1)
+		require.ErrorContains(t, err, errChunkSize.Error())
+
+		rowCount := GetDataChunkCapacity() + size
+		for {
+			chunk, err = r.NextChunk()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			rowCount += chunk.GetSize()
+		}
+		require.Equal(t, 5000, rowCount)
+		require.Equal(t, io.EOF, r.Next(values))
+
+		require.NoError(t, r.Close())
+		_, err = r.NextChunk()
+		require.ErrorIs(t, err, errClosedStmt)
+	})
+
+	t.Run("rows changed", func(t *testing.T) {
+		res, err := duckConn.QueryDirect(context.Background(), `CREATE TABLE direct (i INTEGER)`)
+		require.NoError(t, err)
+		require.NoError(t, res.Close())
+
+		res, err = duckConn.QueryDirect(context.Background(), `INSERT INTO direct SELECT range FROM range(?)`, 42)
+		require.NoError(t, err)
+		require.Equal(t, int64(42), res.RowsChanged())
+		require.Equal(t, STATEMENT_TYPE_INSERT, res.StatementType())
+		require.NoError(t, res.Close())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		_, err := duckConn.QueryDirect(context.Background(), `SELECT * FROM does_not_exist`)
+		require.Error(t, err)
+
+		_, err = duckConn.QueryDirect(context.Background(), `SELECT ?::INTEGER`, "not a number")
+		require.Error(t, err)
+
+		// The connection is still usable.
+		res, err := duckConn.QueryDirect(context.Background(), `SELECT 42`)
+		require.NoError(t, err)
+		require.NoError(t, res.Close())
+	})
+}
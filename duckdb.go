@@ -2,6 +2,10 @@
 // license that can be found in the LICENSE file.
 
 // Package duckdb implements a database/sql driver for the DuckDB database.
+//
+// With Go 1.27 or later, rows scan values into more destinations than database/sql supports,
+// e.g., LIST values into Go slices, JSON values into json.RawMessage, and TIME values into time.Duration.
+// Earlier Go versions only scan into the destinations of database/sql and go-duckdb's own types, e.g., Composite.
 package duckdb
 
 import (
@@ -12,7 +16,10 @@ import (
 	"net/url"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/go-viper/mapstructure/v2"
 	"github.com/marcboeker/go-duckdb/mapping"
 )
 
@@ -25,9 +32,32 @@ func init() {
 	sql.Register("duckdb", Driver{})
 }
 
+// Driver is the DuckDB database/sql driver.
+// The driver registered as "duckdb" has no preset options, see RegisterDriver.
 type Driver struct{}
 
+// RegisterDriver registers a DuckDB driver with preset Connector options under the name.
+// Opening a database with the driver name, e.g., with sql.Open("duckdb-analytics", "analytics.db"),
+// opens a Connector configured by the options. Like sql.Register, it panics,
+// if a driver with the same name is already registered.
+func RegisterDriver(name string, opts ...ConnectorOption) {
+	sql.Register(name, optionsDriver{opts: opts})
+}
+
 func (d Driver) Open(dsn string) (driver.Conn, error) {
+	return optionsDriver{}.Open(dsn)
+}
+
+func (d Driver) OpenConnector(dsn string) (driver.Connector, error) {
+	return optionsDriver{}.OpenConnector(dsn)
+}
+
+// optionsDriver is a driver whose Connectors are configured by preset options, see RegisterDriver.
+type optionsDriver struct {
+	opts []ConnectorOption
+}
+
+func (d optionsDriver) Open(dsn string) (driver.Conn, error) {
 	c, err := d.OpenConnector(dsn)
 	if err != nil {
 		return nil, err
@@ -36,16 +66,134 @@ func (d Driver) Open(dsn string) (driver.Conn, error) {
 	return c.Connect(context.Background())
 }
 
-func (Driver) OpenConnector(dsn string) (driver.Connector, error) {
-	return NewConnector(dsn, func(execerContext driver.ExecerContext) error {
+func (d optionsDriver) OpenConnector(dsn string) (driver.Connector, error) {
+	return NewConnectorWithOptions(dsn, func(execerContext driver.ExecerContext) error {
 		return nil
-	})
+	}, d.opts...)
 }
 
 // NewConnector opens a new Connector for a DuckDB database.
 // The user must close the Connector, if it is not passed to the sql.OpenDB function.
 // Otherwise, sql.DB closes the Connector when calling sql.DB.Close().
 func NewConnector(dsn string, connInitFn func(execer driver.ExecerContext) error) (*Connector, error) {
+	return NewConnectorWithOptions(dsn, connInitFn)
+}
+
+// ConnectorOption configures a Connector.
+type ConnectorOption func(c *Connector)
+
+// WithDefaultQueryTimeout sets a timeout for all statements executed on the Connector's connections,
+// unless their context already has a deadline. The driver interrupts statements exceeding the timeout,
+// and returns context.DeadlineExceeded. Zero or a negative value disables the timeout.
+func WithDefaultQueryTimeout(d time.Duration) ConnectorOption {
+	return func(c *Connector) {
+		c.defaultQueryTimeout = d
+	}
+}
+
+// WithDurationConversion sets how the Connector's connections convert time.Duration values
+// to an INTERVAL, when binding them as arguments or appending them. The default is DurationToMicros.
+func WithDurationConversion(conv DurationConversion) ConnectorOption {
+	return func(c *Connector) {
+		c.durationConversion = conv
+	}
+}
+
+// WithOrderedMaps configures the Connector's connections to scan MAP values as OrderedMap instead of Map,
+// which keeps the order of the entries. Map and TypedMap destinations accept OrderedMap values.
+func WithOrderedMaps() ConnectorOption {
+	return func(c *Connector) {
+		c.orderedMaps = true
+	}
+}
+
+// WithNonFiniteFloats sets how the Connector's connections handle NaN, +Inf, and -Inf FLOAT and DOUBLE values,
+// including those nested in LIST, ARRAY, STRUCT, MAP, and UNION values, when scanning them,
+// and when binding them as arguments. The default is NonFiniteFloatsPassThrough.
+func WithNonFiniteFloats(policy NonFiniteFloats) ConnectorOption {
+	return func(c *Connector) {
+		c.nonFiniteFloats = policy
+	}
+}
+
+// WithTimeLocation configures the Connector's connections to scan TIMESTAMP and TIMESTAMP WITH TIME ZONE values
+// into time.Time values in the location instead of UTC. WithQueryTimeLocation overrides the location per query.
+func WithTimeLocation(loc *time.Location) ConnectorOption {
+	return func(c *Connector) {
+		c.timeLocation = loc
+	}
+}
+
+// WithTimeTZOffsets configures the Connector's connections to scan TIMETZ values into time.Time values
+// in a fixed zone with their stored UTC offset, instead of normalizing them to UTC.
+func WithTimeTZOffsets() ConnectorOption {
+	return func(c *Connector) {
+		c.timeTZOffsets = true
+	}
+}
+
+// WithTextCasts configures the Connector's connections to scan any non-NULL value into a *string
+// with the text of DuckDB's VARCHAR cast, e.g., 1.50 for a DECIMAL(4, 2) value or [1, 2] for an INTEGER[] value.
+// Scanning with the cast text requires Go 1.27 or later.
+func WithTextCasts() ConnectorOption {
+	return func(c *Connector) {
+		c.textCasts = true
+	}
+}
+
+// WithCompositeFieldNaming sets how the Connector's connections match STRUCT field names to struct field names,
+// including the names of duckdb, db, and mapstructure tags, when scanning STRUCT values into Go structs,
+// or into Composite, StrictComposite, TypedMap, Union, and Null. The default is FieldNamingCaseInsensitive.
+// Scanning with the field naming requires Go 1.27 or later. Earlier versions scan with the default.
+func WithCompositeFieldNaming(naming FieldNaming) ConnectorOption {
+	return func(c *Connector) {
+		c.compositeOptions.naming = naming
+	}
+}
+
+// WithCompositeDecodeHooks adds mapstructure decode hooks converting values when the Connector's connections
+// scan STRUCT, LIST, ARRAY, MAP, and UNION values into Go structs, or into Composite, StrictComposite, TypedMap,
+// Union, and Null, e.g., mapstructure.StringToTimeHookFunc(time.RFC3339).
+// The hooks apply to nested values, and run in the order of the options.
+// Scanning with the hooks requires Go 1.27 or later. Earlier versions scan without them.
+func WithCompositeDecodeHooks(hooks ...mapstructure.DecodeHookFunc) ConnectorOption {
+	return func(c *Connector) {
+		c.compositeOptions.hooks = append(c.compositeOptions.hooks, hooks...)
+	}
+}
+
+// WithConnLabels sets the function naming the Connector's connections.
+// The function receives the unique ID of each new connection within the Connector.
+// By default, the connections are numbered, i.e., conn-1, conn-2, etc.
+func WithConnLabels(labelFn func(id uint64) string) ConnectorOption {
+	return func(c *Connector) {
+		c.connLabelFn = labelFn
+	}
+}
+
+// WithConfig sets a DuckDB configuration option, e.g., access_mode or memory_limit,
+// when opening the Connector's database. Options in the DSN take precedence.
+func WithConfig(name string, value string) ConnectorOption {
+	return func(c *Connector) {
+		if c.config == nil {
+			c.config = make(map[string]string)
+		}
+		c.config[name] = value
+	}
+}
+
+// WithConnInit adds a function initializing each new connection, e.g., by loading extensions
+// or setting connection-local settings. The functions run in the order of their options,
+// before the Connector's connInitFn.
+func WithConnInit(initFn func(execer driver.ExecerContext) error) ConnectorOption {
+	return func(c *Connector) {
+		c.initFns = append(c.initFns, initFn)
+	}
+}
+
+// NewConnectorWithOptions opens a new Connector for a DuckDB database, configured by the options.
+// See NewConnector.
+func NewConnectorWithOptions(dsn string, connInitFn func(execer driver.ExecerContext) error, opts ...ConnectorOption) (*Connector, error) {
 	inMemory := false
 	const inMemoryName = ":memory:"
 
@@ -57,47 +205,74 @@ func NewConnector(dsn string, connInitFn func(execer driver.ExecerContext) error
 		inMemory = true
 	}
 
+	c := &Connector{
+		connInitFn: connInitFn,
+		opts:       opts,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
 	parsedDSN, err := url.Parse(dsn)
 	if err != nil {
 		return nil, getError(errParseDSN, err)
 	}
 
-	config, err := prepareConfig(parsedDSN)
+	config, err := prepareConfig(parsedDSN, c.config)
 	if err != nil {
 		return nil, err
 	}
 	defer mapping.DestroyConfig(&config)
 
-	var db mapping.Database
 	var errMsg string
 	var state mapping.State
 
 	if inMemory {
 		// Open an in-memory database.
-		state = mapping.OpenExt("", &db, config, &errMsg)
+		state = mapping.OpenExt("", &c.db, config, &errMsg)
 	} else {
 		// Open a file-backed database.
-		state = mapping.GetOrCreateFromCache(GetInstanceCache(), getDBPath(dsn), &db, config, &errMsg)
+		state = mapping.GetOrCreateFromCache(GetInstanceCache(), getDBPath(dsn), &c.db, config, &errMsg)
 	}
 	if state == mapping.StateError {
-		mapping.Close(&db)
+		mapping.Close(&c.db)
 		return nil, getError(errConnect, getDuckDBError(errMsg))
 	}
 
-	return &Connector{
-		db:         db,
-		connInitFn: connInitFn,
-	}, nil
+	return c, nil
 }
 
 type Connector struct {
 	closed     bool
 	db         mapping.Database
 	connInitFn func(execer driver.ExecerContext) error
+
+	// opts are the options configuring the Connector.
+	opts []ConnectorOption
+	// config contains the DuckDB configuration options set with WithConfig.
+	config map[string]string
+	// initFns contain the connection initialization functions added with WithConnInit.
+	initFns []func(execer driver.ExecerContext) error
+
+	defaultQueryTimeout time.Duration
+	durationConversion  DurationConversion
+	orderedMaps         bool
+	timeLocation        *time.Location
+	timeTZOffsets       bool
+	nonFiniteFloats     NonFiniteFloats
+	textCasts           bool
+	compositeOptions    compositeOptions
+
+	// connCount is the number of opened connections.
+	connCount   atomic.Uint64
+	connLabelFn func(id uint64) string
 }
 
-func (*Connector) Driver() driver.Driver {
-	return Driver{}
+func (c *Connector) Driver() driver.Driver {
+	if len(c.opts) == 0 {
+		return Driver{}
+	}
+	return optionsDriver{opts: c.opts}
 }
 
 func (c *Connector) Connect(context.Context) (driver.Conn, error) {
@@ -106,7 +281,27 @@ func (c *Connector) Connect(context.Context) (driver.Conn, error) {
 		return nil, getError(errConnect, nil)
 	}
 
-	conn := &Conn{conn: newConn}
+	conn := &Conn{
+		conn:                newConn,
+		defaultQueryTimeout: c.defaultQueryTimeout,
+		durationConversion:  c.durationConversion,
+		orderedMaps:         c.orderedMaps,
+		timeLocation:        c.timeLocation,
+		timeTZOffsets:       c.timeTZOffsets,
+		nonFiniteFloats:     c.nonFiniteFloats,
+		textCasts:           c.textCasts,
+		compositeOptions:    c.compositeOptions,
+		id:                  c.connCount.Add(1),
+	}
+	conn.label = fmt.Sprintf("conn-%d", conn.id)
+	if c.connLabelFn != nil {
+		conn.label = c.connLabelFn(conn.id)
+	}
+	for _, initFn := range c.initFns {
+		if err := initFn(conn); err != nil {
+			return nil, err
+		}
+	}
 	if c.connInitFn != nil {
 		if err := c.connInitFn(conn); err != nil {
 			return nil, err
@@ -134,7 +329,7 @@ func getDBPath(dsn string) string {
 	return dsn[0:idx]
 }
 
-func prepareConfig(parsedDSN *url.URL) (mapping.Config, error) {
+func prepareConfig(parsedDSN *url.URL, presets map[string]string) (mapping.Config, error) {
 	var config mapping.Config
 	if mapping.CreateConfig(&config) == mapping.StateError {
 		mapping.DestroyConfig(&config)
@@ -145,12 +340,22 @@ func prepareConfig(parsedDSN *url.URL) (mapping.Config, error) {
 		return config, err
 	}
 
+	query := parsedDSN.Query()
+	for k, v := range presets {
+		if _, ok := query[k]; ok {
+			continue
+		}
+		if err := setConfigOption(config, k, v); err != nil {
+			return config, err
+		}
+	}
+
 	// Early-out, if the DSN does not contain configuration options.
 	if len(parsedDSN.RawQuery) == 0 {
 		return config, nil
 	}
 
-	for k, v := range parsedDSN.Query() {
+	for k, v := range query {
 		if len(v) == 0 {
 			continue
 		}
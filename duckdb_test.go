@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"math"
 	"math/big"
 	"os"
 	"reflect"
@@ -632,6 +633,41 @@ func TestTypeNamesAndScanTypes(t *testing.T) {
 	}
 }
 
+func TestColumnTypeMetadata(t *testing.T) {
+	db := openDbWrapper(t, ``)
+	defer closeDbWrapper(t, db)
+
+	r, err := db.Query(`SELECT 1.5::DECIMAL(18, 3), 'duck', '\xAA'::BLOB, 42, NULL`)
+	require.NoError(t, err)
+	defer closeRowsWrapper(t, r)
+
+	cols, err := r.ColumnTypes()
+	require.NoError(t, err)
+
+	precision, scale, ok := cols[0].DecimalSize()
+	require.True(t, ok)
+	require.Equal(t, int64(18), precision)
+	require.Equal(t, int64(3), scale)
+	_, _, ok = cols[3].DecimalSize()
+	require.False(t, ok)
+
+	length, ok := cols[1].Length()
+	require.True(t, ok)
+	require.Equal(t, int64(math.MaxInt64), length)
+	length, ok = cols[2].Length()
+	require.True(t, ok)
+	require.Equal(t, int64(math.MaxInt64), length)
+	_, ok = cols[3].Length()
+	require.False(t, ok)
+
+	// DuckDB's query results do not report the nullability of their columns.
+	for _, col := range cols {
+		nullable, ok := col.Nullable()
+		require.False(t, ok)
+		require.False(t, nullable)
+	}
+}
+
 func TestMultipleStatements(t *testing.T) {
 	db := openDbWrapper(t, ``)
 	defer closeDbWrapper(t, db)
@@ -693,11 +729,12 @@ func TestMultipleStatements(t *testing.T) {
 	closeRowsWrapper(t, r)
 
 	// SELECT with ExecContext also works, but we don't get the result.
+	// RowsAffected returns the total of all statements.
 	res, err = conn.ExecContext(ctx, `CREATE TABLE foo3(bar VARCHAR, baz INTEGER); INSERT INTO foo3 VALUES ('lala', 12345); SELECT bar FROM foo3 LIMIT 1`)
 	require.NoError(t, err)
 	ra, err = res.RowsAffected()
 	require.NoError(t, err)
-	require.Equal(t, int64(0), ra)
+	require.Equal(t, int64(1), ra)
 
 	// Multiple SELECT, but we get results only for the last one.
 	r, err = conn.QueryContext(ctx, `INSERT INTO foo3 VALUES ('lalo', 1234); SELECT bar FROM foo3 WHERE baz = 12345; SELECT bar FROM foo3 WHERE baz = $1`, 1234)
@@ -765,6 +802,103 @@ func TestQueryTimeout(t *testing.T) {
 	require.Less(t, time.Since(now), 10*time.Second)
 }
 
+func TestDefaultQueryTimeout(t *testing.T) {
+	c, err := NewConnectorWithOptions(``, nil, WithDefaultQueryTimeout(time.Millisecond*250))
+	require.NoError(t, err)
+	db := sql.OpenDB(c)
+	defer closeDbWrapper(t, db)
+
+	now := time.Now()
+	_, err = db.Exec(`CREATE TABLE test AS SELECT * FROM range(10000000) t1, range(1000000) t2`)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Less(t, time.Since(now), 10*time.Second)
+
+	// An explicit deadline takes precedence.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	var count int64
+	require.NoError(t, db.QueryRowContext(ctx, `SELECT count(*) FROM range(1000000)`).Scan(&count))
+	require.Equal(t, int64(1000000), count)
+}
+
+func TestRegisterDriver(t *testing.T) {
+	RegisterDriver("duckdb-test-preset",
+		WithConfig("threads", "2"),
+		WithConfig("memory_limit", "1GB"),
+		WithConnInit(func(execer driver.ExecerContext) error {
+			_, err := execer.ExecContext(context.Background(), `SET VARIABLE preset = 42`, nil)
+			return err
+		}),
+	)
+
+	db, err := sql.Open("duckdb-test-preset", `?memory_limit=2GB`)
+	require.NoError(t, err)
+	defer closeDbWrapper(t, db)
+
+	var (
+		threads     int64
+		memoryLimit string
+		preset      int64
+	)
+	row := db.QueryRow(`SELECT current_setting('threads'), current_setting('memory_limit'), getvariable('preset')`)
+	require.NoError(t, row.Scan(&threads, &memoryLimit, &preset))
+	require.Equal(t, int64(2), threads)
+	// The DSN takes precedence.
+	require.Equal(t, "1.8 GiB", memoryLimit)
+	require.Equal(t, int64(42), preset)
+
+	// Registering the same name twice panics.
+	require.Panics(t, func() {
+		RegisterDriver("duckdb-test-preset")
+	})
+
+	// Invalid configuration options fail when opening the database.
+	RegisterDriver("duckdb-test-invalid", WithConfig("threads", "many"))
+	_, err = sql.Open("duckdb-test-invalid", ``)
+	testError(t, err, errSetConfig.Error())
+
+	// The driver registered as "duckdb" remains comparable.
+	plain := openDbWrapper(t, ``)
+	defer closeDbWrapper(t, plain)
+	require.True(t, plain.Driver() == Driver{})
+	require.False(t, db.Driver() == Driver{})
+}
+
+func TestConnLabels(t *testing.T) {
+	c, err := NewConnectorWithOptions(``, nil, WithConnLabels(func(id uint64) string {
+		return fmt.Sprintf("worker-%d", id)
+	}))
+	require.NoError(t, err)
+	db := sql.OpenDB(c)
+	defer closeDbWrapper(t, db)
+
+	conn1 := openConnWrapper(t, db, context.Background())
+	defer closeConnWrapper(t, conn1)
+	conn2 := openConnWrapper(t, db, context.Background())
+	defer closeConnWrapper(t, conn2)
+
+	label, err := GetConnLabel(conn1)
+	require.NoError(t, err)
+	require.Equal(t, "worker-1", label)
+	label, err = GetConnLabel(conn2)
+	require.NoError(t, err)
+	require.Equal(t, "worker-2", label)
+
+	require.NoError(t, SetConnLabel(conn2, "stuck"))
+	_, err = conn2.ExecContext(context.Background(), `SELECT * FROM does_not_exist`)
+	var duckdbErr *Error
+	require.ErrorAs(t, err, &duckdbErr)
+	require.Equal(t, "stuck", duckdbErr.ConnLabel)
+
+	// The connections are numbered by default.
+	other := newConnectorWrapper(t, ``, nil)
+	defer closeConnectorWrapper(t, other)
+	driverConn := openDriverConnWrapper(t, other)
+	defer closeDriverConnWrapper(t, &driverConn)
+	require.Equal(t, uint64(1), driverConn.(*Conn).ID())
+	require.Equal(t, "conn-1", driverConn.(*Conn).Label())
+}
+
 func TestInstanceCache(t *testing.T) {
 	// We loop a few times to try and trigger different concurrent behavior.
 	for i := 0; i < 20; i++ {
@@ -0,0 +1,112 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// enumTypes maps the Go types registered with RegisterEnum to their ENUM types.
+var enumTypes sync.Map
+
+type enumType struct {
+	name    string
+	members map[string]struct{}
+}
+
+// RegisterEnum maps the Go type T to the ENUM type name, whose members are the values.
+// It creates the ENUM type, if it does not exist. Otherwise, it validates that the existing type
+// is an ENUM type with exactly these members in this order.
+// After registering T, binding and appending T values fails for values that are not members,
+// and so does scanning values that are not members into T with Go 1.27 or later.
+// Registering T again replaces its ENUM type. It is safe for concurrent use.
+func RegisterEnum[T ~string](ctx context.Context, c *sql.Conn, name string, values ...T) error {
+	if name == "" {
+		return getError(errRegisterEnum, errEmptyName)
+	}
+	if len(values) == 0 {
+		return getError(errRegisterEnum, invalidInputError("no values", "at least one value"))
+	}
+
+	members := make([]string, len(values))
+	set := make(map[string]struct{}, len(values))
+	for i, v := range values {
+		if _, ok := set[string(v)]; ok {
+			return getError(errRegisterEnum, duplicateNameError(string(v)))
+		}
+		set[string(v)] = struct{}{}
+		members[i] = string(v)
+	}
+
+	var logicalType string
+	err := c.QueryRowContext(ctx, `SELECT logical_type FROM duckdb_types() WHERE type_name = ? AND NOT internal`, name).
+		Scan(&logicalType)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		err = createEnum(ctx, c, name, members)
+	case err == nil:
+		err = validateEnum(ctx, c, name, logicalType, members)
+	}
+	if err != nil {
+		return getError(errRegisterEnum, err)
+	}
+
+	enumTypes.Store(reflect.TypeFor[T](), enumType{name: name, members: set})
+	return nil
+}
+
+func createEnum(ctx context.Context, c *sql.Conn, name string, members []string) error {
+	literals := make([]string, len(members))
+	for i, member := range members {
+		literals[i] = QuoteLiteral(member)
+	}
+	query := fmt.Sprintf(`CREATE TYPE %s AS ENUM (%s)`, QuoteIdentifier(name), strings.Join(literals, ", "))
+	_, err := c.ExecContext(ctx, query)
+	return err
+}
+
+func validateEnum(ctx context.Context, c *sql.Conn, name string, logicalType string, members []string) error {
+	if logicalType != "ENUM" {
+		return invalidInputError(logicalType, "ENUM")
+	}
+
+	var existing Composite[[]string]
+	query := fmt.Sprintf(`SELECT enum_range(NULL::%s)::VARCHAR[]`, QuoteIdentifier(name))
+	if err := c.QueryRowContext(ctx, query).Scan(&existing); err != nil {
+		return err
+	}
+	if !slices.Equal(existing.Get(), members) {
+		return invalidInputError(fmt.Sprintf("%s with members %q", name, existing.Get()), fmt.Sprintf("members %q", members))
+	}
+	return nil
+}
+
+// enumValue returns the string of a value whose type is registered with RegisterEnum.
+// It returns false, if the type is not registered, and an error, if the value is not a member of the ENUM type.
+func enumValue(val any) (string, bool, error) {
+	if val == nil {
+		return "", false, nil
+	}
+	e, ok := enumTypes.Load(reflect.TypeOf(val))
+	if !ok {
+		return "", false, nil
+	}
+	str := reflect.ValueOf(val).String()
+	if err := e.(enumType).validate(str); err != nil {
+		return "", true, err
+	}
+	return str, true, nil
+}
+
+func (e enumType) validate(member string) error {
+	if _, ok := e.members[member]; !ok {
+		return invalidInputError(strconv.Quote(member), "a member of ENUM "+e.name)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package duckdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testMood string
+
+const (
+	testMoodSad   testMood = "sad"
+	testMoodOk    testMood = "ok"
+	testMoodHappy testMood = "happy"
+)
+
+func TestRegisterEnum(t *testing.T) {
+	db := openDbWrapper(t, ``)
+	defer closeDbWrapper(t, db)
+
+	ctx := context.Background()
+	conn := openConnWrapper(t, db, ctx)
+	defer closeConnWrapper(t, conn)
+
+	require.NoError(t, RegisterEnum(ctx, conn, "mood", testMoodSad, testMoodOk, testMoodHappy))
+
+	var members Composite[[]string]
+	require.NoError(t, conn.QueryRowContext(ctx, `SELECT enum_range(NULL::mood)::VARCHAR[]`).Scan(&members))
+	require.Equal(t, []string{"sad", "ok", "happy"}, members.Get())
+
+	// Registering the same members again validates the existing type.
+	require.NoError(t, RegisterEnum(ctx, conn, "mood", testMoodSad, testMoodOk, testMoodHappy))
+
+	_, err := conn.ExecContext(ctx, `CREATE TABLE person (name VARCHAR, current_mood mood)`)
+	require.NoError(t, err)
+
+	t.Run("bind and scan", func(t *testing.T) {
+		_, err = conn.ExecContext(ctx, `INSERT INTO person VALUES ('ann', ?)`, testMoodHappy)
+		require.NoError(t, err)
+
+		var mood testMood
+		require.NoError(t, conn.QueryRowContext(ctx, `SELECT current_mood FROM person WHERE name = 'ann'`).Scan(&mood))
+		require.Equal(t, testMoodHappy, mood)
+
+		_, err = conn.ExecContext(ctx, `INSERT INTO person VALUES ('bob', ?)`, testMood("angry"))
+		require.ErrorContains(t, err, `expected a member of ENUM mood, got "angry"`)
+	})
+
+	t.Run("append", func(t *testing.T) {
+		err = conn.Raw(func(driverConn any) error {
+			a, err := NewAppenderFromConn(driverConn.(*Conn), "", "person")
+			require.NoError(t, err)
+			require.NoError(t, a.AppendRow("carl", testMoodSad))
+			require.ErrorContains(t, a.AppendRow("dora", testMood("angry")), "a member of ENUM mood")
+			return a.Close()
+		})
+		require.NoError(t, err)
+
+		var mood testMood
+		require.NoError(t, conn.QueryRowContext(ctx, `SELECT current_mood FROM person WHERE name = 'carl'`).Scan(&mood))
+		require.Equal(t, testMoodSad, mood)
+	})
+
+	t.Run("invalid registrations", func(t *testing.T) {
+		err = RegisterEnum(ctx, conn, "mood", testMoodSad, testMoodHappy)
+		testError(t, err, errRegisterEnum.Error(), invalidInputErrMsg)
+
+		err = RegisterEnum(ctx, conn, "other", testMoodSad, testMoodSad)
+		testError(t, err, errRegisterEnum.Error(), duplicateNameErrMsg)
+
+		err = RegisterEnum[testMood](ctx, conn, "other")
+		testError(t, err, errRegisterEnum.Error(), invalidInputErrMsg)
+
+		err = RegisterEnum(ctx, conn, "", testMoodSad)
+		testError(t, err, errRegisterEnum.Error(), errEmptyName.Error())
+
+		_, err = conn.ExecContext(ctx, `CREATE TYPE not_enum AS INTEGER`)
+		require.NoError(t, err)
+		err = RegisterEnum(ctx, conn, "not_enum", testMoodSad)
+		testError(t, err, errRegisterEnum.Error(), invalidInputErrMsg)
+	})
+}
@@ -53,6 +53,10 @@ func invalidatedAppenderError(err error) error {
 	return fmt.Errorf("%w: %s", err, invalidatedAppenderMsg)
 }
 
+func addRowIndexToError(err error, idx int) error {
+	return fmt.Errorf("%w: %s: %d", err, rowIndexErrMsg, idx)
+}
+
 func tryOtherFuncError(hint string) error {
 	return fmt.Errorf("%s: %s", tryOtherFuncErrMsg, hint)
 }
@@ -81,6 +85,7 @@ const (
 	invalidatedAppenderMsg = "appended data has been invalidated due to corrupt row"
 	tryOtherFuncErrMsg     = "please try this function instead"
 	indexErrMsg            = "index"
+	rowIndexErrMsg         = "row index"
 	unknownTypeErrMsg      = "unknown type"
 	interfaceIsNilErrMsg   = "interface is nil"
 	duplicateNameErrMsg    = "duplicate name"
@@ -88,20 +93,27 @@ const (
 )
 
 var (
-	errInternal   = errors.New("internal error: please file a bug report at go-duckdb")
-	errAPI        = errors.New("API error")
-	errVectorSize = errors.New("data chunks cannot exceed duckdb's internal vector size")
+	errInternal      = errors.New("internal error: please file a bug report at go-duckdb")
+	errAPI           = errors.New("API error")
+	errVectorSize    = errors.New("data chunks cannot exceed duckdb's internal vector size")
+	errReadOnlyChunk = errors.New("cannot write to a read-only data chunk")
+	errChunkSize     = errors.New("row does not exist in the data chunk")
 
 	errConnect      = errors.New("could not connect to database")
 	errParseDSN     = errors.New("could not parse DSN for database")
 	errSetConfig    = errors.New("could not set invalid or local option for global database config")
 	errCreateConfig = errors.New("could not create config for database")
 
-	errInvalidCon = errors.New("not a DuckDB driver connection")
-	errClosedCon  = errors.New("closed connection")
+	errInvalidCon   = errors.New("not a DuckDB driver connection")
+	errClosedCon    = errors.New("closed connection")
+	errReadOnlyConn = errors.New("cannot modify the database with a read-only connection")
+
+	errClosedConnector = errors.New("closed connector")
+	errClosedTaskState = errors.New("closed task state")
 
-	errClosedStmt        = errors.New("closed statement")
-	errUninitializedStmt = errors.New("uninitialized statement")
+	errClosedStmt         = errors.New("closed statement")
+	errClosedPendingQuery = errors.New("closed pending query")
+	errUninitializedStmt  = errors.New("uninitialized statement")
 
 	errPrepare                    = errors.New("could not prepare query")
 	errMissingPrepareContext      = errors.New("missing context for multi-statement query: try using PrepareContext")
@@ -120,6 +132,7 @@ var (
 	errAppenderAppendRow        = errors.New("could not append row")
 	errAppenderAppendAfterClose = fmt.Errorf("%w: appender already closed", errAppenderAppendRow)
 	errAppenderFlush            = errors.New("could not flush appender")
+	errAppenderSetDefault       = errors.New("could not set default value")
 
 	errUnsupportedMapKeyType = errors.New("MAP key type not supported")
 	errEmptyName             = errors.New("empty name")
@@ -127,6 +140,7 @@ var (
 	errInvalidDecimalScale   = errors.New("the DECIMAL scale must be less than or equal to the width")
 	errInvalidArraySize      = errors.New("invalid ARRAY size")
 	errSetSQLNULLValue       = errors.New("cannot write to a NULL column")
+	errNonFiniteFloat        = errors.New("non-finite FLOAT or DOUBLE value")
 
 	errScalarUDFCreate          = errors.New("could not create scalar UDF")
 	errScalarUDFNoName          = fmt.Errorf("%w: missing name", errScalarUDFCreate)
@@ -145,6 +159,19 @@ var (
 	errTableUDFColumnTypeIsNil = fmt.Errorf("%w: column type is nil", errTableUDFCreate)
 
 	errProfilingInfoEmpty = errors.New("no profiling information available for this connection")
+
+	errBulkLoad        = errors.New("could not bulk load rows")
+	errNoStructColumns = errors.New("struct has no exported fields")
+
+	errCopyTable  = errors.New("could not copy table")
+	errExport     = errors.New("could not export query result")
+	errScanStruct = errors.New("could not scan struct")
+	errSniffCSV   = errors.New("could not sniff CSV file")
+	errMigrate    = errors.New("could not migrate database")
+	errUpsert     = errors.New("could not upsert rows")
+
+	errRegisterEnum        = errors.New("could not register ENUM")
+	errRegisterTypeHandler = errors.New("could not register type handler")
 )
 
 type ErrorType int
@@ -244,6 +271,8 @@ var errorPrefixMap = map[string]ErrorType{
 type Error struct {
 	Type ErrorType
 	Msg  string
+	// ConnLabel is the label of the connection causing the error, if any.
+	ConnLabel string
 }
 
 func (e *Error) Error() string {
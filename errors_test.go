@@ -1,11 +1,15 @@
 package duckdb
 
 import (
+	"context"
 	"database/sql"
 	"database/sql/driver"
 	"errors"
+	"io"
+	"math"
 	"strings"
 	"testing"
+	"testing/fstest"
 	"time"
 
 	"github.com/stretchr/testify/require"
@@ -59,6 +63,38 @@ func TestErrNestedMap(t *testing.T) {
 	testError(t, err, errUnsupportedMapKeyType.Error())
 }
 
+// foreignConnector opens connections that are not *Conn, e.g., like instrumentation wrapping the driver.
+type foreignConnector struct {
+	*Connector
+}
+
+type foreignConn struct {
+	driver.Conn
+}
+
+func (c foreignConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	return foreignConn{conn}, err
+}
+
+func TestErrForeignConn(t *testing.T) {
+	c := newConnectorWrapper(t, ``, nil)
+	db := sql.OpenDB(foreignConnector{c})
+	defer closeDbWrapper(t, db)
+	conn := openConnWrapper(t, db, context.Background())
+	defer closeConnWrapper(t, conn)
+
+	_, err := CopyTable(context.Background(), conn, "src", "dst", CopyOptions{})
+	testError(t, err, errInvalidCon.Error())
+	_, err = ExportParquet(context.Background(), conn, `SELECT 42`, io.Discard, ParquetOptions{})
+	testError(t, err, errExport.Error(), errInvalidCon.Error())
+	_, err = ExportCSV(context.Background(), conn, `SELECT 42`, io.Discard, ExportCSVOptions{})
+	testError(t, err, errExport.Error(), errInvalidCon.Error())
+
+	err = Migrate(context.Background(), db, fstest.MapFS{})
+	testError(t, err, errMigrate.Error(), errInvalidCon.Error())
+}
+
 func TestErrAppender(t *testing.T) {
 	t.Run(errInvalidCon.Error(), func(t *testing.T) {
 		var conn driver.Conn
@@ -207,6 +243,14 @@ func TestErrAppendDecimal(t *testing.T) {
 	testError(t, err, errAppenderAppendRow.Error(), castErrMsg)
 	err = a.AppendRow(Decimal{Width: 8, Scale: 3})
 	testError(t, err, errAppenderAppendRow.Error(), castErrMsg)
+	err = a.AppendRow("1234567.89")
+	testError(t, err, errAppenderAppendRow.Error(), castErrMsg)
+	err = a.AppendRow("not a number")
+	testError(t, err, errAppenderAppendRow.Error(), castErrMsg)
+	err = a.AppendRow(math.Inf(1))
+	testError(t, err, errAppenderAppendRow.Error(), castErrMsg)
+	err = a.AppendRow(true)
+	testError(t, err, errAppenderAppendRow.Error(), castErrMsg)
 }
 
 func TestErrAppendEnum(t *testing.T) {
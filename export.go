@@ -0,0 +1,181 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/marcboeker/go-duckdb/mapping"
+)
+
+// ParquetOptions configures ExportParquet.
+type ParquetOptions struct {
+	// Compression is the compression codec of the file, e.g., "zstd" or "uncompressed".
+	// DuckDB's default is "snappy".
+	Compression string
+	// RowGroupSize is the number of rows per row group, if positive.
+	RowGroupSize int
+	// Progress is called with the query's progress while exporting the rows, if not nil.
+	// DuckDB only tracks the progress if the connection enables it, e.g., with SET enable_progress_bar = true.
+	Progress func(QueryProgress)
+}
+
+// ExportParquet writes the result of a query as a Parquet file to w, e.g., to upload it to object storage.
+// It executes COPY ... TO with DuckDB's Parquet writer, and returns the number of exported rows.
+// The query is inserted into the COPY statement as-is.
+// On Unix, DuckDB writes the file into a named pipe, from which ExportParquet copies it to w,
+// so that the file never touches the disk. On other platforms, DuckDB writes a temporary file.
+func ExportParquet(ctx context.Context, c *sql.Conn, query string, w io.Writer, opts ParquetOptions) (int64, error) {
+	options := []string{`FORMAT parquet`}
+	if opts.Compression != "" {
+		options = append(options, `COMPRESSION `+QuoteLiteral(opts.Compression))
+	}
+	if opts.RowGroupSize > 0 {
+		options = append(options, fmt.Sprintf(`ROW_GROUP_SIZE %d`, opts.RowGroupSize))
+	}
+	return exportQuery(ctx, c, query, options, w, opts.Progress)
+}
+
+// ExportCSVOptions configures ExportCSV.
+type ExportCSVOptions struct {
+	// Delimiter is the field delimiter. Empty means ','.
+	Delimiter string
+	// Quote is the quote character. Empty means '"'.
+	Quote string
+	// Escape is the character escaping quote characters in quoted fields. Empty means the quote character.
+	Escape string
+	// Header writes a first record with the column names.
+	Header bool
+	// ForceQuote quotes all non-NULL fields. Otherwise, DuckDB only quotes fields that require it.
+	ForceQuote bool
+	// NullString is the text of NULL values. Empty means empty fields.
+	NullString string
+	// Progress is called with the query's progress while exporting the rows, if not nil.
+	// DuckDB only tracks the progress if the connection enables it, e.g., with SET enable_progress_bar = true.
+	Progress func(QueryProgress)
+}
+
+// ExportCSV writes the result of a query as CSV records to w.
+// It executes COPY ... TO with DuckDB's CSV writer, and returns the number of exported rows.
+// Like ExportParquet, it inserts the query into the COPY statement as-is, and does not write the file to disk on Unix.
+func ExportCSV(ctx context.Context, c *sql.Conn, query string, w io.Writer, opts ExportCSVOptions) (int64, error) {
+	options := []string{`FORMAT csv`, fmt.Sprintf(`HEADER %t`, opts.Header)}
+	if opts.Delimiter != "" {
+		options = append(options, `DELIMITER `+QuoteLiteral(opts.Delimiter))
+	}
+	if opts.Quote != "" {
+		options = append(options, `QUOTE `+QuoteLiteral(opts.Quote))
+	}
+	if opts.Escape != "" {
+		options = append(options, `ESCAPE `+QuoteLiteral(opts.Escape))
+	}
+	if opts.ForceQuote {
+		options = append(options, `FORCE_QUOTE *`)
+	}
+	if opts.NullString != "" {
+		options = append(options, `NULLSTR `+QuoteLiteral(opts.NullString))
+	}
+	return exportQuery(ctx, c, query, options, w, opts.Progress)
+}
+
+// exportQuery copies the result of a query to w with COPY ... TO and the options.
+// It returns the number of exported rows.
+func exportQuery(ctx context.Context, c *sql.Conn, query string, options []string, w io.Writer, progress func(QueryProgress)) (int64, error) {
+	dir, err := os.MkdirTemp("", "go-duckdb-export-")
+	if err != nil {
+		return 0, getError(errExport, err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "export")
+	// DuckDB must write to the path itself, and not to a temporary file next to it.
+	options = append(options, `USE_TMP_FILE false`)
+	copyQuery := fmt.Sprintf(`COPY (%s) TO %s (%s)`, query, QuoteLiteral(path), strings.Join(options, ", "))
+
+	var count int64
+	var errWrite error
+	err = c.Raw(func(driverConn any) error {
+		conn, ok := driverConn.(*Conn)
+		if !ok {
+			return errInvalidCon
+		}
+		if !exportPipes {
+			var err error
+			if count, err = executeExport(ctx, conn, copyQuery, progress); err != nil {
+				return err
+			}
+			errWrite = copyExportFile(path, w)
+			return nil
+		}
+
+		r, guard, err := openExportPipe(path)
+		if err != nil {
+			errWrite = err
+			return nil
+		}
+		writeDoneCh := make(chan error, 1)
+		go func() {
+			writeDoneCh <- copyExportPipe(conn, r, w)
+		}()
+
+		count, err = executeExport(ctx, conn, copyQuery, progress)
+		// The reader reaches the end of the pipe once DuckDB and the guard closed it.
+		_ = guard.Close()
+		errWrite = <-writeDoneCh
+		return err
+	})
+	if errWrite != nil {
+		return 0, getError(errExport, errWrite)
+	}
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return 0, ctxErr
+		}
+		return 0, getError(errExport, err)
+	}
+	return count, nil
+}
+
+func executeExport(ctx context.Context, conn *Conn, query string, progress func(QueryProgress)) (int64, error) {
+	p, err := conn.PendingQueryContext(ctx, query, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer p.Close()
+
+	if err = p.wait(ctx, progress); err != nil {
+		return 0, err
+	}
+	res, err := p.Result()
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// copyExportPipe copies the file that DuckDB writes into a named pipe from its read end r to w.
+// If copying fails, it interrupts the query, and drains the pipe, so that DuckDB does not block on writing.
+func copyExportPipe(conn *Conn, r *os.File, w io.Writer) error {
+	defer r.Close()
+	_, err := io.Copy(w, r)
+	if err != nil {
+		mapping.Interrupt(conn.conn)
+		_, _ = io.Copy(io.Discard, r)
+	}
+	return err
+}
+
+func copyExportFile(path string, w io.Writer) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(w, f)
+	return err
+}
@@ -0,0 +1,15 @@
+//go:build !unix
+
+package duckdb
+
+import (
+	"errors"
+	"os"
+)
+
+// exportPipes is true, if DuckDB exports files into named pipes.
+const exportPipes = false
+
+func openExportPipe(string) (r, guard *os.File, err error) {
+	return nil, nil, errors.ErrUnsupported
+}
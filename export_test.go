@@ -0,0 +1,106 @@
+package duckdb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("failing writer")
+}
+
+func TestExportParquet(t *testing.T) {
+	db := openDbWrapper(t, ``)
+	defer closeDbWrapper(t, db)
+	conn := openConnWrapper(t, db, context.Background())
+	defer closeConnWrapper(t, conn)
+
+	var buf bytes.Buffer
+	count, err := ExportParquet(context.Background(), conn, `SELECT i, i::VARCHAR AS s FROM range(100000) t(i)`, &buf, ParquetOptions{
+		Compression:  "zstd",
+		RowGroupSize: 10000,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(100000), count)
+	require.Equal(t, []byte("PAR1"), buf.Bytes()[:4])
+
+	path := filepath.Join(t.TempDir(), "export.parquet")
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0o600))
+
+	var rows, sum int64
+	require.NoError(t, conn.QueryRowContext(context.Background(), `SELECT count(*), sum(i) FROM read_parquet(?)`, path).Scan(&rows, &sum))
+	require.Equal(t, int64(100000), rows)
+	require.Equal(t, int64(4999950000), sum)
+
+	var rowGroups int64
+	var compression string
+	require.NoError(t, conn.QueryRowContext(context.Background(),
+		`SELECT count(DISTINCT row_group_id), any_value(compression) FROM parquet_metadata(?)`, path).Scan(&rowGroups, &compression))
+	require.Equal(t, int64(10), rowGroups)
+	require.Equal(t, "ZSTD", compression)
+
+	// The query fails before DuckDB writes the file.
+	buf.Reset()
+	_, err = ExportParquet(context.Background(), conn, `SELECT * FROM does_not_exist`, &buf, ParquetOptions{})
+	testError(t, err, errExport.Error(), "does_not_exist")
+	require.Zero(t, buf.Len())
+
+	// Writing the file fails.
+	_, err = ExportParquet(context.Background(), conn, `SELECT i FROM range(10000000) t(i)`, failingWriter{}, ParquetOptions{})
+	testError(t, err, errExport.Error(), "failing writer")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = ExportParquet(ctx, conn, `SELECT 42`, &buf, ParquetOptions{})
+	require.ErrorIs(t, err, context.Canceled)
+
+	// The connection is still usable.
+	var v int
+	require.NoError(t, conn.QueryRowContext(context.Background(), `SELECT 42`).Scan(&v))
+	require.Equal(t, 42, v)
+}
+
+func TestExportCSV(t *testing.T) {
+	db := openDbWrapper(t, ``)
+	defer closeDbWrapper(t, db)
+	conn := openConnWrapper(t, db, context.Background())
+	defer closeConnWrapper(t, conn)
+
+	query := `SELECT * FROM (VALUES (1, 'duck', 1.5), (2, 'say "quack"', NULL), (3, 'a,b', 2.0), (4, '', 0.0)) t(id, name, v) ORDER BY id`
+
+	tests := []struct {
+		opts     ExportCSVOptions
+		expected string
+	}{
+		{
+			opts:     ExportCSVOptions{},
+			expected: "1,duck,1.5\n2,\"say \"\"quack\"\"\",\n3,\"a,b\",2.0\n4,\"\",0.0\n",
+		},
+		{
+			opts:     ExportCSVOptions{Header: true, Delimiter: ";", NullString: "NULL"},
+			expected: "id;name;v\n1;duck;1.5\n2;\"say \"\"quack\"\"\";NULL\n3;a,b;2.0\n4;;0.0\n",
+		},
+		{
+			opts:     ExportCSVOptions{Quote: "'", Escape: "\\", ForceQuote: true},
+			expected: "'1','duck','1.5'\n'2','say \"quack\"',\n'3','a,b','2.0'\n'4','','0.0'\n",
+		},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		count, err := ExportCSV(context.Background(), conn, query, &buf, test.opts)
+		require.NoError(t, err)
+		require.Equal(t, int64(4), count)
+		require.Equal(t, test.expected, buf.String())
+	}
+
+	_, err := ExportCSV(context.Background(), conn, `SELECT * FROM does_not_exist`, &bytes.Buffer{}, ExportCSVOptions{})
+	testError(t, err, errExport.Error(), "does_not_exist")
+}
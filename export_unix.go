@@ -0,0 +1,36 @@
+//go:build unix
+
+package duckdb
+
+import (
+	"os"
+	"syscall"
+)
+
+// exportPipes is true, if DuckDB exports files into named pipes.
+const exportPipes = true
+
+// openExportPipe creates a named pipe at path, and opens its read end r and a write end guard.
+// Neither open blocks, and r does not reach the end of the pipe before the guard is closed,
+// even if DuckDB fails before opening the pipe.
+func openExportPipe(path string) (r, guard *os.File, err error) {
+	if err = syscall.Mkfifo(path, 0o600); err != nil {
+		return nil, nil, err
+	}
+	fd, err := syscall.Open(path, syscall.O_RDONLY|syscall.O_NONBLOCK|syscall.O_CLOEXEC, 0)
+	if err != nil {
+		return nil, nil, &os.PathError{Op: "open", Path: path, Err: err}
+	}
+	// Read in blocking mode, as not all platforms can poll named pipes.
+	if err = syscall.SetNonblock(fd, false); err != nil {
+		_ = syscall.Close(fd)
+		return nil, nil, err
+	}
+	r = os.NewFile(uintptr(fd), path)
+
+	if guard, err = os.OpenFile(path, os.O_WRONLY, 0); err != nil {
+		_ = r.Close()
+		return nil, nil, err
+	}
+	return r, guard, nil
+}
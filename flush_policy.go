@@ -0,0 +1,59 @@
+package duckdb
+
+import "time"
+
+// FlushState describes the rows an Appender buffered since its last flush.
+type FlushState struct {
+	// Rows is the number of buffered rows.
+	Rows int
+	// Chunks is the number of buffered data chunks.
+	Chunks int
+	// Bytes is the estimated size of the buffered rows.
+	// The estimate includes the data chunks and the length of VARCHAR and BLOB values.
+	Bytes int64
+	// LastFlush is the time of the last flush, or of creating the Appender.
+	LastFlush time.Time
+}
+
+// FlushPolicy decides when an Appender flushes automatically, see WithFlushPolicy.
+type FlushPolicy interface {
+	// ShouldFlush returns true, if the Appender should flush its buffered rows.
+	ShouldFlush(state FlushState) bool
+}
+
+// FlushPolicyFunc is a function implementing the FlushPolicy interface.
+type FlushPolicyFunc func(state FlushState) bool
+
+// ShouldFlush implements the FlushPolicy interface.
+func (f FlushPolicyFunc) ShouldFlush(state FlushState) bool {
+	return f(state)
+}
+
+// FlushAfterRows returns a FlushPolicy that flushes after buffering n rows.
+func FlushAfterRows(n int) FlushPolicy {
+	return FlushPolicyFunc(func(state FlushState) bool {
+		return n > 0 && state.Rows >= n
+	})
+}
+
+// FlushAfterChunks returns a FlushPolicy that flushes after buffering n full data chunks.
+func FlushAfterChunks(n int) FlushPolicy {
+	return FlushPolicyFunc(func(state FlushState) bool {
+		return n > 0 && state.Rows >= n*GetDataChunkCapacity()
+	})
+}
+
+// FlushAfterBytes returns a FlushPolicy that flushes after the estimated size of the buffered rows reaches n bytes.
+func FlushAfterBytes(n int64) FlushPolicy {
+	return FlushPolicyFunc(func(state FlushState) bool {
+		return n > 0 && state.Bytes >= n
+	})
+}
+
+// FlushAfterDuration returns a FlushPolicy that flushes, if the last flush is at least d ago.
+// The Appender only consults its policies when appending rows, i.e., it does not flush while idle.
+func FlushAfterDuration(d time.Duration) FlushPolicy {
+	return FlushPolicyFunc(func(state FlushState) bool {
+		return d > 0 && time.Since(state.LastFlush) >= d
+	})
+}
@@ -0,0 +1,83 @@
+package duckdb
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlushPolicy(t *testing.T) {
+	c := newConnectorWrapper(t, ``, nil)
+	defer closeConnectorWrapper(t, c)
+	db := sql.OpenDB(c)
+	defer closeDbWrapper(t, db)
+	conn := openDriverConnWrapper(t, c)
+	defer closeDriverConnWrapper(t, &conn)
+
+	createTable(t, db, `CREATE TABLE test (id INTEGER)`)
+	count := func() int {
+		var n int
+		require.NoError(t, db.QueryRow(`SELECT count(*) FROM test`).Scan(&n))
+		return n
+	}
+	appendRows := func(a *Appender, n int) {
+		for i := 0; i < n; i++ {
+			require.NoError(t, a.AppendRow(int32(i)))
+		}
+	}
+	newAppender := func(opts ...AppenderOption) *Appender {
+		_, err := db.Exec(`DELETE FROM test`)
+		require.NoError(t, err)
+		a, err := NewAppenderWithOptions(conn, "", "", "test", opts...)
+		require.NoError(t, err)
+		return a
+	}
+
+	t.Run("rows", func(t *testing.T) {
+		a := newAppender(WithFlushPolicy(FlushAfterRows(10)))
+		appendRows(a, 25)
+		require.Equal(t, 20, count())
+		closeAppenderWrapper(t, a)
+		require.Equal(t, 25, count())
+	})
+
+	t.Run("chunks", func(t *testing.T) {
+		a := newAppender(WithFlushPolicy(FlushAfterChunks(2)))
+		appendRows(a, 2*GetDataChunkCapacity()-1)
+		require.Equal(t, 0, count())
+		appendRows(a, 1)
+		require.Equal(t, 2*GetDataChunkCapacity(), count())
+		closeAppenderWrapper(t, a)
+	})
+
+	t.Run("duration", func(t *testing.T) {
+		a := newAppender(WithFlushPolicy(FlushAfterDuration(50 * time.Millisecond)))
+		appendRows(a, 5)
+		require.Equal(t, 0, count())
+		time.Sleep(60 * time.Millisecond)
+		appendRows(a, 1)
+		require.Equal(t, 6, count())
+		closeAppenderWrapper(t, a)
+	})
+
+	t.Run("custom", func(t *testing.T) {
+		var states []FlushState
+		policy := FlushPolicyFunc(func(state FlushState) bool {
+			states = append(states, state)
+			return state.Rows == 3
+		})
+		a := newAppender(WithFlushPolicy(policy), WithFlushPolicy(FlushAfterRows(100)))
+		require.NoError(t, a.AppendRowMap(map[string]driver.Value{"id": int32(1)}))
+		appendRows(a, 2)
+		require.Equal(t, 3, count())
+		require.Len(t, states, 3)
+		require.Equal(t, 1, states[0].Rows)
+		require.Equal(t, 1, states[0].Chunks)
+		require.Greater(t, states[0].Bytes, int64(0))
+		require.False(t, states[0].LastFlush.IsZero())
+		closeAppenderWrapper(t, a)
+	})
+}
@@ -0,0 +1,434 @@
+package duckdb
+
+import (
+	"database/sql/driver"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Geometry is a GEOMETRY value of the spatial extension, which it holds as WKB (well-known binary).
+// Rows return GEOMETRY values as Geometry, converting them from the internal format of the spatial extension.
+// Binding or appending a Geometry passes its WKB, see Appender for appending to GEOMETRY columns.
+// Build with the duckdb_geom tag to convert Geometry values to and from github.com/twpayne/go-geom geometries.
+type Geometry struct {
+	wkb []byte
+}
+
+// NewGeometry returns the Geometry of a WKB value, e.g., the result of ST_AsWKB. It fails for invalid WKB values.
+func NewGeometry(wkb []byte) (Geometry, error) {
+	r := wkbReader{b: wkb}
+	if err := r.readGeometry(nil); err != nil {
+		return Geometry{}, err
+	}
+	if len(r.b) != 0 {
+		return Geometry{}, invalidInputError(fmt.Sprintf("%d trailing bytes", len(r.b)), "a WKB value")
+	}
+	return Geometry{wkb: wkb}, nil
+}
+
+// WKB returns the geometry as WKB.
+func (g Geometry) WKB() []byte {
+	return g.wkb
+}
+
+// WKT returns the geometry as WKT (well-known text), e.g., POINT (1 2). It returns an empty string for a zero Geometry.
+func (g Geometry) WKT() string {
+	if len(g.wkb) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	r := wkbReader{b: g.wkb}
+	// NewGeometry and the rows validate the WKB value.
+	_ = r.readGeometry(&b)
+	return b.String()
+}
+
+// String returns the geometry as WKT.
+func (g Geometry) String() string {
+	return g.WKT()
+}
+
+// Scan implements the sql.Scanner interface. It accepts GEOMETRY values and WKB values.
+func (g *Geometry) Scan(v any) error {
+	switch data := v.(type) {
+	case Geometry:
+		*g = data
+		return nil
+	case []byte:
+		geometry, err := NewGeometry(append([]byte(nil), data...))
+		if err != nil {
+			return err
+		}
+		*g = geometry
+		return nil
+	}
+	return fmt.Errorf("invalid type `%T` for scanning `Geometry`, expected `Geometry` or WKB `[]byte`", v)
+}
+
+// Value implements the driver.Valuer interface. It returns the WKB value.
+func (g Geometry) Value() (driver.Value, error) {
+	if g.wkb == nil {
+		return nil, nil
+	}
+	return g.wkb, nil
+}
+
+var reflectTypeGeometry = reflect.TypeFor[Geometry]()
+
+// The geometry types of WKB. ISO WKB adds 1000 for Z, 2000 for M, and 3000 for ZM coordinates.
+const (
+	wkbPoint              = 1
+	wkbLineString         = 2
+	wkbPolygon            = 3
+	wkbMultiPoint         = 4
+	wkbMultiLineString    = 5
+	wkbMultiPolygon       = 6
+	wkbGeometryCollection = 7
+)
+
+var wkbTypeNames = map[uint32]string{
+	wkbPoint:              "POINT",
+	wkbLineString:         "LINESTRING",
+	wkbPolygon:            "POLYGON",
+	wkbMultiPoint:         "MULTIPOINT",
+	wkbMultiLineString:    "MULTILINESTRING",
+	wkbMultiPolygon:       "MULTIPOLYGON",
+	wkbGeometryCollection: "GEOMETRYCOLLECTION",
+}
+
+var errInvalidWKB = errors.New("invalid WKB value")
+
+// wkbReader validates a WKB value, and optionally writes it as WKT.
+type wkbReader struct {
+	b     []byte
+	order binary.ByteOrder
+}
+
+func (r *wkbReader) uint32() (uint32, error) {
+	if len(r.b) < 4 {
+		return 0, errInvalidWKB
+	}
+	v := r.order.Uint32(r.b)
+	r.b = r.b[4:]
+	return v, nil
+}
+
+func (r *wkbReader) float64() (float64, error) {
+	if len(r.b) < 8 {
+		return 0, errInvalidWKB
+	}
+	v := math.Float64frombits(r.order.Uint64(r.b))
+	r.b = r.b[8:]
+	return v, nil
+}
+
+// readGeometry reads a geometry with its byte order and type. If w is not nil, it writes the geometry as WKT.
+func (r *wkbReader) readGeometry(w *strings.Builder) error {
+	t, dims, err := r.readHeader(w)
+	if err != nil {
+		return err
+	}
+	if t == wkbPoint {
+		return r.readPoint(w, dims, true)
+	}
+	return r.readCollection(w, t, dims, true)
+}
+
+// readHeader reads the byte order and the type of a geometry, and returns the type and the number of coordinates
+// of its vertices. It supports ISO WKB and EWKB types. If w is not nil, it writes the WKT tag of the type.
+func (r *wkbReader) readHeader(w *strings.Builder) (uint32, int, error) {
+	if len(r.b) == 0 {
+		return 0, 0, errInvalidWKB
+	}
+	switch r.b[0] {
+	case 0:
+		r.order = binary.BigEndian
+	case 1:
+		r.order = binary.LittleEndian
+	default:
+		return 0, 0, errInvalidWKB
+	}
+	r.b = r.b[1:]
+
+	t, err := r.uint32()
+	if err != nil {
+		return 0, 0, err
+	}
+	iso := (t & 0x0fffffff) / 1000
+	hasZ := t&0x80000000 != 0 || iso == 1 || iso == 3
+	hasM := t&0x40000000 != 0 || iso == 2 || iso == 3
+	if t&0x20000000 != 0 {
+		// Skip the SRID of EWKB values.
+		if _, err = r.uint32(); err != nil {
+			return 0, 0, err
+		}
+	}
+	t = (t & 0x0fffffff) % 1000
+	name, ok := wkbTypeNames[t]
+	if !ok {
+		return 0, 0, errInvalidWKB
+	}
+
+	dims := 2
+	if w != nil {
+		w.WriteString(name)
+	}
+	if hasZ || hasM {
+		if w != nil {
+			w.WriteByte(' ')
+		}
+		if hasZ {
+			dims++
+			if w != nil {
+				w.WriteByte('Z')
+			}
+		}
+		if hasM {
+			dims++
+			if w != nil {
+				w.WriteByte('M')
+			}
+		}
+	}
+	return t, dims, nil
+}
+
+// readCollection reads the elements of a geometry that is not a POINT.
+// If w is not nil, it writes them as WKT, starting with a space for a geometry that is not nested.
+func (r *wkbReader) readCollection(w *strings.Builder, t uint32, dims int, top bool) error {
+	count, err := r.uint32()
+	if err != nil {
+		return err
+	}
+	if w != nil {
+		if top {
+			w.WriteByte(' ')
+		}
+		if count == 0 {
+			w.WriteString("EMPTY")
+			return nil
+		}
+		w.WriteByte('(')
+	}
+	for i := uint32(0); i < count; i++ {
+		if w != nil && i > 0 {
+			w.WriteString(", ")
+		}
+		switch t {
+		case wkbLineString:
+			err = r.readVertex(w, dims)
+		case wkbPolygon:
+			err = r.readCollection(w, wkbLineString, dims, false)
+		case wkbMultiPoint, wkbMultiLineString, wkbMultiPolygon:
+			err = r.readElement(w, t-3)
+		case wkbGeometryCollection:
+			err = r.readGeometry(w)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if w != nil {
+		w.WriteByte(')')
+	}
+	return nil
+}
+
+// readElement reads an element of a MULTI* geometry, which must have the type of the elements.
+func (r *wkbReader) readElement(w *strings.Builder, expected uint32) error {
+	t, dims, err := r.readHeader(nil)
+	if err != nil {
+		return err
+	}
+	if t != expected {
+		return errInvalidWKB
+	}
+	if t == wkbPoint {
+		return r.readPoint(w, dims, false)
+	}
+	return r.readCollection(w, t, dims, false)
+}
+
+// readPoint reads a POINT, whose coordinates are NaN, if it is empty.
+// If w is not nil, it writes the point, with parentheses, if it is not nested in a MULTIPOINT.
+func (r *wkbReader) readPoint(w *strings.Builder, dims int, top bool) error {
+	var vertex strings.Builder
+	if err := r.readVertex(&vertex, dims); err != nil {
+		return err
+	}
+	if w == nil {
+		return nil
+	}
+	if top {
+		w.WriteByte(' ')
+	}
+	switch {
+	case strings.HasPrefix(vertex.String(), "NaN NaN"):
+		w.WriteString("EMPTY")
+	case top:
+		w.WriteByte('(')
+		w.WriteString(vertex.String())
+		w.WriteByte(')')
+	default:
+		w.WriteString(vertex.String())
+	}
+	return nil
+}
+
+// readVertex reads the coordinates of a vertex. If w is not nil, it writes them separated by spaces.
+func (r *wkbReader) readVertex(w *strings.Builder, dims int) error {
+	for i := 0; i < dims; i++ {
+		v, err := r.float64()
+		if err != nil {
+			return err
+		}
+		if w == nil {
+			continue
+		}
+		if i > 0 {
+			w.WriteByte(' ')
+		}
+		w.WriteString(strconv.FormatFloat(v, 'f', -1, 64))
+	}
+	return nil
+}
+
+var errInvalidGeometry = errors.New("invalid GEOMETRY value")
+
+// geometryFromSpatial converts a GEOMETRY value from the internal format of the spatial extension to WKB.
+// The format starts with the type, the flags, and four bytes of padding, followed by an optional bounding box.
+// The elements of the geometry start with their type and their count, followed by their vertices or elements.
+func geometryFromSpatial(b []byte) (Geometry, error) {
+	if len(b) < 8 {
+		return Geometry{}, errInvalidGeometry
+	}
+	flags := b[1]
+	hasZ, hasM, hasBBox := flags&0x01 != 0, flags&0x02 != 0, flags&0x04 != 0
+
+	r := spatialReader{b: b[8:], dims: 2}
+	if hasZ {
+		r.dims++
+		r.typeOffset += 1000
+	}
+	if hasM {
+		r.dims++
+		r.typeOffset += 2000
+	}
+	if hasBBox {
+		// The bounding box holds the minimum and the maximum of each coordinate as float32.
+		if len(r.b) < r.dims*8 {
+			return Geometry{}, errInvalidGeometry
+		}
+		r.b = r.b[r.dims*8:]
+	}
+
+	wkb, err := r.appendGeometry(make([]byte, 0, len(b)))
+	if err != nil {
+		return Geometry{}, err
+	}
+	if len(r.b) != 0 {
+		return Geometry{}, errInvalidGeometry
+	}
+	return Geometry{wkb: wkb}, nil
+}
+
+// spatialReader reads the internal format of the spatial extension, which stores numbers in little-endian order.
+type spatialReader struct {
+	b []byte
+	// The number of coordinates of the vertices.
+	dims int
+	// The offset of the ISO WKB types, i.e., 1000 for Z, 2000 for M, and 3000 for ZM coordinates.
+	typeOffset uint32
+}
+
+func (r *spatialReader) uint32() (uint32, error) {
+	if len(r.b) < 4 {
+		return 0, errInvalidGeometry
+	}
+	v := binary.LittleEndian.Uint32(r.b)
+	r.b = r.b[4:]
+	return v, nil
+}
+
+// appendVertices appends count vertices to the little-endian WKB value.
+func (r *spatialReader) appendVertices(dst []byte, count uint32) ([]byte, error) {
+	n := int(count) * r.dims * 8
+	if n < 0 || len(r.b) < n {
+		return nil, errInvalidGeometry
+	}
+	dst = append(dst, r.b[:n]...)
+	r.b = r.b[n:]
+	return dst, nil
+}
+
+// appendGeometry appends the next geometry to the little-endian WKB value.
+func (r *spatialReader) appendGeometry(dst []byte) ([]byte, error) {
+	t, err := r.uint32()
+	if err != nil {
+		return nil, err
+	}
+	count, err := r.uint32()
+	if err != nil {
+		return nil, err
+	}
+
+	// The types of the spatial extension start at zero, and have the order of the WKB types.
+	t++
+	if _, ok := wkbTypeNames[t]; !ok {
+		return nil, errInvalidGeometry
+	}
+	dst = append(dst, 1)
+	dst = binary.LittleEndian.AppendUint32(dst, t+r.typeOffset)
+
+	switch t {
+	case wkbPoint:
+		if count == 0 {
+			// WKB represents an empty point with NaN coordinates.
+			for i := 0; i < r.dims; i++ {
+				dst = binary.LittleEndian.AppendUint64(dst, math.Float64bits(math.NaN()))
+			}
+			return dst, nil
+		}
+		return r.appendVertices(dst, 1)
+	case wkbLineString:
+		dst = binary.LittleEndian.AppendUint32(dst, count)
+		return r.appendVertices(dst, count)
+	case wkbPolygon:
+		// The ring lengths precede the rings, padded to a multiple of eight bytes.
+		if int(count) < 0 || len(r.b) < int(count)*4 {
+			return nil, errInvalidGeometry
+		}
+		lengths := make([]uint32, count)
+		for i := range lengths {
+			if lengths[i], err = r.uint32(); err != nil {
+				return nil, err
+			}
+		}
+		if count%2 == 1 {
+			if _, err = r.uint32(); err != nil {
+				return nil, err
+			}
+		}
+		dst = binary.LittleEndian.AppendUint32(dst, count)
+		for _, length := range lengths {
+			dst = binary.LittleEndian.AppendUint32(dst, length)
+			if dst, err = r.appendVertices(dst, length); err != nil {
+				return nil, err
+			}
+		}
+		return dst, nil
+	default:
+		dst = binary.LittleEndian.AppendUint32(dst, count)
+		for i := uint32(0); i < count; i++ {
+			if dst, err = r.appendGeometry(dst); err != nil {
+				return nil, err
+			}
+		}
+		return dst, nil
+	}
+}
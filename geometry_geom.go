@@ -0,0 +1,24 @@
+//go:build duckdb_geom
+
+package duckdb
+
+import (
+	"encoding/binary"
+
+	"github.com/twpayne/go-geom"
+	"github.com/twpayne/go-geom/encoding/wkb"
+)
+
+// Geom returns the geometry as a github.com/twpayne/go-geom geometry.
+func (g Geometry) Geom() (geom.T, error) {
+	return wkb.Unmarshal(g.wkb)
+}
+
+// GeometryFromGeom returns the Geometry of a github.com/twpayne/go-geom geometry.
+func GeometryFromGeom(t geom.T) (Geometry, error) {
+	b, err := wkb.Marshal(t, binary.LittleEndian)
+	if err != nil {
+		return Geometry{}, err
+	}
+	return NewGeometry(b)
+}
@@ -0,0 +1,28 @@
+//go:build duckdb_geom
+
+package duckdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/twpayne/go-geom"
+)
+
+func TestGeometryGeom(t *testing.T) {
+	g, err := NewGeometry(testWKBLineString)
+	require.NoError(t, err)
+
+	lineString, err := g.Geom()
+	require.NoError(t, err)
+	require.Equal(t, geom.NewLineStringFlat(geom.XY, []float64{0, 0, 1, 1.5}), lineString)
+
+	polygon := geom.NewPolygonFlat(geom.XYZ, []float64{0, 0, 1, 4, 0, 1, 4, 4, 1, 0, 0, 1}, []int{12})
+	g, err = GeometryFromGeom(polygon)
+	require.NoError(t, err)
+	require.Equal(t, "POLYGON Z ((0 0 1, 4 0 1, 4 4 1, 0 0 1))", g.WKT())
+
+	roundTrip, err := g.Geom()
+	require.NoError(t, err)
+	require.Equal(t, polygon, roundTrip)
+}
@@ -0,0 +1,206 @@
+package duckdb
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/marcboeker/go-duckdb/mapping"
+)
+
+// littleEndian encodes bytes, uint32, float32, and float64 values in little-endian order.
+func littleEndian(values ...any) []byte {
+	var b []byte
+	for _, v := range values {
+		switch v := v.(type) {
+		case uint8:
+			b = append(b, v)
+		case uint32:
+			b = binary.LittleEndian.AppendUint32(b, v)
+		case float32:
+			b = binary.LittleEndian.AppendUint32(b, math.Float32bits(v))
+		case float64:
+			b = binary.LittleEndian.AppendUint64(b, math.Float64bits(v))
+		case []byte:
+			b = append(b, v...)
+		}
+	}
+	return b
+}
+
+var (
+	testWKBPoint      = littleEndian(uint8(1), uint32(1), 1.0, 2.0)
+	testWKBLineString = littleEndian(uint8(1), uint32(2), uint32(2), 0.0, 0.0, 1.0, 1.5)
+)
+
+func TestGeometry(t *testing.T) {
+	bigEndian := []byte{0, 0, 0, 0, 1}
+	bigEndian = binary.BigEndian.AppendUint64(bigEndian, math.Float64bits(1.5))
+	bigEndian = binary.BigEndian.AppendUint64(bigEndian, math.Float64bits(-2))
+
+	tests := []struct {
+		name string
+		wkb  []byte
+		wkt  string
+	}{
+		{name: "point", wkb: testWKBPoint, wkt: "POINT (1 2)"},
+		{name: "big endian", wkb: bigEndian, wkt: "POINT (1.5 -2)"},
+		{name: "point Z", wkb: littleEndian(uint8(1), uint32(1001), 1.0, 2.0, 3.0), wkt: "POINT Z (1 2 3)"},
+		{name: "point ZM", wkb: littleEndian(uint8(1), uint32(3001), 1.0, 2.0, 3.0, 4.0), wkt: "POINT ZM (1 2 3 4)"},
+		{name: "EWKB with SRID", wkb: littleEndian(uint8(1), uint32(0x20000001), uint32(4326), 1.0, 2.0), wkt: "POINT (1 2)"},
+		{name: "empty point", wkb: littleEndian(uint8(1), uint32(1), math.NaN(), math.NaN()), wkt: "POINT EMPTY"},
+		{name: "linestring", wkb: testWKBLineString, wkt: "LINESTRING (0 0, 1 1.5)"},
+		{name: "empty linestring", wkb: littleEndian(uint8(1), uint32(2), uint32(0)), wkt: "LINESTRING EMPTY"},
+		{
+			name: "polygon",
+			wkb: littleEndian(uint8(1), uint32(3), uint32(2),
+				uint32(4), 0.0, 0.0, 4.0, 0.0, 4.0, 4.0, 0.0, 0.0,
+				uint32(4), 1.0, 1.0, 2.0, 1.0, 2.0, 2.0, 1.0, 1.0),
+			wkt: "POLYGON ((0 0, 4 0, 4 4, 0 0), (1 1, 2 1, 2 2, 1 1))",
+		},
+		{
+			name: "multipoint",
+			wkb:  littleEndian(uint8(1), uint32(4), uint32(2), testWKBPoint, uint8(1), uint32(1), 3.0, 4.0),
+			wkt:  "MULTIPOINT (1 2, 3 4)",
+		},
+		{
+			name: "multilinestring",
+			wkb:  littleEndian(uint8(1), uint32(5), uint32(1), testWKBLineString),
+			wkt:  "MULTILINESTRING ((0 0, 1 1.5))",
+		},
+		{
+			name: "geometry collection",
+			wkb:  littleEndian(uint8(1), uint32(7), uint32(2), testWKBPoint, testWKBLineString),
+			wkt:  "GEOMETRYCOLLECTION (POINT (1 2), LINESTRING (0 0, 1 1.5))",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g, err := NewGeometry(test.wkb)
+			require.NoError(t, err)
+			require.Equal(t, test.wkb, g.WKB())
+			require.Equal(t, test.wkt, g.WKT())
+			require.Equal(t, test.wkt, g.String())
+
+			v, err := g.Value()
+			require.NoError(t, err)
+			require.Equal(t, test.wkb, v)
+
+			var scanned Geometry
+			require.NoError(t, scanned.Scan(test.wkb))
+			require.Equal(t, g, scanned)
+		})
+	}
+
+	invalid := map[string][]byte{
+		"empty":              nil,
+		"byte order":         littleEndian(uint8(2), uint32(1), 1.0, 2.0),
+		"type":               littleEndian(uint8(1), uint32(8), 1.0, 2.0),
+		"truncated":          testWKBPoint[:len(testWKBPoint)-1],
+		"trailing bytes":     append(littleEndian(testWKBPoint), 0),
+		"multipoint element": littleEndian(uint8(1), uint32(4), uint32(1), testWKBLineString),
+	}
+	for name, wkb := range invalid {
+		_, err := NewGeometry(wkb)
+		require.Error(t, err, name)
+	}
+
+	var g Geometry
+	require.ErrorContains(t, g.Scan("POINT (1 2)"), "invalid type `string` for scanning `Geometry`")
+	require.Empty(t, g.WKT())
+	v, err := g.Value()
+	require.NoError(t, err)
+	require.Nil(t, v)
+}
+
+func TestGeometryFromSpatial(t *testing.T) {
+	tests := []struct {
+		name     string
+		internal []byte
+		wkt      string
+	}{
+		{
+			name:     "point",
+			internal: littleEndian(uint8(0), uint8(0), uint32(0), uint8(0), uint8(0), uint32(0), uint32(1), 1.0, 2.0),
+			wkt:      "POINT (1 2)",
+		},
+		{
+			name:     "empty point",
+			internal: littleEndian(uint8(0), uint8(0), uint32(0), uint8(0), uint8(0), uint32(0), uint32(0)),
+			wkt:      "POINT EMPTY",
+		},
+		{
+			// The polygon has a bounding box, and a padded ring count.
+			name: "polygon",
+			internal: littleEndian(uint8(2), uint8(0x04), uint32(0), uint8(0), uint8(0),
+				float32(0), float32(0), float32(4), float32(4),
+				uint32(2), uint32(1), uint32(4), uint32(0),
+				0.0, 0.0, 4.0, 0.0, 4.0, 4.0, 0.0, 0.0),
+			wkt: "POLYGON ((0 0, 4 0, 4 4, 0 0))",
+		},
+		{
+			name: "multipoint Z",
+			internal: littleEndian(uint8(3), uint8(0x01), uint32(0), uint8(0), uint8(0),
+				uint32(3), uint32(2), uint32(0), uint32(1), 1.0, 2.0, 3.0, uint32(0), uint32(1), 4.0, 5.0, 6.0),
+			wkt: "MULTIPOINT Z (1 2 3, 4 5 6)",
+		},
+		{
+			name: "geometry collection",
+			internal: littleEndian(uint8(6), uint8(0), uint32(0), uint8(0), uint8(0),
+				uint32(6), uint32(2), uint32(0), uint32(1), 1.0, 2.0, uint32(1), uint32(2), 0.0, 0.0, 1.0, 1.5),
+			wkt: "GEOMETRYCOLLECTION (POINT (1 2), LINESTRING (0 0, 1 1.5))",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g, err := geometryFromSpatial(test.internal)
+			require.NoError(t, err)
+			require.Equal(t, test.wkt, g.WKT())
+
+			// The WKB value is valid.
+			_, err = NewGeometry(g.WKB())
+			require.NoError(t, err)
+		})
+	}
+
+	invalid := map[string][]byte{
+		"short header":   {0, 0, 0},
+		"type":           littleEndian(uint32(0), uint32(0), uint32(7), uint32(0)),
+		"truncated":      littleEndian(uint32(0), uint32(0), uint32(0), uint32(1), 1.0),
+		"trailing bytes": littleEndian(uint32(0), uint32(0), uint32(0), uint32(1), 1.0, 2.0, uint8(0)),
+		"rings":          littleEndian(uint32(2), uint32(0), uint32(2), uint32(1000)),
+	}
+	for name, internal := range invalid {
+		_, err := geometryFromSpatial(internal)
+		require.Error(t, err, name)
+	}
+}
+
+func TestGeometryVector(t *testing.T) {
+	// Emulate the GEOMETRY type of the spatial extension, which is a BLOB with an alias.
+	logicalType := mapping.CreateLogicalType(TYPE_BLOB)
+	defer mapping.DestroyLogicalType(&logicalType)
+	mapping.LogicalTypeSetAlias(logicalType, aliasGeometry)
+
+	chunk := mapping.CreateDataChunk([]mapping.LogicalType{logicalType})
+	defer mapping.DestroyDataChunk(&chunk)
+
+	var vec vector
+	require.NoError(t, vec.init(logicalType, 0))
+	vec.initVectors(mapping.DataChunkGetVector(chunk, 0), true)
+	require.True(t, vec.isGeometry)
+
+	point := littleEndian(uint32(0), uint32(0), uint32(0), uint32(1), 1.0, 2.0)
+	require.NoError(t, vec.setFn(&vec, 0, point))
+	require.NoError(t, vec.setFn(&vec, 1, nil))
+	require.NoError(t, vec.setFn(&vec, 2, []byte{1, 2, 3}))
+
+	g, ok := vec.getFn(&vec, 0).(Geometry)
+	require.True(t, ok)
+	require.Equal(t, testWKBPoint, g.WKB())
+	require.Nil(t, vec.getFn(&vec, 1))
+	// Values that are not in the internal format of the spatial extension remain blobs.
+	require.Equal(t, []byte{1, 2, 3}, vec.getFn(&vec, 2))
+}
@@ -8,7 +8,9 @@ require (
 	github.com/google/uuid v1.6.0
 	github.com/marcboeker/go-duckdb/arrowmapping v0.0.7
 	github.com/marcboeker/go-duckdb/mapping v0.0.7
+	github.com/shopspring/decimal v1.4.0
 	github.com/stretchr/testify v1.10.0
+	github.com/twpayne/go-geom v1.6.1
 )
 
 require (
@@ -0,0 +1,105 @@
+package duckdb
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net/netip"
+	"reflect"
+
+	"github.com/marcboeker/go-duckdb/mapping"
+)
+
+// The inet extension stores an INET value as STRUCT(ip_type UTINYINT, address HUGEINT, mask USMALLINT).
+// The address is unsigned, so the extension flips its most significant bit to store it as a HUGEINT.
+const (
+	inetIPv4 = 1
+	inetIPv6 = 2
+)
+
+var reflectTypePrefix = reflect.TypeFor[netip.Prefix]()
+
+func (vec *vector) initINET(logicalType mapping.LogicalType, colIdx int) error {
+	if err := vec.initStruct(logicalType, colIdx); err != nil {
+		return err
+	}
+	// Keep the STRUCT values of unexpected layouts.
+	if len(vec.childVectors) != 3 || vec.childVectors[0].Type != TYPE_UTINYINT ||
+		vec.childVectors[1].Type != TYPE_HUGEINT || vec.childVectors[2].Type != TYPE_USMALLINT {
+		return nil
+	}
+	vec.getFn = func(vec *vector, rowIdx mapping.IdxT) any {
+		if vec.getNull(rowIdx) {
+			return nil
+		}
+		return vec.getINET(rowIdx)
+	}
+	vec.isINET = true
+	return nil
+}
+
+func (vec *vector) getINET(rowIdx mapping.IdxT) any {
+	ipType := getPrimitive[uint8](&vec.childVectors[0], rowIdx)
+	address := getPrimitive[mapping.HugeInt](&vec.childVectors[1], rowIdx)
+	mask := getPrimitive[uint16](&vec.childVectors[2], rowIdx)
+	prefix, err := inetPrefix(ipType, &address, mask)
+	if err != nil {
+		return vec.getStruct(rowIdx)
+	}
+	return prefix
+}
+
+// inetPrefix converts the fields of an INET value to a netip.Prefix.
+func inetPrefix(ipType uint8, address *mapping.HugeInt, mask uint16) (netip.Prefix, error) {
+	lower, upper := mapping.HugeIntMembers(address)
+	var addr netip.Addr
+	switch ipType {
+	case inetIPv4:
+		addr = netip.AddrFrom4([4]byte(binary.BigEndian.AppendUint32(nil, uint32(lower))))
+	case inetIPv6:
+		b := binary.BigEndian.AppendUint64(nil, uint64(upper)^(1<<63))
+		addr = netip.AddrFrom16([16]byte(binary.BigEndian.AppendUint64(b, lower)))
+	default:
+		return netip.Prefix{}, invalidInputError(fmt.Sprintf("IP type %d", ipType), "an IPv4 or IPv6 INET value")
+	}
+	prefix := netip.PrefixFrom(addr, int(mask))
+	if !prefix.IsValid() {
+		return netip.Prefix{}, invalidInputError(fmt.Sprintf("mask %d", mask), fmt.Sprintf("a mask of at most %d bits", addr.BitLen()))
+	}
+	return prefix, nil
+}
+
+// inetText returns the text of a netip.Addr or netip.Prefix, or of a non-nil pointer to one, which DuckDB casts to INET.
+// It returns nil for a nil pointer.
+func inetText(val any) (any, bool, error) {
+	switch v := val.(type) {
+	case netip.Addr:
+		if !v.IsValid() {
+			return nil, true, invalidInputError("the zero netip.Addr", "a valid IP address")
+		}
+		return v.String(), true, nil
+	case netip.Prefix:
+		if !v.IsValid() {
+			return nil, true, invalidInputError(v.String(), "a valid IP prefix")
+		}
+		return v.String(), true, nil
+	case *netip.Addr:
+		if v == nil {
+			return nil, true, nil
+		}
+		return inetText(*v)
+	case *netip.Prefix:
+		if v == nil {
+			return nil, true, nil
+		}
+		return inetText(*v)
+	}
+	return nil, false, nil
+}
+
+// inetString returns the text of an INET value in the format of DuckDB, which omits the mask of single addresses.
+func inetString(prefix netip.Prefix) string {
+	if prefix.IsSingleIP() {
+		return prefix.Addr().String()
+	}
+	return prefix.String()
+}
@@ -0,0 +1,101 @@
+package duckdb
+
+import (
+	"math/big"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/marcboeker/go-duckdb/mapping"
+)
+
+// inetAddress returns the HUGEINT address of an IP address in the storage format of the inet extension.
+func inetAddress(addr netip.Addr) *big.Int {
+	b := addr.As16()
+	if addr.Is4() {
+		return new(big.Int).SetBytes(b[12:])
+	}
+	u := new(big.Int).SetBytes(b[:])
+	// Flip the most significant bit of the unsigned address.
+	return u.Sub(u, new(big.Int).Lsh(big.NewInt(1), 127))
+}
+
+func TestINETVector(t *testing.T) {
+	// Emulate the INET type of the inet extension, which is a STRUCT with an alias.
+	types := []mapping.LogicalType{
+		mapping.CreateLogicalType(TYPE_UTINYINT),
+		mapping.CreateLogicalType(TYPE_HUGEINT),
+		mapping.CreateLogicalType(TYPE_USMALLINT),
+	}
+	logicalType := mapping.CreateStructType(types, []string{"ip_type", "address", "mask"})
+	for i := range types {
+		mapping.DestroyLogicalType(&types[i])
+	}
+	defer mapping.DestroyLogicalType(&logicalType)
+	mapping.LogicalTypeSetAlias(logicalType, aliasINET)
+
+	chunk := mapping.CreateDataChunk([]mapping.LogicalType{logicalType})
+	defer mapping.DestroyDataChunk(&chunk)
+
+	var vec vector
+	require.NoError(t, vec.init(logicalType, 0))
+	vec.initVectors(mapping.DataChunkGetVector(chunk, 0), true)
+	require.True(t, vec.isINET)
+
+	prefixes := []netip.Prefix{
+		netip.MustParsePrefix("192.168.0.1/32"),
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("::1/128"),
+		netip.MustParsePrefix("2001:db8::/32"),
+		netip.MustParsePrefix("ffff:ffff::/32"),
+	}
+	for i, prefix := range prefixes {
+		ipType := uint8(inetIPv6)
+		if prefix.Addr().Is4() {
+			ipType = inetIPv4
+		}
+		row := map[string]any{"ip_type": ipType, "address": inetAddress(prefix.Addr()), "mask": uint16(prefix.Bits())}
+		require.NoError(t, vec.setFn(&vec, mapping.IdxT(i), row))
+	}
+	require.NoError(t, vec.setFn(&vec, 5, nil))
+	invalid := map[string]any{"ip_type": uint8(1), "address": big.NewInt(1), "mask": uint16(33)}
+	require.NoError(t, vec.setFn(&vec, 6, invalid))
+
+	for i, prefix := range prefixes {
+		require.Equal(t, prefix, vec.getFn(&vec, mapping.IdxT(i)))
+	}
+	require.Nil(t, vec.getFn(&vec, 5))
+	// Invalid values remain STRUCT values.
+	require.Equal(t, invalid, vec.getFn(&vec, 6))
+}
+
+func TestINETString(t *testing.T) {
+	require.Equal(t, "192.168.0.1", inetString(netip.MustParsePrefix("192.168.0.1/32")))
+	require.Equal(t, "10.0.0.0/8", inetString(netip.MustParsePrefix("10.0.0.0/8")))
+	require.Equal(t, "::1", inetString(netip.MustParsePrefix("::1/128")))
+	require.Equal(t, "2001:db8::/32", inetString(netip.MustParsePrefix("2001:db8::/32")))
+}
+
+func TestBindINET(t *testing.T) {
+	db := openDbWrapper(t, ``)
+	defer closeDbWrapper(t, db)
+
+	addr := netip.MustParseAddr("2001:db8::1")
+	prefix := netip.MustParsePrefix("10.0.0.0/8")
+
+	var s string
+	require.NoError(t, db.QueryRow(`SELECT ?::VARCHAR`, addr).Scan(&s))
+	require.Equal(t, "2001:db8::1", s)
+	require.NoError(t, db.QueryRow(`SELECT ?::VARCHAR`, &prefix).Scan(&s))
+	require.Equal(t, "10.0.0.0/8", s)
+
+	var v any
+	require.NoError(t, db.QueryRow(`SELECT ?`, (*netip.Addr)(nil)).Scan(&v))
+	require.Nil(t, v)
+
+	_, err := db.Exec(`SELECT ?`, netip.Addr{})
+	require.ErrorContains(t, err, invalidInputErrMsg)
+	_, err = db.Exec(`SELECT ?`, netip.Prefix{})
+	require.ErrorContains(t, err, invalidInputErrMsg)
+}
@@ -0,0 +1,213 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type interpolateContextKey struct{}
+
+// WithInterpolation returns a context that makes ExecContext and QueryContext render the arguments
+// into the query as SQL literals instead of binding them to a prepared statement.
+// Use it for statements that cannot be prepared with parameters,
+// e.g., CREATE VIEW v AS SELECT ?, or ATTACH ? AS db.
+// The query's placeholders (?, $1, $name) outside string literals, quoted identifiers,
+// and comments are replaced by the matching argument.
+// time.Time arguments are rendered as TIMESTAMP literals in UTC.
+func WithInterpolation(ctx context.Context) context.Context {
+	return context.WithValue(ctx, interpolateContextKey{}, true)
+}
+
+func interpolationEnabled(ctx context.Context) bool {
+	enabled, ok := ctx.Value(interpolateContextKey{}).(bool)
+	return ok && enabled
+}
+
+// interpolateParams replaces the placeholders of the query with the arguments' SQL literals.
+func interpolateParams(query string, args []driver.NamedValue) (string, error) {
+	var b strings.Builder
+	positional := 0
+
+	for i := 0; i < len(query); {
+		c := query[i]
+		switch {
+		case c == '\'' || c == '"':
+			// Skip the string literal or quoted identifier. Doubled quotes escape the quote.
+			j := i + 1
+			for j < len(query) {
+				if query[j] == c {
+					if j+1 < len(query) && query[j+1] == c {
+						j += 2
+						continue
+					}
+					break
+				}
+				j++
+			}
+			end := min(j+1, len(query))
+			b.WriteString(query[i:end])
+			i = end
+
+		case strings.HasPrefix(query[i:], "--"):
+			end := strings.IndexByte(query[i:], '\n')
+			if end == -1 {
+				end = len(query) - i
+			}
+			b.WriteString(query[i : i+end])
+			i += end
+
+		case strings.HasPrefix(query[i:], "/*"):
+			end := strings.Index(query[i+2:], "*/")
+			if end == -1 {
+				end = len(query) - i
+			} else {
+				end += 4
+			}
+			b.WriteString(query[i : i+end])
+			i += end
+
+		case c == '?':
+			positional++
+			lit, err := interpolateArg(args, positional, "")
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(lit)
+			i++
+
+		case c == '$' && (i == 0 || !isIdentifierChar(query[i-1])):
+			j := i + 1
+			for j < len(query) && isIdentifierChar(query[j]) {
+				j++
+			}
+			if j < len(query) && query[j] == '$' {
+				// Skip the dollar-quoted string.
+				tag := query[i : j+1]
+				end := strings.Index(query[j+1:], tag)
+				if end == -1 {
+					b.WriteString(query[i:])
+					return b.String(), nil
+				}
+				end += j + 1 + len(tag)
+				b.WriteString(query[i:end])
+				i = end
+				continue
+			}
+			if j == i+1 {
+				b.WriteByte(c)
+				i++
+				continue
+			}
+
+			param := query[i+1 : j]
+			var lit string
+			var err error
+			if ordinal, errConv := strconv.Atoi(param); errConv == nil {
+				lit, err = interpolateArg(args, ordinal, "")
+			} else {
+				lit, err = interpolateArg(args, 0, param)
+			}
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(lit)
+			i = j
+
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+
+	return b.String(), nil
+}
+
+func isIdentifierChar(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c >= 0x80
+}
+
+// interpolateArg returns the SQL literal of the argument with the ordinal or name.
+func interpolateArg(args []driver.NamedValue, ordinal int, name string) (string, error) {
+	for _, arg := range args {
+		if (name == "" && arg.Ordinal == ordinal) || (name != "" && arg.Name == name) {
+			return formatLiteral(arg.Value)
+		}
+	}
+	if name != "" {
+		return "", invalidInputError("$"+name, "named argument")
+	}
+	return "", paramIndexError(ordinal, uint64(len(args)))
+}
+
+// formatLiteral returns the SQL literal of a value.
+func formatLiteral(v any) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "NULL", nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int8, int16, int32, int64, int, uint8, uint16, uint32, uint64, uint:
+		s := fmt.Sprintf("%d", v)
+		if strings.HasPrefix(s, "-") {
+			// Otherwise, e.g., a-? becomes a--1, which starts a comment.
+			return "(" + s + ")", nil
+		}
+		return s, nil
+	case *big.Int:
+		return QuoteLiteral(v.String()) + "::HUGEINT", nil
+	case Decimal:
+		return fmt.Sprintf("%s::DECIMAL(%d, %d)", QuoteLiteral(v.String()), v.Width, v.Scale), nil
+	case float32:
+		return formatFloat(float64(v), 32, "FLOAT"), nil
+	case float64:
+		return formatFloat(v, 64, "DOUBLE"), nil
+	case string:
+		return QuoteLiteral(v), nil
+	case []byte:
+		var b strings.Builder
+		b.WriteByte('\'')
+		for _, c := range v {
+			fmt.Fprintf(&b, `\x%02X`, c)
+		}
+		b.WriteString("'::BLOB")
+		return b.String(), nil
+	case UUID:
+		return QuoteLiteral(v.String()) + "::UUID", nil
+	case *UUID:
+		return QuoteLiteral(v.String()) + "::UUID", nil
+	case Interval:
+		return fmt.Sprintf("INTERVAL '%d months %d days %d microseconds'", v.Months, v.Days, v.Micros), nil
+	case time.Time:
+		return QuoteLiteral(v.UTC().Format("2006-01-02 15:04:05.999999")) + "::TIMESTAMP", nil
+	case TimestampNS:
+		switch v {
+		case TimestampNSInfinity:
+			return "'infinity'::TIMESTAMP_NS", nil
+		case TimestampNSNegInfinity:
+			return "'-infinity'::TIMESTAMP_NS", nil
+		}
+		return QuoteLiteral(v.Time().Format("2006-01-02 15:04:05.999999999")) + "::TIMESTAMP_NS", nil
+	}
+	return "", unsupportedTypeError(fmt.Sprintf("%T", v))
+}
+
+func formatFloat(f float64, bitSize int, typeName string) string {
+	var s string
+	switch {
+	case math.IsNaN(f):
+		s = "nan"
+	case math.IsInf(f, 1):
+		s = "inf"
+	case math.IsInf(f, -1):
+		s = "-inf"
+	default:
+		s = strconv.FormatFloat(f, 'g', -1, bitSize)
+	}
+	return QuoteLiteral(s) + "::" + typeName
+}
@@ -0,0 +1,102 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterpolateParams(t *testing.T) {
+	args := []driver.NamedValue{
+		{Ordinal: 1, Value: int64(42)},
+		{Ordinal: 2, Value: "it's"},
+		{Ordinal: 3, Name: "name", Value: nil},
+	}
+
+	query, err := interpolateParams(`SELECT ?, ?, '?', "?" -- ?
+/* $1 */ $1, $name, $$ ? $$, $tag$ ? $tag$, foo$bar`, args)
+	require.NoError(t, err)
+	require.Equal(t, `SELECT 42, 'it''s', '?', "?" -- ?
+/* $1 */ 42, NULL, $$ ? $$, $tag$ ? $tag$, foo$bar`, query)
+
+	_, err = interpolateParams(`SELECT ?, ?, ?, ?`, args)
+	require.ErrorContains(t, err, paramIndexErrMsg)
+	_, err = interpolateParams(`SELECT $missing`, args)
+	require.ErrorContains(t, err, invalidInputErrMsg)
+}
+
+func TestInterpolateNegativeNumbers(t *testing.T) {
+	db := openDbWrapper(t, ``)
+	defer closeDbWrapper(t, db)
+
+	payload := "foo\n; DROP TABLE t; --"
+	_, err := db.Exec(`CREATE TABLE t (id VARCHAR, a INTEGER); INSERT INTO t VALUES (?, 1)`, payload)
+	require.NoError(t, err)
+
+	ctx := WithInterpolation(context.Background())
+	_, err = db.ExecContext(ctx, `UPDATE t SET a = a-? WHERE id = ?`, int64(-1), payload)
+	require.NoError(t, err)
+
+	var a int32
+	require.NoError(t, db.QueryRow(`SELECT a FROM t WHERE id = ?`, payload).Scan(&a))
+	require.Equal(t, int32(2), a)
+
+	query, err := interpolateParams(`SELECT 1-?, ?`, []driver.NamedValue{
+		{Ordinal: 1, Value: int8(-1)},
+		{Ordinal: 2, Value: uint(1)},
+	})
+	require.NoError(t, err)
+	require.Equal(t, `SELECT 1-(-1), 1`, query)
+}
+
+func TestWithInterpolation(t *testing.T) {
+	db := openDbWrapper(t, ``)
+	defer closeDbWrapper(t, db)
+
+	ctx := WithInterpolation(context.Background())
+
+	// CREATE VIEW cannot be prepared with parameters.
+	_, err := db.ExecContext(context.Background(), `CREATE VIEW v AS SELECT ? AS x`, 1)
+	require.Error(t, err)
+
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 123456000, time.UTC)
+	_, err = db.ExecContext(ctx, `CREATE VIEW v AS SELECT ? AS b, ? AS i, ? AS h, ? AS f, ? AS s, ? AS blob, ? AS ts, ? AS iv, ? AS n`,
+		true, int64(-7), big.NewInt(math.MaxInt64), 1.5, `a'"b`, []byte{0, 'x', 0xff}, ts, Interval{Months: 1, Days: 2, Micros: 3}, nil)
+	require.NoError(t, err)
+
+	var (
+		b    bool
+		i    int64
+		h    *big.Int
+		f    float64
+		s    string
+		blob []byte
+		tsv  time.Time
+		iv   Interval
+		n    sql.NullString
+	)
+	row := db.QueryRow(`SELECT * FROM v`)
+	require.NoError(t, row.Scan(&b, &i, &h, &f, &s, &blob, &tsv, &iv, &n))
+	require.True(t, b)
+	require.Equal(t, int64(-7), i)
+	require.Equal(t, big.NewInt(math.MaxInt64), h)
+	require.Equal(t, 1.5, f)
+	require.Equal(t, `a'"b`, s)
+	require.Equal(t, []byte{0, 'x', 0xff}, blob)
+	require.Equal(t, ts, tsv.UTC())
+	require.Equal(t, Interval{Months: 1, Days: 2, Micros: 3}, iv)
+	require.False(t, n.Valid)
+
+	_, err = db.ExecContext(ctx, `SELECT ?, ?`, 1)
+	testError(t, err, errCouldNotBind.Error(), paramIndexErrMsg)
+
+	var nan float64
+	require.NoError(t, db.QueryRowContext(ctx, `SELECT $val`, sql.Named("val", math.NaN())).Scan(&nan))
+	require.True(t, math.IsNaN(nan))
+}
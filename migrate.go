@@ -0,0 +1,204 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/marcboeker/go-duckdb/mapping"
+)
+
+// MigrationsTable is the name of the table tracking the applied migrations.
+const MigrationsTable = "schema_migrations"
+
+// MigrationError is returned by Migrate, if a migration fails.
+type MigrationError struct {
+	// Version and Name of the failed migration.
+	Version int64
+	Name    string
+	// Statement is the 1-based position of the failed statement in the migration file,
+	// or zero, if the migration failed outside a single statement, e.g., when parsing or committing it.
+	Statement int
+	// Err is the error of the failed statement.
+	Err error
+}
+
+func (e *MigrationError) Error() string {
+	return fmt.Sprintf("%s: %s: %s, statement %d: %s", driverErrMsg, errMigrate.Error(), e.Name, e.Statement, e.Err.Error())
+}
+
+func (e *MigrationError) Unwrap() []error {
+	return []error{errMigrate, e.Err}
+}
+
+type migration struct {
+	version int64
+	name    string
+}
+
+// migrateLock serializes all migrations of this process,
+// as DuckDB only allows a single writer to each database.
+var migrateLock sync.Mutex
+
+// Migrate applies all pending migrations of a file system in the order of their versions.
+// Each migration is a file named <version>_<description>.sql in the root directory of the file system,
+// e.g., 0001_create_users.sql. A migration can contain multiple statements.
+// Migrate runs each migration in a transaction, and records its version in the MigrationsTable.
+func Migrate(ctx context.Context, db *sql.DB, fsys fs.FS) error {
+	migrations, err := readMigrations(fsys)
+	if err != nil {
+		return getError(errMigrate, err)
+	}
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	migrateLock.Lock()
+	defer migrateLock.Unlock()
+
+	return conn.Raw(func(driverConn any) error {
+		c, ok := driverConn.(*Conn)
+		if !ok {
+			return getError(errMigrate, errInvalidCon)
+		}
+		if c.tx {
+			return getError(errMigrate, errMultipleTx)
+		}
+
+		createQuery := `CREATE TABLE IF NOT EXISTS ` + MigrationsTable +
+			` (version BIGINT PRIMARY KEY, name VARCHAR NOT NULL, applied_at TIMESTAMPTZ NOT NULL DEFAULT now())`
+		if _, err = c.ExecContext(ctx, createQuery, nil); err != nil {
+			return getError(errMigrate, err)
+		}
+
+		for _, m := range migrations {
+			if err = c.applyMigration(ctx, fsys, m); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func readMigrations(fsys fs.FS) ([]migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	var migrations []migration
+	versions := map[int64]string{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || path.Ext(name) != ".sql" {
+			continue
+		}
+
+		prefix, _, _ := strings.Cut(strings.TrimSuffix(name, ".sql"), "_")
+		version, errParse := strconv.ParseInt(prefix, 10, 64)
+		if errParse != nil {
+			return nil, invalidInputError(name, "<version>_<description>.sql")
+		}
+		if other, ok := versions[version]; ok {
+			return nil, duplicateNameError(other + ", " + name)
+		}
+		versions[version] = name
+		migrations = append(migrations, migration{version: version, name: name})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].version < migrations[j].version
+	})
+	return migrations, nil
+}
+
+func (conn *Conn) applyMigration(ctx context.Context, fsys fs.FS, m migration) error {
+	content, err := fs.ReadFile(fsys, m.name)
+	if err != nil {
+		return &MigrationError{Version: m.version, Name: m.name, Err: err}
+	}
+
+	if _, err = conn.ExecContext(ctx, `BEGIN TRANSACTION`, nil); err != nil {
+		return &MigrationError{Version: m.version, Name: m.name, Err: err}
+	}
+
+	statement, err := conn.applyMigrationInTx(ctx, string(content), m)
+	if err != nil {
+		_, errRollback := conn.ExecContext(context.Background(), `ROLLBACK`, nil)
+		return &MigrationError{Version: m.version, Name: m.name, Statement: statement, Err: errors.Join(err, errRollback)}
+	}
+
+	if _, err = conn.ExecContext(ctx, `COMMIT`, nil); err != nil {
+		_, errRollback := conn.ExecContext(context.Background(), `ROLLBACK`, nil)
+		return &MigrationError{Version: m.version, Name: m.name, Err: errors.Join(err, errRollback)}
+	}
+	return nil
+}
+
+// applyMigrationInTx executes the migration, unless it has already been applied.
+// It returns the 1-based position of the failed statement, if any.
+func (conn *Conn) applyMigrationInTx(ctx context.Context, query string, m migration) (int, error) {
+	// Checking inside the transaction guards against concurrent migrations of other processes.
+	r, err := conn.QueryContext(ctx, `SELECT count(*) FROM `+MigrationsTable+` WHERE version = ?`,
+		[]driver.NamedValue{{Ordinal: 1, Value: m.version}})
+	if err != nil {
+		return 0, err
+	}
+	values := make([]driver.Value, 1)
+	err = r.Next(values)
+	errClose := r.Close()
+	if err != nil || errClose != nil {
+		return 0, errors.Join(err, errClose)
+	}
+	if values[0].(int64) != 0 {
+		return 0, nil
+	}
+
+	if statement, errExec := conn.execEachStmt(ctx, query); errExec != nil {
+		return statement, errExec
+	}
+
+	_, err = conn.ExecContext(ctx, `INSERT INTO `+MigrationsTable+` (version, name) VALUES (?, ?)`,
+		[]driver.NamedValue{{Ordinal: 1, Value: m.version}, {Ordinal: 2, Value: m.name}})
+	return 0, err
+}
+
+// execEachStmt executes each statement of a query without arguments.
+// It returns the 1-based position of the failed statement, if any.
+// Queries without any statements, e.g., only containing comments, are no-ops.
+func (conn *Conn) execEachStmt(ctx context.Context, query string) (int, error) {
+	stmts, count, err := conn.extractStmts(query)
+	if errors.Is(err, errEmptyQuery) {
+		return 0, nil
+	}
+	if err != nil {
+		// The error message of the parser contains the line of the error.
+		return 0, err
+	}
+	defer mapping.DestroyExtracted(stmts)
+
+	for i := mapping.IdxT(0); i < count; i++ {
+		stmt, errPrepare := conn.prepareExtractedStmt(*stmts, i)
+		if errPrepare != nil {
+			return int(i) + 1, errPrepare
+		}
+
+		_, errExec := stmt.ExecContext(ctx, nil)
+		errClose := stmt.Close()
+		if errExec != nil || errClose != nil {
+			return int(i) + 1, errors.Join(errExec, errClose)
+		}
+	}
+	return 0, nil
+}
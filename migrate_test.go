@@ -0,0 +1,71 @@
+package duckdb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrate(t *testing.T) {
+	db := openDbWrapper(t, ``)
+	defer closeDbWrapper(t, db)
+
+	fsys := fstest.MapFS{
+		"0002_add_users.sql":    {Data: []byte(`INSERT INTO users VALUES (1, 'alice'); INSERT INTO users VALUES (2, 'bob');`)},
+		"0001_create_users.sql": {Data: []byte(`CREATE TABLE users (id INTEGER PRIMARY KEY, name VARCHAR);`)},
+		"0003_comment.sql":      {Data: []byte(`-- nothing to do`)},
+		"README.md":             {Data: []byte(`not a migration`)},
+	}
+	require.NoError(t, Migrate(context.Background(), db, fsys))
+
+	var count int
+	require.NoError(t, db.QueryRow(`SELECT count(*) FROM users`).Scan(&count))
+	require.Equal(t, 2, count)
+	require.NoError(t, db.QueryRow(`SELECT count(*) FROM `+MigrationsTable).Scan(&count))
+	require.Equal(t, 3, count)
+
+	// Applying the migrations again is a no-op.
+	fsys["0004_add_carol.sql"] = &fstest.MapFile{Data: []byte(`INSERT INTO users VALUES (3, 'carol');`)}
+	require.NoError(t, Migrate(context.Background(), db, fsys))
+	require.NoError(t, db.QueryRow(`SELECT count(*) FROM users`).Scan(&count))
+	require.Equal(t, 3, count)
+}
+
+func TestErrMigrate(t *testing.T) {
+	db := openDbWrapper(t, ``)
+	defer closeDbWrapper(t, db)
+
+	fsys := fstest.MapFS{
+		"1_create.sql": {Data: []byte(`CREATE TABLE t (i INTEGER PRIMARY KEY);`)},
+		"2_insert.sql": {Data: []byte(`INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (2);
+INSERT INTO t VALUES (1);`)},
+	}
+	err := Migrate(context.Background(), db, fsys)
+	testError(t, err, errMigrate.Error(), "2_insert.sql, statement 3", "duplicate key")
+
+	var migrationErr *MigrationError
+	require.True(t, errors.As(err, &migrationErr))
+	require.Equal(t, int64(2), migrationErr.Version)
+	require.Equal(t, 3, migrationErr.Statement)
+
+	var duckdbErr *Error
+	require.True(t, errors.As(err, &duckdbErr))
+	require.Equal(t, ErrorTypeConstraint, duckdbErr.Type)
+
+	// The failed migration has been rolled back.
+	var count int
+	require.NoError(t, db.QueryRow(`SELECT count(*) FROM t`).Scan(&count))
+	require.Equal(t, 0, count)
+	require.NoError(t, db.QueryRow(`SELECT max(version) FROM `+MigrationsTable).Scan(&count))
+	require.Equal(t, 1, count)
+
+	err = Migrate(context.Background(), db, fstest.MapFS{"create.sql": {}})
+	testError(t, err, errMigrate.Error(), invalidInputErrMsg)
+
+	err = Migrate(context.Background(), db, fstest.MapFS{"1_a.sql": {}, "01_b.sql": {}})
+	testError(t, err, errMigrate.Error(), duplicateNameErrMsg)
+}
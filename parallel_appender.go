@@ -0,0 +1,100 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+// ParallelAppender appends rows to a table from multiple goroutines.
+// It owns a fixed number of connections, each with its own Appender,
+// and distributes the appended rows across them.
+// The order of rows appended by different goroutines is undefined.
+type ParallelAppender struct {
+	shards []*appenderShard
+	next   atomic.Uint64
+}
+
+type appenderShard struct {
+	mu   sync.Mutex
+	conn driver.Conn
+	a    *Appender
+}
+
+// NewParallelAppender opens n connections with the Connector, and creates an Appender
+// with the options on each of them. It is the caller's responsibility to close the ParallelAppender.
+func NewParallelAppender(c *Connector, catalog, schema, table string, n int, opts ...AppenderOption) (*ParallelAppender, error) {
+	if n < 1 {
+		return nil, getError(errAppenderCreation, invalidInputError(strconv.Itoa(n), "at least one connection"))
+	}
+
+	p := &ParallelAppender{}
+	for i := 0; i < n; i++ {
+		conn, err := c.Connect(context.Background())
+		if err != nil {
+			return nil, errors.Join(err, p.Close())
+		}
+		a, err := NewAppenderWithOptions(conn, catalog, schema, table, opts...)
+		if err != nil {
+			return nil, errors.Join(err, conn.Close(), p.Close())
+		}
+		p.shards = append(p.shards, &appenderShard{conn: conn, a: a})
+	}
+	return p, nil
+}
+
+// AppendRow loads a row of values into one of the appenders. It is safe for concurrent use.
+func (p *ParallelAppender) AppendRow(args ...driver.Value) error {
+	s := p.lockShard()
+	defer s.mu.Unlock()
+	return s.a.AppendRow(args...)
+}
+
+// AppendRows loads a batch of rows into one of the appenders. It is safe for concurrent use.
+// See Appender.AppendRows.
+func (p *ParallelAppender) AppendRows(rows [][]driver.Value) error {
+	s := p.lockShard()
+	defer s.mu.Unlock()
+	return s.a.AppendRows(rows)
+}
+
+// Flush flushes the rows of all appenders to the table, one appender after another.
+// Rows appended concurrently to Flush may or may not be flushed.
+func (p *ParallelAppender) Flush() error {
+	var errs []error
+	for _, s := range p.shards {
+		s.mu.Lock()
+		errs = append(errs, s.a.Flush())
+		s.mu.Unlock()
+	}
+	return errors.Join(errs...)
+}
+
+// Close closes all appenders, which flushes their rows, and their connections.
+func (p *ParallelAppender) Close() error {
+	var errs []error
+	for _, s := range p.shards {
+		s.mu.Lock()
+		errs = append(errs, s.a.Close(), s.conn.Close())
+		s.mu.Unlock()
+	}
+	return errors.Join(errs...)
+}
+
+// lockShard locks and returns an idle shard, if any. Otherwise, it waits for the next shard in round-robin order.
+func (p *ParallelAppender) lockShard() *appenderShard {
+	start := p.next.Add(1)
+	n := uint64(len(p.shards))
+	for i := uint64(0); i < n; i++ {
+		s := p.shards[(start+i)%n]
+		if s.mu.TryLock() {
+			return s
+		}
+	}
+	s := p.shards[start%n]
+	s.mu.Lock()
+	return s
+}
@@ -0,0 +1,64 @@
+package duckdb
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParallelAppender(t *testing.T) {
+	c := newConnectorWrapper(t, ``, nil)
+	defer closeConnectorWrapper(t, c)
+	db := sql.OpenDB(c)
+	defer closeDbWrapper(t, db)
+	createTable(t, db, `CREATE TABLE test (worker INTEGER, i INTEGER)`)
+
+	p, err := NewParallelAppender(c, "", "", "test", 4)
+	require.NoError(t, err)
+
+	const workers = 8
+	const rowsPerWorker = 5000
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < rowsPerWorker; i++ {
+				if err := p.AppendRow(int32(w), int32(i)); err != nil {
+					errs <- err
+					return
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	close(errs)
+	for err = range errs {
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, p.Flush())
+	var count, workerCount int
+	require.NoError(t, db.QueryRow(`SELECT count(*), count(DISTINCT worker) FROM test`).Scan(&count, &workerCount))
+	require.Equal(t, workers*rowsPerWorker, count)
+	require.Equal(t, workers, workerCount)
+
+	require.NoError(t, p.AppendRow(int32(-1), int32(-1)))
+	require.NoError(t, p.AppendRows([][]driver.Value{{int32(-2), int32(-2)}, {int32(-3), int32(-3)}}))
+	require.NoError(t, p.Close())
+	require.NoError(t, db.QueryRow(`SELECT count(*) FROM test`).Scan(&count))
+	require.Equal(t, workers*rowsPerWorker+3, count)
+
+	err = p.AppendRow(int32(0), int32(0))
+	testError(t, err, errAppenderAppendAfterClose.Error())
+
+	// Invalid inputs.
+	_, err = NewParallelAppender(c, "", "", "test", 0)
+	testError(t, err, errAppenderCreation.Error(), invalidInputErrMsg)
+	_, err = NewParallelAppender(c, "", "", "does_not_exist", 2)
+	testError(t, err, errAppenderCreation.Error())
+}
@@ -0,0 +1,240 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+
+	"github.com/marcboeker/go-duckdb/mapping"
+)
+
+// QueryProgress holds the progress of the query running on a connection.
+type QueryProgress struct {
+	// Percentage is the estimated progress in percent, or -1, if no estimate is available.
+	Percentage float64
+	// RowsProcessed is the number of rows processed so far.
+	RowsProcessed uint64
+	// TotalRowsToProcess is the estimated total number of rows to process.
+	TotalRowsToProcess uint64
+}
+
+func getQueryProgress(conn mapping.Connection) QueryProgress {
+	progress := mapping.QueryProgress(conn)
+	percentage, processed, total := mapping.QueryProgressTypeMembers(&progress)
+	return QueryProgress{
+		Percentage:         percentage,
+		RowsProcessed:      processed,
+		TotalRowsToProcess: total,
+	}
+}
+
+type queryProgressContextKey struct{}
+
+// WithQueryProgress returns a context reporting the progress of the queries executed with it,
+// e.g., of long-running COPY or CREATE TABLE AS statements.
+// The driver calls progress on the executing goroutine whenever the progress changes.
+// DuckDB only tracks the progress if the connection enables it, e.g., with SET enable_progress_bar = true.
+func WithQueryProgress(ctx context.Context, progress func(QueryProgress)) context.Context {
+	return context.WithValue(ctx, queryProgressContextKey{}, progress)
+}
+
+// executeTasksWithProgress executes the tasks of a pending result until it is ready, and reports the progress.
+// It is a no-op, if the context does not contain a progress callback.
+func executeTasksWithProgress(ctx context.Context, conn mapping.Connection, pending mapping.PendingResult) {
+	progress, ok := ctx.Value(queryProgressContextKey{}).(func(QueryProgress))
+	if !ok || progress == nil {
+		return
+	}
+	executeTasks(ctx, conn, pending, progress)
+}
+
+// executeTasks executes the tasks of a pending result on the calling goroutine,
+// until the result is ready, the execution fails, or the context is cancelled.
+// It calls progress whenever the query's progress changes, if progress is not nil.
+// It returns the state of the last executed task.
+func executeTasks(ctx context.Context, conn mapping.Connection, pending mapping.PendingResult, progress func(QueryProgress)) mapping.PendingState {
+	last := QueryProgress{Percentage: -1}
+	for {
+		state := mapping.PendingExecuteTask(pending)
+		if mapping.PendingExecutionIsFinished(state) || ctx.Err() != nil {
+			return state
+		}
+		if progress == nil {
+			continue
+		}
+		if current := getQueryProgress(conn); current != last {
+			progress(current)
+			last = current
+		}
+	}
+}
+
+// PendingQuery is a query whose execution has started, but not yet finished.
+// The caller drives its execution by calling Poll or Wait, which execute the query's tasks
+// on the calling goroutine. Once the query is ready, Rows or Result retrieve its result.
+// A connection can only execute one pending query at a time,
+// and the caller must not use the connection until closing the PendingQuery.
+//
+// To execute queries on an application-managed goroutine pool, open the database with threads=1,
+// so that DuckDB does not start any background threads, and call Poll from the pool's workers.
+// To execute the tasks of a query on multiple goroutines concurrently, use a TaskState.
+type PendingQuery struct {
+	stmt    *Stmt
+	pending mapping.PendingResult
+	ready   bool
+	closed  bool
+}
+
+// PendingQueryContext prepares the query, binds the arguments, and starts its execution.
+// Like QueryContext, it executes all statements but the last one of a multi-statement query immediately.
+// It is the caller's responsibility to close the PendingQuery.
+func (conn *Conn) PendingQueryContext(ctx context.Context, query string, args []driver.NamedValue) (*PendingQuery, error) {
+	stmt, err := conn.prepareStmts(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if err = stmt.bind(args); err != nil {
+		return nil, closeStmtOnError(stmt, err)
+	}
+
+	var pending mapping.PendingResult
+	if mapping.PendingPrepared(*stmt.preparedStmt, &pending) == mapping.StateError {
+		err = conn.getDuckDBError(mapping.PendingError(pending))
+		mapping.DestroyPending(&pending)
+		return nil, closeStmtOnError(stmt, err)
+	}
+
+	return &PendingQuery{stmt: stmt, pending: pending}, nil
+}
+
+// Poll executes a single task of the query.
+// It returns true, if the result is ready, and an error, if the execution failed.
+func (p *PendingQuery) Poll() (bool, error) {
+	if p.closed || p.stmt == nil {
+		return false, errClosedPendingQuery
+	}
+	if p.ready {
+		return true, nil
+	}
+
+	state := mapping.PendingExecuteTask(p.pending)
+	switch state {
+	case mapping.PendingStateError:
+		return false, p.stmt.conn.getDuckDBError(mapping.PendingError(p.pending))
+	case mapping.PendingStateResultReady:
+		p.ready = true
+	}
+	return p.ready, nil
+}
+
+// Wait executes the query's tasks until the result is ready.
+// If the context is cancelled, Wait interrupts the query and returns the context's error.
+func (p *PendingQuery) Wait(ctx context.Context) error {
+	return p.wait(ctx, nil)
+}
+
+// wait is like Wait, but calls progress whenever the query's progress changes, if progress is not nil.
+func (p *PendingQuery) wait(ctx context.Context, progress func(QueryProgress)) error {
+	if p.closed || p.stmt == nil {
+		return errClosedPendingQuery
+	}
+	if p.ready {
+		return nil
+	}
+
+	switch executeTasks(ctx, p.stmt.conn.conn, p.pending, progress) {
+	case mapping.PendingStateError:
+		return p.stmt.conn.getDuckDBError(mapping.PendingError(p.pending))
+	case mapping.PendingStateResultReady:
+		p.ready = true
+		return nil
+	}
+	mapping.Interrupt(p.stmt.conn.conn)
+	return ctx.Err()
+}
+
+// Progress returns the progress of the query.
+func (p *PendingQuery) Progress() QueryProgress {
+	if p.closed || p.stmt == nil {
+		return QueryProgress{Percentage: -1}
+	}
+	return getQueryProgress(p.stmt.conn.conn)
+}
+
+// Rows returns the rows of a finished query.
+// Rows finishes executing the query if it is not ready yet.
+// After a successful call, closing the rows also releases the query's resources.
+func (p *PendingQuery) Rows() (driver.Rows, error) {
+	res, err := p.result()
+	if err != nil {
+		return nil, err
+	}
+
+	p.stmt.rows = true
+	p.stmt.closeOnRowsClose = true
+	r := newRowsWithStmt(*res, p.stmt)
+	p.stmt = nil
+	return r, nil
+}
+
+// Result returns the result of a finished query that does not return rows.
+// Result finishes executing the query if it is not ready yet.
+func (p *PendingQuery) Result() (driver.Result, error) {
+	res, err := p.result()
+	if err != nil {
+		return nil, err
+	}
+	defer mapping.DestroyResult(res)
+
+	return newResult(getRowsAffected(res)), p.Close()
+}
+
+// Close releases the resources of the PendingQuery.
+// It interrupts the query if its execution has not finished.
+func (p *PendingQuery) Close() error {
+	if p.closed {
+		return nil
+	}
+	p.closed = true
+
+	if p.stmt == nil {
+		return nil
+	}
+	if !p.ready {
+		mapping.Interrupt(p.stmt.conn.conn)
+	}
+	if p.pending.Ptr != nil {
+		mapping.DestroyPending(&p.pending)
+	}
+	err := p.stmt.Close()
+	p.stmt = nil
+	return err
+}
+
+func (p *PendingQuery) result() (*mapping.Result, error) {
+	if p.closed || p.stmt == nil {
+		return nil, errClosedPendingQuery
+	}
+
+	var res mapping.Result
+	state := mapping.ExecutePending(p.pending, &res)
+	mapping.DestroyPending(&p.pending)
+	p.ready = true
+
+	if state == mapping.StateError {
+		err := p.stmt.conn.getDuckDBError(mapping.ResultError(&res))
+		mapping.DestroyResult(&res)
+		p.closed = true
+		err = closeStmtOnError(p.stmt, err)
+		p.stmt = nil
+		return nil, err
+	}
+	return &res, nil
+}
+
+func closeStmtOnError(stmt *Stmt, err error) error {
+	if errClose := stmt.Close(); errClose != nil {
+		return errors.Join(err, errClose)
+	}
+	return err
+}
@@ -0,0 +1,109 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPendingQuery(t *testing.T) {
+	c := newConnectorWrapper(t, ``, nil)
+	defer closeConnectorWrapper(t, c)
+	conn := openDriverConnWrapper(t, c)
+	defer closeDriverConnWrapper(t, &conn)
+	duckConn := conn.(*Conn)
+
+	t.Run("poll", func(t *testing.T) {
+		p, err := duckConn.PendingQueryContext(context.Background(), `SELECT sum(range) FROM range(?)`,
+			[]driver.NamedValue{{Ordinal: 1, Value: int64(1000000)}})
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, p.Close())
+		}()
+
+		for {
+			ready, errPoll := p.Poll()
+			require.NoError(t, errPoll)
+			if ready {
+				break
+			}
+			require.LessOrEqual(t, p.Progress().Percentage, float64(100))
+		}
+
+		r, err := p.Rows()
+		require.NoError(t, err)
+		values := make([]driver.Value, 1)
+		require.NoError(t, r.Next(values))
+		require.Equal(t, "499999500000", values[0].(interface{ String() string }).String())
+		require.NoError(t, r.Close())
+
+		_, err = p.Poll()
+		require.ErrorIs(t, err, errClosedPendingQuery)
+	})
+
+	t.Run("wait", func(t *testing.T) {
+		p, err := duckConn.PendingQueryContext(context.Background(), `CREATE TABLE pending AS SELECT range AS i FROM range(10)`, nil)
+		require.NoError(t, err)
+		require.NoError(t, p.Wait(context.Background()))
+
+		res, err := p.Result()
+		require.NoError(t, err)
+		ra, err := res.RowsAffected()
+		require.NoError(t, err)
+		require.Equal(t, int64(10), ra)
+		require.NoError(t, p.Close())
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		p, err := duckConn.PendingQueryContext(context.Background(), `SELECT count(*) FROM range(10000000000) t1`, nil)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		require.ErrorIs(t, p.Wait(ctx), context.Canceled)
+		require.NoError(t, p.Close())
+
+		// The connection is still usable.
+		_, err = duckConn.ExecContext(context.Background(), `SELECT 42`, nil)
+		require.NoError(t, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		p, err := duckConn.PendingQueryContext(context.Background(), `SELECT * FROM does_not_exist`, nil)
+		require.Error(t, err)
+		require.Nil(t, p)
+	})
+}
+
+func TestWithQueryProgress(t *testing.T) {
+	db := openDbWrapper(t, ``)
+	defer closeDbWrapper(t, db)
+	conn := openConnWrapper(t, db, context.Background())
+	defer closeConnWrapper(t, conn)
+
+	_, err := conn.ExecContext(context.Background(), `SET enable_progress_bar = true`)
+	require.NoError(t, err)
+
+	var reports []QueryProgress
+	ctx := WithQueryProgress(context.Background(), func(progress QueryProgress) {
+		reports = append(reports, progress)
+	})
+	res, err := conn.ExecContext(ctx, `CREATE TABLE progress AS SELECT range AS i FROM range(10000000)`)
+	require.NoError(t, err)
+	ra, err := res.RowsAffected()
+	require.NoError(t, err)
+	require.Equal(t, int64(10000000), ra)
+
+	require.NotEmpty(t, reports)
+	for i := 1; i < len(reports); i++ {
+		require.GreaterOrEqual(t, reports[i].RowsProcessed, reports[i-1].RowsProcessed)
+	}
+
+	// The callback is not called without the context.
+	reports = nil
+	_, err = conn.ExecContext(context.Background(), `CREATE TABLE other AS SELECT range AS i FROM range(1000000)`)
+	require.NoError(t, err)
+	require.Empty(t, reports)
+}
@@ -0,0 +1,29 @@
+package duckdb
+
+import "strings"
+
+// QuoteIdentifier quotes an identifier, such as a table or column name, for use in a SQL query.
+// DuckDB escapes identifiers by doubling double quotes, then wrapping in double quotes.
+func QuoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// QuoteLiteral quotes a string literal for use in a SQL query.
+// DuckDB escapes string literals by doubling single quotes, then wrapping in single quotes.
+// Backslashes have no special meaning.
+func QuoteLiteral(s string) string {
+	return `'` + strings.ReplaceAll(s, `'`, `''`) + `'`
+}
+
+// QuoteQualified quotes a qualified name, e.g., catalog.schema.table, for use in a SQL query.
+// It omits an empty catalog or schema.
+func QuoteQualified(catalog, schema, name string) string {
+	quoted := QuoteIdentifier(name)
+	if schema != "" {
+		quoted = QuoteIdentifier(schema) + "." + quoted
+	}
+	if catalog != "" {
+		quoted = QuoteIdentifier(catalog) + "." + quoted
+	}
+	return quoted
+}
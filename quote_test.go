@@ -0,0 +1,36 @@
+package duckdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuote(t *testing.T) {
+	require.Equal(t, `"foo"`, QuoteIdentifier(`foo`))
+	require.Equal(t, `"fo""o"`, QuoteIdentifier(`fo"o`))
+	require.Equal(t, `'it''s'`, QuoteLiteral(`it's`))
+	require.Equal(t, `'a\b'`, QuoteLiteral(`a\b`))
+	require.Equal(t, `"t"`, QuoteQualified("", "", "t"))
+	require.Equal(t, `"s"."t"`, QuoteQualified("", "s", "t"))
+	require.Equal(t, `"memory"."s"."t"`, QuoteQualified("memory", "s", "t"))
+
+	db := openDbWrapper(t, ``)
+	defer closeDbWrapper(t, db)
+
+	names := []string{`my "table"`, `ü 🦆 ' x`, `select`, `a.b`}
+	for _, name := range names {
+		table := QuoteQualified("memory", "main", name)
+		_, err := db.Exec(`CREATE TABLE ` + table + ` (` + QuoteIdentifier(name) + ` VARCHAR)`)
+		require.NoError(t, err)
+		_, err = db.Exec(`INSERT INTO ` + table + ` VALUES (` + QuoteLiteral(name) + `)`)
+		require.NoError(t, err)
+
+		var tableName, value string
+		row := db.QueryRow(`SELECT table_name FROM duckdb_tables() WHERE table_name = ` + QuoteLiteral(name))
+		require.NoError(t, row.Scan(&tableName))
+		require.Equal(t, name, tableName)
+		require.NoError(t, db.QueryRow(`SELECT `+QuoteIdentifier(name)+` FROM `+table).Scan(&value))
+		require.Equal(t, name, value)
+	}
+}
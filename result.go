@@ -1,13 +1,61 @@
 package duckdb
 
-type result struct {
-	rowsAffected int64
+import (
+	"github.com/marcboeker/go-duckdb/mapping"
+)
+
+// Result is the driver.Result of a query that doesn't return rows, such as an INSERT or UPDATE.
+// database/sql hides the driver.Result, so the driver connection's ExecContext must be called,
+// e.g., via sql.Conn.Raw, to access the statement counts.
+type Result struct {
+	// rowsAffected contains the number of affected rows of each executed statement.
+	rowsAffected []int64
+}
+
+func newResult(rowsAffected ...int64) *Result {
+	return &Result{rowsAffected: rowsAffected}
 }
 
-func (r result) LastInsertId() (int64, error) {
+// LastInsertId is not supported.
+func (r *Result) LastInsertId() (int64, error) {
 	return 0, nil
 }
 
-func (r result) RowsAffected() (int64, error) {
-	return r.rowsAffected, nil
+// RowsAffected returns the total number of rows affected by all statements of the query.
+func (r *Result) RowsAffected() (int64, error) {
+	var total int64
+	for _, ra := range r.rowsAffected {
+		total += ra
+	}
+	return total, nil
+}
+
+// StatementRowsAffected returns the number of rows affected by each statement of the query,
+// in the order of their execution.
+func (r *Result) StatementRowsAffected() []int64 {
+	return r.rowsAffected
+}
+
+// getRowsAffected returns the number of rows affected by the statement of a result.
+// For INSERT, UPDATE, and DELETE statements with a RETURNING clause, it counts the returned rows.
+func getRowsAffected(res *mapping.Result) int64 {
+	if mapping.ResultReturnType(*res) != mapping.ResultTypeQueryResult {
+		// The result contains the count, if any.
+		return mapping.ValueInt64(res, 0, 0)
+	}
+
+	switch StmtType(mapping.ResultStatementType(*res)) {
+	case STATEMENT_TYPE_INSERT, STATEMENT_TYPE_UPDATE, STATEMENT_TYPE_DELETE:
+	default:
+		return 0
+	}
+
+	var count int64
+	chunkCount := mapping.ResultChunkCount(*res)
+	for i := mapping.IdxT(0); i < chunkCount; i++ {
+		chunk := mapping.ResultGetChunk(*res, i)
+		count += int64(mapping.DataChunkGetSize(chunk))
+		mapping.DestroyDataChunk(&chunk)
+	}
+	return count
 }
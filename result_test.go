@@ -0,0 +1,35 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResultRowsAffected(t *testing.T) {
+	c := newConnectorWrapper(t, ``, nil)
+	defer closeConnectorWrapper(t, c)
+	conn := openDriverConnWrapper(t, c)
+	defer closeDriverConnWrapper(t, &conn)
+	duckConn := conn.(*Conn)
+
+	res, err := duckConn.ExecContext(context.Background(), `CREATE TABLE foo (i INTEGER);
+		INSERT INTO foo SELECT range FROM range(10);
+		UPDATE foo SET i = i + 1 WHERE i < 5;
+		SELECT * FROM foo;
+		DELETE FROM foo WHERE i > ? RETURNING i`, []driver.NamedValue{{Ordinal: 1, Value: 7}})
+	require.NoError(t, err)
+
+	ra, err := res.RowsAffected()
+	require.NoError(t, err)
+	require.Equal(t, int64(17), ra)
+	require.Equal(t, []int64{0, 10, 5, 0, 2}, res.(*Result).StatementRowsAffected())
+
+	res, err = duckConn.ExecContext(context.Background(), `INSERT INTO foo VALUES (1), (2) RETURNING i`, nil)
+	require.NoError(t, err)
+	ra, err = res.RowsAffected()
+	require.NoError(t, err)
+	require.Equal(t, int64(2), ra)
+}
@@ -1,17 +1,30 @@
 package duckdb
 
 import (
+	"bytes"
+	"context"
 	"database/sql/driver"
 	"fmt"
 	"io"
+	"math"
 	"math/big"
 	"reflect"
-	"strings"
 	"time"
 
 	"github.com/marcboeker/go-duckdb/mapping"
 )
 
+// Rows is implemented by the driver.Rows of the queries of a Conn.
+// To read a result chunk by chunk instead of row by row, query the driver connection, e.g., within sql.Conn.Raw,
+// and assert that its driver.Rows implement Rows.
+type Rows interface {
+	driver.Rows
+	// NextChunk returns the next data chunk of the result, or io.EOF, if there are no more chunks.
+	// It skips the rows of the current chunk that Next did not return yet.
+	// The returned chunk is read-only, and only valid until the next call to Next, NextChunk, or Close.
+	NextChunk() (*DataChunk, error)
+}
+
 // rows is a helper struct for scanning a duckdb result.
 type rows struct {
 	// stmt is a pointer to the stmt of which we are scanning the result.
@@ -22,23 +35,58 @@ type rows struct {
 	chunk DataChunk
 	// closeChunk is true after the first iteration of Next.
 	closeChunk bool
+	// streaming is true, if res is a streaming result, whose chunks we fetch one by one.
+	streaming bool
+	// ctx is the context of the query, which interrupts fetching the chunks of a streaming result.
+	ctx context.Context
 	// chunkCount is the number of chunks in the result.
 	chunkCount mapping.IdxT
 	// chunkIdx is the chunk index in the result.
 	chunkIdx mapping.IdxT
 	// rowCount is the number of scanned rows.
 	rowCount int
+	// blobBuffers holds a reusable buffer for each BLOB and UUID column, see getScanValue.
+	blobBuffers [][]byte
+	// timeLocation is the location of the time.Time values of timestamps, if not nil.
+	timeLocation *time.Location
+	// scanErr is the error of the last value, e.g., of a non-finite float with NonFiniteFloatsError.
+	scanErr error
+}
+
+type timeLocationContextKey struct{}
+
+// WithQueryTimeLocation returns a context scanning the TIMESTAMP and TIMESTAMP WITH TIME ZONE values
+// of the queries executed with it into time.Time values in the location.
+// It overrides the location set with WithTimeLocation.
+func WithQueryTimeLocation(ctx context.Context, loc *time.Location) context.Context {
+	return context.WithValue(ctx, timeLocationContextKey{}, loc)
+}
+
+// newRowsWithContext returns the rows of a result, with the time location of the context, if any.
+// The result must be a streaming result, if the context streams results.
+func newRowsWithContext(ctx context.Context, res mapping.Result, stmt *Stmt) *rows {
+	r := newRowsWithStmt(res, stmt)
+	r.streaming = streamingResults(ctx)
+	r.ctx = ctx
+	if loc, ok := ctx.Value(timeLocationContextKey{}).(*time.Location); ok {
+		r.timeLocation = loc
+	}
+	return r
 }
 
 func newRowsWithStmt(res mapping.Result, stmt *Stmt) *rows {
 	columnCount := mapping.ColumnCount(&res)
 	r := rows{
-		res:        res,
-		stmt:       stmt,
-		chunk:      DataChunk{},
-		chunkCount: mapping.ResultChunkCount(res),
-		chunkIdx:   0,
-		rowCount:   0,
+		res:         res,
+		stmt:        stmt,
+		chunk:       DataChunk{},
+		chunkCount:  mapping.ResultChunkCount(res),
+		chunkIdx:    0,
+		rowCount:    0,
+		blobBuffers: make([][]byte, columnCount),
+	}
+	if stmt != nil && stmt.conn != nil {
+		r.timeLocation = stmt.conn.timeLocation
 	}
 
 	for i := mapping.IdxT(0); i < columnCount; i++ {
@@ -54,51 +102,221 @@ func (r *rows) Columns() []string {
 }
 
 func (r *rows) Next(dst []driver.Value) error {
+	if err := r.nextChunk(); err != nil {
+		return err
+	}
+
+	columnCount := len(r.chunk.columns)
+	for colIdx := 0; colIdx < columnCount; colIdx++ {
+		var err error
+		if dst[colIdx], err = r.getValue(colIdx, r.rowCount); err != nil {
+			return err
+		}
+	}
+	r.rowCount++
+
+	return nil
+}
+
+// nextChunk fetches the next data chunk, if all rows of the current chunk have been scanned.
+// NextChunk implements Rows.
+func (r *rows) NextChunk() (*DataChunk, error) {
+	if r.stmt == nil {
+		return nil, errClosedStmt
+	}
+	r.rowCount = r.chunk.size
+	if err := r.nextChunk(); err != nil {
+		return nil, err
+	}
+	r.rowCount = r.chunk.size
+	return &r.chunk, nil
+}
+
+func (r *rows) nextChunk() error {
 	for r.rowCount == r.chunk.size {
 		if r.closeChunk {
 			r.chunk.close()
 			r.closeChunk = false
 		}
-		if r.chunkIdx == r.chunkCount {
-			return io.EOF
+		var chunk mapping.DataChunk
+		if r.streaming {
+			var err error
+			if chunk, err = r.fetchChunk(); err != nil {
+				return err
+			}
+			if chunk.Ptr == nil {
+				return io.EOF
+			}
+		} else {
+			if r.chunkIdx == r.chunkCount {
+				return io.EOF
+			}
+			chunk = mapping.ResultGetChunk(r.res, r.chunkIdx)
 		}
-		chunk := mapping.ResultGetChunk(r.res, r.chunkIdx)
 		r.closeChunk = true
 		if err := r.chunk.initFromDuckDataChunk(chunk, false); err != nil {
 			return getError(err, nil)
 		}
+		if r.stmt.conn.orderedMaps {
+			for i := range r.chunk.columns {
+				r.chunk.columns[i].orderMaps()
+			}
+		}
+		if policy := r.stmt.conn.nonFiniteFloats; policy != NonFiniteFloatsPassThrough {
+			for i := range r.chunk.columns {
+				r.chunk.columns[i].handleNonFiniteFloats(policy, func(err error) {
+					r.scanErr = err
+				})
+			}
+		}
+		if r.stmt.conn.timeTZOffsets {
+			for i := range r.chunk.columns {
+				r.chunk.columns[i].keepTimeTZOffsets()
+			}
+		}
+		if r.timeLocation != nil {
+			for i := range r.chunk.columns {
+				r.chunk.columns[i].inLocation(r.timeLocation)
+			}
+		}
 
 		r.chunkIdx++
 		r.rowCount = 0
 	}
+	return nil
+}
 
-	columnCount := len(r.chunk.columns)
-	for colIdx := 0; colIdx < columnCount; colIdx++ {
-		var err error
-		if dst[colIdx], err = r.chunk.GetValue(colIdx, r.rowCount); err != nil {
-			return err
-		}
+// fetchChunk fetches the next chunk of a streaming result.
+// It interrupts the query, if the context is cancelled while DuckDB produces the chunk.
+// It returns a chunk with a nil pointer, if the result is exhausted.
+func (r *rows) fetchChunk() (mapping.DataChunk, error) {
+	if err := r.ctx.Err(); err != nil {
+		return mapping.DataChunk{}, err
 	}
-	r.rowCount++
 
-	return nil
+	var chunk mapping.DataChunk
+	if r.ctx.Done() == nil {
+		chunk = fetchChunk(&r.res)
+	} else {
+		fetchDoneCh := make(chan struct{})
+		bgDoneCh := make(chan struct{})
+		go func() {
+			select {
+			case <-r.ctx.Done():
+				mapping.Interrupt(r.stmt.conn.conn)
+			case <-fetchDoneCh:
+			}
+			close(bgDoneCh)
+		}()
+		chunk = fetchChunk(&r.res)
+		close(fetchDoneCh)
+		// Wait for the background goroutine, so that it cannot interrupt a later query.
+		<-bgDoneCh
+	}
+
+	if chunk.Ptr != nil {
+		return chunk, nil
+	}
+	if err := r.ctx.Err(); err != nil {
+		return chunk, err
+	}
+	if msg := mapping.ResultError(&r.res); msg != "" {
+		return chunk, r.stmt.conn.getDuckDBError(msg)
+	}
+	return chunk, nil
+}
+
+// getValue returns the value of a column in a row. The caller owns the returned BLOB and UUID bytes.
+func (r *rows) getValue(colIdx int, rowIdx int) (driver.Value, error) {
+	return r.getValueBuffered(colIdx, rowIdx, false)
+}
+
+// getScanValue is like getValue, but copies BLOB and UUID values into the column's buffer,
+// which the next row reuses. Only ScanColumn uses it, as sql.ConvertAssign copies []byte values
+// into all destinations but sql.RawBytes, which may reference the buffer until the next row,
+// and sql.Scanner, which must copy them.
+func (r *rows) getScanValue(colIdx int, rowIdx int) (driver.Value, error) {
+	return r.getValueBuffered(colIdx, rowIdx, true)
+}
+
+func (r *rows) getValueBuffered(colIdx int, rowIdx int, buffered bool) (driver.Value, error) {
+	if r.chunk.columns[colIdx].Type == TYPE_BLOB && !r.chunk.columns[colIdx].isGeometry {
+		return r.getBlob(colIdx, rowIdx, buffered), nil
+	}
+	if r.chunk.columns[colIdx].Type == TYPE_UUID {
+		return r.getUUID(colIdx, rowIdx, buffered), nil
+	}
+	val, err := r.chunk.GetValue(colIdx, rowIdx)
+	if r.scanErr != nil {
+		err, r.scanErr = r.scanErr, nil
+	}
+	return val, err
+}
+
+// getBlob returns the bytes of a BLOB value. If buffered is true, it copies them into the column's buffer.
+func (r *rows) getBlob(colIdx int, rowIdx int, buffered bool) driver.Value {
+	vec := &r.chunk.columns[colIdx]
+	if vec.getNull(mapping.IdxT(rowIdx)) {
+		return nil
+	}
+
+	data := vec.getRawBytes(mapping.IdxT(rowIdx))
+	if !buffered {
+		return bytes.Clone(data)
+	}
+	r.blobBuffers[colIdx] = append(r.blobBuffers[colIdx][:0], data...)
+	return r.blobBuffers[colIdx]
+}
+
+// getUUID returns the 16 bytes of a UUID value. If buffered is true, it writes them into the column's buffer.
+// This lets scanners such as uuid.UUID copy the bytes without an allocation or a string round trip.
+func (r *rows) getUUID(colIdx int, rowIdx int, buffered bool) driver.Value {
+	vec := &r.chunk.columns[colIdx]
+	if vec.getNull(mapping.IdxT(rowIdx)) {
+		return nil
+	}
+
+	var b []byte
+	if buffered {
+		if cap(r.blobBuffers[colIdx]) < uuidLength {
+			r.blobBuffers[colIdx] = make([]byte, uuidLength)
+		}
+		r.blobBuffers[colIdx] = r.blobBuffers[colIdx][:uuidLength]
+		b = r.blobBuffers[colIdx]
+	} else {
+		b = make([]byte, uuidLength)
+	}
+	hugeInt := getPrimitive[mapping.HugeInt](vec, mapping.IdxT(rowIdx))
+	putUUID(b, &hugeInt)
+	return b
 }
 
 // ColumnTypeScanType implements driver.RowsColumnTypeScanType.
 func (r *rows) ColumnTypeScanType(index int) reflect.Type {
 	logicalType := mapping.ColumnLogicalType(&r.res, mapping.IdxT(index))
 	defer mapping.DestroyLogicalType(&logicalType)
+	return scanType(logicalType)
+}
 
+// scanType returns the Go type of the values of a column type.
+func scanType(logicalType mapping.LogicalType) reflect.Type {
 	alias := mapping.LogicalTypeGetAlias(logicalType)
 	switch alias {
 	case aliasJSON:
 		return reflect.TypeOf((*any)(nil)).Elem()
+	case aliasGeometry:
+		return reflectTypeGeometry
+	case aliasINET:
+		return reflectTypePrefix
 	}
 
-	t := Type(mapping.ColumnType(&r.res, mapping.IdxT(index)))
+	t := Type(mapping.GetTypeId(logicalType))
 	switch t {
 	case TYPE_INVALID:
 		return nil
+	case TYPE_SQLNULL:
+		// All values of an untyped NULL column are NULL.
+		return reflect.TypeOf((*any)(nil)).Elem()
 	case TYPE_BOOLEAN:
 		return reflect.TypeOf(true)
 	case TYPE_TINYINT:
@@ -139,6 +357,8 @@ func (r *rows) ColumnTypeScanType(index int) reflect.Type {
 		return reflect.TypeOf(map[string]any{})
 	case TYPE_MAP:
 		return reflect.TypeOf(Map{})
+	case TYPE_UNION:
+		return reflect.TypeOf(Union[any]{})
 	case TYPE_ARRAY:
 		return reflect.TypeOf([]any{})
 	case TYPE_UUID:
@@ -148,26 +368,58 @@ func (r *rows) ColumnTypeScanType(index int) reflect.Type {
 	}
 }
 
-// ColumnTypeDatabaseTypeName implements driver.RowsColumnTypeScanType.
+// ColumnTypeDatabaseTypeName implements driver.RowsColumnTypeDatabaseTypeName.
 func (r *rows) ColumnTypeDatabaseTypeName(index int) string {
 	logicalType := mapping.ColumnLogicalType(&r.res, mapping.IdxT(index))
 	defer mapping.DestroyLogicalType(&logicalType)
+	return databaseTypeName(logicalType)
+}
 
+// databaseTypeName returns the name of a column type.
+func databaseTypeName(logicalType mapping.LogicalType) string {
 	alias := mapping.LogicalTypeGetAlias(logicalType)
 	switch alias {
-	case aliasJSON:
-		return aliasJSON
+	case aliasJSON, aliasGeometry, aliasINET:
+		return alias
 	}
 
-	t := Type(mapping.ColumnType(&r.res, mapping.IdxT(index)))
+	t := Type(mapping.GetTypeId(logicalType))
 	switch t {
-	case TYPE_DECIMAL, TYPE_ENUM, TYPE_LIST, TYPE_STRUCT, TYPE_MAP, TYPE_ARRAY:
+	case TYPE_DECIMAL, TYPE_ENUM, TYPE_LIST, TYPE_STRUCT, TYPE_MAP, TYPE_ARRAY, TYPE_SQLNULL:
 		return logicalTypeName(logicalType)
 	default:
 		return typeToStringMap[t]
 	}
 }
 
+// ColumnTypeNullable implements driver.RowsColumnTypeNullable.
+// DuckDB's query results do not report the nullability of their columns, so it is unknown.
+func (r *rows) ColumnTypeNullable(int) (nullable, ok bool) {
+	return false, false
+}
+
+// ColumnTypeLength implements driver.RowsColumnTypeLength.
+// VARCHAR and BLOB values have no maximum length, so their length is math.MaxInt64.
+func (r *rows) ColumnTypeLength(index int) (length int64, ok bool) {
+	t := Type(mapping.ColumnType(&r.res, mapping.IdxT(index)))
+	switch t {
+	case TYPE_VARCHAR, TYPE_BLOB:
+		return math.MaxInt64, true
+	default:
+		return 0, false
+	}
+}
+
+// ColumnTypePrecisionScale implements driver.RowsColumnTypePrecisionScale.
+func (r *rows) ColumnTypePrecisionScale(index int) (precision, scale int64, ok bool) {
+	logicalType := mapping.ColumnLogicalType(&r.res, mapping.IdxT(index))
+	defer mapping.DestroyLogicalType(&logicalType)
+	if Type(mapping.GetTypeId(logicalType)) != TYPE_DECIMAL {
+		return 0, 0, false
+	}
+	return int64(mapping.DecimalWidth(logicalType)), int64(mapping.DecimalScale(logicalType)), true
+}
+
 func (r *rows) Close() error {
 	if r.closeChunk {
 		r.chunk.close()
@@ -202,6 +454,9 @@ func logicalTypeName(logicalType mapping.LogicalType) string {
 		return logicalTypeNameMap(logicalType)
 	case TYPE_ARRAY:
 		return logicalTypeNameArray(logicalType)
+	case TYPE_SQLNULL:
+		// DuckDB names the type of untyped NULL values NULL.
+		return "NULL"
 	default:
 		return typeToStringMap[t]
 	}
@@ -229,7 +484,7 @@ func logicalTypeNameStruct(logicalType mapping.LogicalType) string {
 		childType := mapping.StructTypeChildType(logicalType, i)
 
 		// Add comma if not at the end of the list.
-		name += escapeStructFieldName(childName) + " " + logicalTypeName(childType)
+		name += QuoteIdentifier(childName) + " " + logicalTypeName(childType)
 		if i != count-1 {
 			name += ", "
 		}
@@ -257,8 +512,3 @@ func logicalTypeNameArray(logicalType mapping.LogicalType) string {
 
 	return fmt.Sprintf("%s[%d]", childName, int(size))
 }
-
-func escapeStructFieldName(s string) string {
-	// DuckDB escapes STRUCT field names by doubling double quotes, then wrapping in double quotes.
-	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
-}
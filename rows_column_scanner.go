@@ -0,0 +1,383 @@
+//go:build go1.27
+
+package duckdb
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/netip"
+	"reflect"
+	"time"
+	"unsafe"
+
+	"github.com/marcboeker/go-duckdb/mapping"
+)
+
+// NextRow implements driver.RowsColumnScanner.
+func (r *rows) NextRow() error {
+	if err := r.nextChunk(); err != nil {
+		return err
+	}
+	r.rowCount++
+	return nil
+}
+
+// ScanColumn implements driver.RowsColumnScanner, which database/sql only uses with Go 1.27 or later.
+// Earlier Go versions only scan into the destinations that database/sql supports.
+// Besides those, it scans:
+//   - Values into pointers to types registered with RegisterTypeHandler, with their handlers.
+//   - Values into Composite, StrictComposite, TypedMap, Union, and Null with the options
+//     of WithCompositeFieldNaming and WithCompositeDecodeHooks.
+//   - With WithTextCasts, non-NULL values into *string with the text of DuckDB's VARCHAR cast.
+//   - VARCHAR, JSON, and BLOB values into *sql.RawBytes without copying them.
+//     The bytes are valid until the next call to Next.
+//   - JSON values as raw text into json.RawMessage and []byte.
+//   - VARCHAR, JSON, ENUM, and UUID values into an encoding.TextUnmarshaler, and BLOB values
+//     into an encoding.BinaryUnmarshaler, if the destination is not a sql.Scanner.
+//   - BLOB values into byte arrays, e.g., *[32]byte, if their lengths match.
+//   - TIME and TIME WITH TIME ZONE values into *time.Duration as the duration since midnight.
+//   - HUGEINT values into integer and float destinations, if they fit.
+//     Otherwise, it returns an ErrorTypeOutOfRange error.
+//   - DECIMAL values into float and string destinations with Decimal.Float64 and Decimal.String.
+//   - STRUCT values into Go structs, and LIST and ARRAY values into Go slices and arrays,
+//     e.g., *[3][4]float32 for a FLOAT[4][3] column. It copies numeric and BOOLEAN elements directly.
+//   - GEOMETRY values of the spatial extension into *[]byte as WKB, and into *string as WKT.
+//
+// Strings that are not members of the ENUM type registered for the destination's type fail to scan.
+func (r *rows) ScanColumn(scanCtx driver.ScanContext, index int, dest any) error {
+	rowIdx := r.rowCount - 1
+	if rv := reflect.ValueOf(dest); rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		if scan, ok := handlerScan(rv.Type().Elem()); ok {
+			val, err := r.getValue(index, rowIdx)
+			if err != nil {
+				return err
+			}
+			return scanWithHandler(scan, val, dest)
+		}
+	}
+	if s, ok := dest.(compositeScanner); ok {
+		val, err := r.getValue(index, rowIdx)
+		if err != nil {
+			return err
+		}
+		return s.scanComposite(val, r.stmt.conn.compositeOptions)
+	}
+	if d, ok := dest.(*sql.RawBytes); ok {
+		if vec := &r.chunk.columns[index]; (vec.Type == TYPE_VARCHAR || vec.Type == TYPE_BLOB) && !vec.isGeometry {
+			if vec.getNull(mapping.IdxT(rowIdx)) {
+				*d = nil
+			} else {
+				*d = vec.getRawBytes(mapping.IdxT(rowIdx))
+			}
+			return nil
+		}
+	}
+	if d, ok := dest.(*string); ok && r.stmt.conn.textCasts && !r.chunk.columns[index].getNull(mapping.IdxT(rowIdx)) {
+		*d = r.chunk.columns[index].getCastText(mapping.IdxT(rowIdx))
+		return nil
+	}
+	if r.chunk.columns[index].isJSON {
+		switch d := dest.(type) {
+		case *json.RawMessage:
+			*d = r.getRawJSON(index, rowIdx)
+			return nil
+		case *[]byte:
+			*d = r.getRawJSON(index, rowIdx)
+			return nil
+		}
+	}
+	if _, ok := dest.(sql.Scanner); !ok && !r.chunk.columns[index].getNull(mapping.IdxT(rowIdx)) {
+		if u, ok := dest.(encoding.TextUnmarshaler); ok {
+			if text, isText := r.getText(index, rowIdx); isText {
+				return u.UnmarshalText(text)
+			}
+		}
+		if u, ok := dest.(encoding.BinaryUnmarshaler); ok && r.chunk.columns[index].Type == TYPE_BLOB &&
+			!r.chunk.columns[index].isGeometry {
+			return u.UnmarshalBinary(r.getBlob(index, rowIdx, true).([]byte))
+		}
+		if scanned, err := r.scanFixedBytes(index, rowIdx, dest); scanned {
+			return err
+		}
+		if d, ok := dest.(*time.Duration); ok {
+			if t := r.chunk.columns[index].Type; t == TYPE_TIME || t == TYPE_TIME_TZ {
+				*d = r.chunk.columns[index].getTimeOfDay(mapping.IdxT(rowIdx))
+				return nil
+			}
+		}
+	}
+
+	// Copying the elements directly would skip the NonFiniteFloats policy.
+	if r.stmt.conn.nonFiniteFloats == NonFiniteFloatsPassThrough && r.scanPrimitiveElements(index, rowIdx, dest) {
+		return nil
+	}
+
+	val, err := r.getScanValue(index, rowIdx)
+	if err != nil {
+		return err
+	}
+	if str, ok := val.(string); ok {
+		if err = validateEnumDest(str, dest); err != nil {
+			return err
+		}
+	}
+	if geometry, ok := val.(Geometry); ok {
+		switch d := dest.(type) {
+		case *[]byte:
+			*d = geometry.WKB()
+			return nil
+		case *string:
+			*d = geometry.WKT()
+			return nil
+		}
+	}
+	if prefix, ok := val.(netip.Prefix); ok {
+		switch d := dest.(type) {
+		case *netip.Addr:
+			if !prefix.IsSingleIP() {
+				return castError("INET "+prefix.String(), "netip.Addr")
+			}
+			*d = prefix.Addr()
+			return nil
+		case *string:
+			*d = inetString(prefix)
+			return nil
+		}
+	}
+	if d, ok := val.(Decimal); ok {
+		if _, isScanner := dest.(sql.Scanner); !isScanner && scanDecimal(d, dest) {
+			return nil
+		}
+	}
+	if hugeInt, ok := val.(*big.Int); ok {
+		if _, isScanner := dest.(sql.Scanner); !isScanner {
+			if scanned, errScan := scanHugeInt(hugeInt, dest); scanned {
+				return errScan
+			}
+		}
+	}
+	if isCompositeDest(val, dest) {
+		return decodeComposite(val, dest, false, r.stmt.conn.compositeOptions)
+	}
+	return sql.ConvertAssign(scanCtx, dest, val)
+}
+
+// scanHugeInt scans a HUGEINT value into a pointer to an integer or a float.
+// It returns false, if dest is not such a pointer.
+func scanHugeInt(val *big.Int, dest any) (bool, error) {
+	rv := reflect.ValueOf(dest)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return false, nil
+	}
+
+	elem := rv.Elem()
+	switch elem.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if !val.IsInt64() || elem.OverflowInt(val.Int64()) {
+			return true, hugeIntRangeError(val, elem.Type())
+		}
+		elem.SetInt(val.Int64())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if !val.IsUint64() || elem.OverflowUint(val.Uint64()) {
+			return true, hugeIntRangeError(val, elem.Type())
+		}
+		elem.SetUint(val.Uint64())
+	case reflect.Float32, reflect.Float64:
+		f, _ := new(big.Float).SetInt(val).Float64()
+		if elem.OverflowFloat(f) {
+			return true, hugeIntRangeError(val, elem.Type())
+		}
+		elem.SetFloat(f)
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+// scanDecimal scans a DECIMAL value into a pointer to a float or a string with Decimal.Float64 and Decimal.String.
+// It returns false, if dest is not such a pointer.
+func scanDecimal(val Decimal, dest any) bool {
+	rv := reflect.ValueOf(dest)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return false
+	}
+
+	elem := rv.Elem()
+	switch elem.Kind() {
+	case reflect.Float32, reflect.Float64:
+		elem.SetFloat(val.Float64())
+	case reflect.String:
+		elem.SetString(val.String())
+	default:
+		return false
+	}
+	return true
+}
+
+func hugeIntRangeError(val *big.Int, t reflect.Type) error {
+	return &Error{
+		Type: ErrorTypeOutOfRange,
+		Msg:  fmt.Sprintf("Out of Range Error: value %s is out of range for %s", val.String(), t.String()),
+	}
+}
+
+// validateEnumDest returns an error, if dest points to a type registered with RegisterEnum,
+// and the value is not a member of its ENUM type.
+func validateEnumDest(val string, dest any) error {
+	t := reflect.TypeOf(dest)
+	if t == nil || t.Kind() != reflect.Pointer {
+		return nil
+	}
+	if e, ok := enumTypes.Load(t.Elem()); ok {
+		return e.(enumType).validate(val)
+	}
+	return nil
+}
+
+// scanPrimitiveElements copies a LIST or ARRAY value into dest, if dest points to a Go slice,
+// or to a Go array of the value's length, whose element kind matches the value's child type.
+// It returns false, if it did not scan the value, e.g., because the value or one of its elements is NULL.
+func (r *rows) scanPrimitiveElements(colIdx int, rowIdx int, dest any) bool {
+	vec := &r.chunk.columns[colIdx]
+	if vec.Type != TYPE_LIST && vec.Type != TYPE_ARRAY {
+		return false
+	}
+	t := reflect.TypeOf(dest)
+	if t == nil || t.Kind() != reflect.Pointer {
+		return false
+	}
+	if _, ok := dest.(sql.Scanner); ok {
+		return false
+	}
+	rv := reflect.ValueOf(dest)
+	if rv.IsNil() {
+		return false
+	}
+
+	destType := t.Elem()
+	if vec.getNull(mapping.IdxT(rowIdx)) {
+		return false
+	}
+
+	var offset, length mapping.IdxT
+	if vec.Type == TYPE_LIST {
+		entry := getPrimitive[mapping.ListEntry](vec, mapping.IdxT(rowIdx))
+		o, l := mapping.ListEntryMembers(&entry)
+		offset, length = mapping.IdxT(o), mapping.IdxT(l)
+	} else {
+		length = mapping.IdxT(vec.arrayLength)
+		offset = mapping.IdxT(rowIdx) * length
+	}
+
+	switch destType.Kind() {
+	case reflect.Slice:
+	case reflect.Array:
+		if destType.Len() != int(length) {
+			return false
+		}
+	default:
+		return false
+	}
+	child := &vec.childVectors[0]
+	kind, ok := primitiveElementKinds[child.Type]
+	if !ok || destType.Elem().Kind() != kind {
+		return false
+	}
+	for i := mapping.IdxT(0); i < length; i++ {
+		if child.getNull(offset + i) {
+			return false
+		}
+	}
+
+	dst := rv.UnsafePointer()
+	if destType.Kind() == reflect.Slice {
+		slice := reflect.MakeSlice(destType, int(length), int(length))
+		rv.Elem().Set(slice)
+		dst = slice.UnsafePointer()
+	}
+	size := int(length) * int(destType.Elem().Size())
+	if size == 0 {
+		return true
+	}
+	src := unsafe.Add(child.dataPtr, int(offset)*int(destType.Elem().Size()))
+	copy(unsafe.Slice((*byte)(dst), size), unsafe.Slice((*byte)(src), size))
+	return true
+}
+
+// scanFixedBytes copies a non-NULL BLOB value into a pointer to a byte array.
+// It returns false if the column or the destination do not match, and an error if the lengths differ.
+func (r *rows) scanFixedBytes(colIdx int, rowIdx int, dest any) (bool, error) {
+	vec := &r.chunk.columns[colIdx]
+	if vec.Type != TYPE_BLOB || vec.isGeometry {
+		return false, nil
+	}
+	rv := reflect.ValueOf(dest)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return false, nil
+	}
+	arr := rv.Elem()
+	if arr.Kind() != reflect.Array || arr.Type().Elem().Kind() != reflect.Uint8 {
+		return false, nil
+	}
+
+	strT := getPrimitive[mapping.StringT](vec, mapping.IdxT(rowIdx))
+	data := mapping.StringTData(&strT)
+	if len(data) != arr.Len() {
+		return true, invalidInputError(fmt.Sprintf("%d bytes", len(data)), fmt.Sprintf("%d bytes for %s", arr.Len(), arr.Type()))
+	}
+	reflect.Copy(arr, reflect.ValueOf(data))
+	return true, nil
+}
+
+// isCompositeDest returns true, if the LIST, ARRAY, or STRUCT value decodes into dest like Composite,
+// i.e., if dest is a pointer to a Go slice, array, or struct, or a pointer to such a pointer.
+func isCompositeDest(val any, dest any) bool {
+	switch val.(type) {
+	case []any, map[string]any:
+	default:
+		return false
+	}
+	if _, ok := dest.(sql.Scanner); ok {
+		return false
+	}
+	t := reflect.TypeOf(dest)
+	if t == nil || t.Kind() != reflect.Pointer {
+		return false
+	}
+	t = t.Elem()
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Kind() != reflect.Map && isCompositeType(t)
+}
+
+// getRawJSON returns a copy of the text of a JSON value, or nil, if the value is NULL.
+func (r *rows) getRawJSON(colIdx int, rowIdx int) []byte {
+	vec := &r.chunk.columns[colIdx]
+	if vec.getNull(mapping.IdxT(rowIdx)) {
+		return nil
+	}
+	strT := getPrimitive[mapping.StringT](vec, mapping.IdxT(rowIdx))
+	return []byte(mapping.StringTData(&strT))
+}
+
+// getText returns the text of a non-NULL VARCHAR, JSON, ENUM, or UUID value.
+func (r *rows) getText(colIdx int, rowIdx int) ([]byte, bool) {
+	vec := &r.chunk.columns[colIdx]
+	switch vec.Type {
+	case TYPE_VARCHAR:
+		// JSON values are VARCHAR values.
+		strT := getPrimitive[mapping.StringT](vec, mapping.IdxT(rowIdx))
+		return []byte(mapping.StringTData(&strT)), true
+	case TYPE_ENUM:
+		return []byte(vec.getFn(vec, mapping.IdxT(rowIdx)).(string)), true
+	case TYPE_UUID:
+		uuid := UUID(vec.getFn(vec, mapping.IdxT(rowIdx)).([]byte))
+		return []byte(uuid.String()), true
+	}
+	return nil, false
+}
@@ -0,0 +1,709 @@
+//go:build go1.27
+
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"net/netip"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+type testTextID struct {
+	prefix string
+	value  string
+}
+
+func (id *testTextID) UnmarshalText(text []byte) error {
+	prefix, value, ok := strings.Cut(string(text), ":")
+	if !ok {
+		return errors.New("invalid ID")
+	}
+	id.prefix, id.value = prefix, value
+	return nil
+}
+
+type testText string
+
+func (s *testText) UnmarshalText(text []byte) error {
+	*s = testText(text)
+	return nil
+}
+
+type testBinaryID []byte
+
+func (id *testBinaryID) UnmarshalBinary(data []byte) error {
+	*id = append((*id)[:0], data...)
+	return nil
+}
+
+func TestScanUnmarshaler(t *testing.T) {
+	db := openDbWrapper(t, ``)
+	defer closeDbWrapper(t, db)
+
+	createTable(t, db, `CREATE TYPE kind AS ENUM ('user:1', 'user:2')`)
+
+	var (
+		addr    netip.Addr
+		enumID  testTextID
+		jsonID  testTextID
+		uuidStr testText
+		blobID  testBinaryID
+		blobIP  netip.Addr
+		nullIP  *netip.Addr
+	)
+	row := db.QueryRow(`SELECT '10.0.0.1', 'user:2'::kind, '"a:b"'::JSON,
+		'f47ac10b-58cc-4372-a567-0e02b2c3d479'::UUID, '\xAA\xBB'::BLOB, '\x0A\x00\x00\x02'::BLOB, NULL::VARCHAR`)
+	require.NoError(t, row.Scan(&addr, &enumID, &jsonID, &uuidStr, &blobID, &blobIP, &nullIP))
+
+	require.Equal(t, netip.MustParseAddr("10.0.0.1"), addr)
+	require.Equal(t, testTextID{prefix: "user", value: "2"}, enumID)
+	require.Equal(t, testTextID{prefix: `"a`, value: `b"`}, jsonID)
+	require.Equal(t, testText("f47ac10b-58cc-4372-a567-0e02b2c3d479"), uuidStr)
+	require.Equal(t, testBinaryID{0xAA, 0xBB}, blobID)
+	require.Equal(t, netip.MustParseAddr("10.0.0.2"), blobIP)
+	require.Nil(t, nullIP)
+
+	// Scanning into a sql.Scanner takes precedence.
+	var uuid UUID
+	require.NoError(t, db.QueryRow(`SELECT 'f47ac10b-58cc-4372-a567-0e02b2c3d479'::UUID`).Scan(&uuid))
+	require.Equal(t, "f47ac10b-58cc-4372-a567-0e02b2c3d479", uuid.String())
+
+	// Unmarshal errors are returned.
+	err := db.QueryRow(`SELECT 'not an address'`).Scan(&addr)
+	require.ErrorContains(t, err, "ParseAddr")
+}
+
+func TestScanNestedArray(t *testing.T) {
+	db := openDbWrapper(t, ``)
+	defer closeDbWrapper(t, db)
+
+	var (
+		matrix [3][2]float32
+		slices [][]float32
+		list   []int64
+		nested *[2][2]int32
+	)
+	row := db.QueryRow(`SELECT [[1, 2], [3, 4], [5, 6]]::FLOAT[2][3], [[1, 2], [3, 4]]::FLOAT[2][2], [1, 2, 3], NULL::INT[2][2]`)
+	require.NoError(t, row.Scan(&matrix, &slices, &list, &nested))
+	require.Equal(t, [3][2]float32{{1, 2}, {3, 4}, {5, 6}}, matrix)
+	require.Equal(t, [][]float32{{1, 2}, {3, 4}}, slices)
+	require.Equal(t, []int64{1, 2, 3}, list)
+	require.Nil(t, nested)
+}
+
+func TestScanRawJSON(t *testing.T) {
+	db := openDbWrapper(t, ``)
+	defer closeDbWrapper(t, db)
+
+	const doc = `{"id":12345678901234567890,"price":0.10000000000000000001}`
+	var (
+		raw     json.RawMessage
+		b       []byte
+		decoded any
+		nullRaw json.RawMessage
+	)
+	row := db.QueryRow(`SELECT ?::JSON, ?::JSON, ?::JSON, NULL::JSON`, doc, doc, doc)
+	require.NoError(t, row.Scan(&raw, &b, &decoded, &nullRaw))
+	require.JSONEq(t, doc, string(raw))
+	require.JSONEq(t, doc, string(b))
+	require.Nil(t, nullRaw)
+
+	// The raw text keeps the precision of large numbers.
+	var v struct {
+		ID json.Number `json:"id"`
+	}
+	dec := json.NewDecoder(strings.NewReader(string(raw)))
+	dec.UseNumber()
+	require.NoError(t, dec.Decode(&v))
+	require.Equal(t, "12345678901234567890", v.ID.String())
+
+	// Other destinations still receive the decoded value.
+	require.IsType(t, map[string]any{}, decoded)
+
+	// Scanning VARCHAR values into []byte is unaffected.
+	require.NoError(t, db.QueryRow(`SELECT 'not json'`).Scan(&b))
+	require.Equal(t, []byte("not json"), b)
+}
+
+func TestScanHugeInt(t *testing.T) {
+	db := openDbWrapper(t, ``)
+	defer closeDbWrapper(t, db)
+
+	var (
+		i  int64
+		u  uint64
+		f  float64
+		i8 int8
+		hi *big.Int
+	)
+	row := db.QueryRow(`SELECT -42::HUGEINT, 18446744073709551615::HUGEINT, 170141183460469231731687303715884105727::HUGEINT,
+		127::HUGEINT, 42::HUGEINT`)
+	require.NoError(t, row.Scan(&i, &u, &f, &i8, &hi))
+	require.Equal(t, int64(-42), i)
+	require.Equal(t, uint64(math.MaxUint64), u)
+	require.Equal(t, 1.7014118346046923e38, f)
+	require.Equal(t, int8(127), i8)
+	require.Equal(t, "42", hi.String())
+
+	tests := map[string]any{
+		`SELECT 9223372036854775808::HUGEINT`: &i,
+		`SELECT -1::HUGEINT`:                  &u,
+		`SELECT 128::HUGEINT`:                 &i8,
+	}
+	for query, dest := range tests {
+		err := db.QueryRow(query).Scan(dest)
+		var duckdbErr *Error
+		require.ErrorAs(t, err, &duckdbErr, query)
+		require.Equal(t, ErrorTypeOutOfRange, duckdbErr.Type)
+		require.ErrorContains(t, err, "out of range")
+	}
+}
+
+func TestScanDecimal(t *testing.T) {
+	db := openDbWrapper(t, ``)
+	defer closeDbWrapper(t, db)
+
+	type amount string
+	var (
+		f    float64
+		f32  float32
+		s    string
+		a    amount
+		null *float64
+		d    Decimal
+	)
+	row := db.QueryRow(`SELECT 1234.5678::DECIMAL(8, 4), -1.5::DECIMAL(4, 1), 12345678901234567890.123::DECIMAL(38, 3),
+		0.10::DECIMAL(3, 2), NULL::DECIMAL(4, 1), 2.5::DECIMAL(4, 1)`)
+	require.NoError(t, row.Scan(&f, &f32, &s, &a, &null, &d))
+	require.Equal(t, 1234.5678, f)
+	require.Equal(t, float32(-1.5), f32)
+	require.Equal(t, "12345678901234567890.123", s)
+	require.Equal(t, amount("0.1"), a)
+	require.Nil(t, null)
+	require.Equal(t, 2.5, d.Float64())
+
+	// Other destinations still fail.
+	var i int
+	require.Error(t, db.QueryRow(`SELECT 1.5::DECIMAL(4, 1)`).Scan(&i))
+}
+
+func TestScanTypeHandler(t *testing.T) {
+	db := openDbWrapper(t, ``)
+	defer closeDbWrapper(t, db)
+	registerTestAccountID(t)
+
+	var id, other testAccountID
+	require.NoError(t, db.QueryRow(`SELECT 'user-7', ?`, testAccountID(8)).Scan(&id, &other))
+	require.Equal(t, testAccountID(7), id)
+	require.Equal(t, testAccountID(8), other)
+
+	// The handler receives NULL values as nil.
+	require.ErrorContains(t, db.QueryRow(`SELECT NULL::VARCHAR`).Scan(&id), "cannot scan <nil> into testAccountID")
+}
+
+func TestScanTimeDuration(t *testing.T) {
+	db := openDbWrapper(t, ``)
+	defer closeDbWrapper(t, db)
+
+	var (
+		tm     time.Duration
+		tmTZ   time.Duration
+		end    time.Duration
+		null   *time.Duration
+		ts     time.Time
+		nullTZ *time.Duration
+	)
+	row := db.QueryRow(`SELECT TIME '12:34:56.789', TIMETZ '12:34:56+02', TIME '24:00:00', NULL::TIME,
+		TIME '01:02:03', NULL::TIMETZ`)
+	require.NoError(t, row.Scan(&tm, &tmTZ, &end, &null, &ts, &nullTZ))
+	require.Equal(t, 12*time.Hour+34*time.Minute+56*time.Second+789*time.Millisecond, tm)
+	require.Equal(t, 10*time.Hour+34*time.Minute+56*time.Second, tmTZ)
+	require.Equal(t, 24*time.Hour, end)
+	require.Nil(t, null)
+	require.Equal(t, time.Date(1, time.January, 1, 1, 2, 3, 0, time.UTC), ts)
+	require.Nil(t, nullTZ)
+
+	// Other types still fail to scan into time.Duration.
+	require.Error(t, db.QueryRow(`SELECT TIMESTAMP '2024-01-01 12:00:00'`).Scan(&tm))
+}
+
+func TestScanEnum(t *testing.T) {
+	db := openDbWrapper(t, ``)
+	defer closeDbWrapper(t, db)
+
+	ctx := context.Background()
+	conn := openConnWrapper(t, db, ctx)
+	defer closeConnWrapper(t, conn)
+	require.NoError(t, RegisterEnum(ctx, conn, "mood", testMoodSad, testMoodOk, testMoodHappy))
+
+	var mood testMood
+	require.NoError(t, conn.QueryRowContext(ctx, `SELECT 'ok'::mood`).Scan(&mood))
+	require.Equal(t, testMoodOk, mood)
+
+	// Scanning strings that are not members fails.
+	err := conn.QueryRowContext(ctx, `SELECT 'angry'`).Scan(&mood)
+	require.ErrorContains(t, err, `expected a member of ENUM mood, got "angry"`)
+}
+
+type testScore float64
+
+func TestScanPrimitiveArray(t *testing.T) {
+	db := openDbWrapper(t, ``)
+	defer closeDbWrapper(t, db)
+
+	createTable(t, db, `CREATE TABLE embeddings AS
+		SELECT i AS id, [i, i + 0.5, i + 1]::FLOAT[3] AS vec, [i, -i]::BIGINT[2] AS ints,
+			[i % 2 = 0, true]::BOOLEAN[2] AS flags, [i * 0.25, 1]::DOUBLE[2] AS scores
+		FROM range(3000) t(i)`)
+
+	res, err := db.Query(`SELECT id, vec, ints, flags, scores FROM embeddings ORDER BY id`)
+	require.NoError(t, err)
+	defer closeRowsWrapper(t, res)
+
+	count := 0
+	for res.Next() {
+		var (
+			id     int64
+			vec    [3]float32
+			ints   [2]int64
+			flags  [2]bool
+			scores [2]testScore
+		)
+		require.NoError(t, res.Scan(&id, &vec, &ints, &flags, &scores))
+		i := float32(id)
+		require.Equal(t, [3]float32{i, i + 0.5, i + 1}, vec)
+		require.Equal(t, [2]int64{id, -id}, ints)
+		require.Equal(t, [2]bool{id%2 == 0, true}, flags)
+		require.Equal(t, [2]testScore{testScore(float64(id) * 0.25), 1}, scores)
+		count++
+	}
+	require.NoError(t, res.Err())
+	require.Equal(t, 3000, count)
+
+	// Mismatching element types and NULL elements fall back to converting the elements.
+	var doubles [3]float64
+	require.NoError(t, db.QueryRow(`SELECT [1, 2, 3]::FLOAT[3]`).Scan(&doubles))
+	require.Equal(t, [3]float64{1, 2, 3}, doubles)
+
+	var withNull [2]*int32
+	require.NoError(t, db.QueryRow(`SELECT [1, NULL]::INTEGER[2]`).Scan(&withNull))
+	require.Equal(t, int32(1), *withNull[0])
+	require.Nil(t, withNull[1])
+
+	var null *[2]int32
+	require.NoError(t, db.QueryRow(`SELECT NULL::INTEGER[2]`).Scan(&null))
+	require.Nil(t, null)
+}
+
+type testIDs []int64
+
+type testJoined string
+
+func (j *testJoined) Scan(v any) error {
+	parts := make([]string, 0)
+	for _, val := range v.([]any) {
+		parts = append(parts, fmt.Sprint(val))
+	}
+	*j = testJoined(strings.Join(parts, ","))
+	return nil
+}
+
+type testJoinedList []string
+
+func (l *testJoinedList) Scan(v any) error {
+	*l = testJoinedList{fmt.Sprint(v)}
+	return nil
+}
+
+func TestScanList(t *testing.T) {
+	db := openDbWrapper(t, ``)
+	defer closeDbWrapper(t, db)
+
+	var (
+		ints    []int32
+		strs    []string
+		floats  []float64
+		nested  [][]int32
+		ids     testIDs
+		null    = &[]int32{1}
+		empty   []int64
+		withNil []*int32
+	)
+	row := db.QueryRow(`SELECT [1, 2, 3]::INTEGER[], ['a', 'b'], [0.5, 1.5]::DOUBLE[], [[1], [2, 3]], [7, 8]::BIGINT[],
+		NULL::INTEGER[], []::BIGINT[], [1, NULL]::INTEGER[]`)
+	require.NoError(t, row.Scan(&ints, &strs, &floats, &nested, &ids, &null, &empty, &withNil))
+	require.Equal(t, []int32{1, 2, 3}, ints)
+	require.Equal(t, []string{"a", "b"}, strs)
+	require.Equal(t, []float64{0.5, 1.5}, floats)
+	require.Equal(t, [][]int32{{1}, {2, 3}}, nested)
+	require.Equal(t, testIDs{7, 8}, ids)
+	require.Nil(t, null)
+	require.NotNil(t, empty)
+	require.Empty(t, empty)
+	require.Equal(t, int32(1), *withNil[0])
+	require.Nil(t, withNil[1])
+
+	// Each scan returns a new slice.
+	res, err := db.Query(`SELECT [i, i + 1]::INTEGER[] FROM range(3) t(i)`)
+	require.NoError(t, err)
+	defer closeRowsWrapper(t, res)
+	var all [][]int32
+	for res.Next() {
+		require.NoError(t, res.Scan(&ints))
+		all = append(all, ints)
+	}
+	require.NoError(t, res.Err())
+	require.Equal(t, [][]int32{{0, 1}, {1, 2}, {2, 3}}, all)
+
+	// Scanners take precedence.
+	var joined testJoined
+	var joinedList testJoinedList
+	require.NoError(t, db.QueryRow(`SELECT [1, 2]::INTEGER[], ['x']`).Scan(&joined, &joinedList))
+	require.Equal(t, testJoined("1,2"), joined)
+	require.Equal(t, testJoinedList{"[x]"}, joinedList)
+}
+
+type testScanLeaf struct {
+	X  int64     `duckdb:"x"`
+	At time.Time `duckdb:"at"`
+}
+
+type testScanInner struct {
+	At   time.Time
+	N    *int32
+	Tags []string
+	Leaf *testScanLeaf
+	Day  *time.Time
+}
+
+type testScanOuter struct {
+	Name   string `db:"full_name"`
+	Inner  testScanInner
+	Ptr    *testScanInner
+	Leaves []testScanLeaf
+	Amount Decimal
+}
+
+func TestScanStruct(t *testing.T) {
+	db := openDbWrapper(t, ``)
+	defer closeDbWrapper(t, db)
+
+	const query = `SELECT {
+		'full_name': 'ann',
+		'inner': {'at': TIMESTAMP '2024-01-01 10:00:00', 'n': 5, 'tags': ['x', 'y'],
+			'leaf': {'x': 1, 'at': TIMESTAMP '2024-01-02'}, 'day': DATE '2024-01-03'},
+		'ptr': {'at': TIMESTAMP '2024-01-04', 'n': NULL, 'tags': NULL, 'leaf': NULL, 'day': NULL},
+		'leaves': [{'x': 2, 'at': TIMESTAMP '2024-01-05'}],
+		'amount': 1.5::DECIMAL(4, 1)}`
+
+	n := int32(5)
+	day := time.Date(2024, time.January, 3, 0, 0, 0, 0, time.UTC)
+	expected := testScanOuter{
+		Name: "ann",
+		Inner: testScanInner{
+			At:   time.Date(2024, time.January, 1, 10, 0, 0, 0, time.UTC),
+			N:    &n,
+			Tags: []string{"x", "y"},
+			Leaf: &testScanLeaf{X: 1, At: time.Date(2024, time.January, 2, 0, 0, 0, 0, time.UTC)},
+			Day:  &day,
+		},
+		Ptr:    &testScanInner{At: time.Date(2024, time.January, 4, 0, 0, 0, 0, time.UTC)},
+		Leaves: []testScanLeaf{{X: 2, At: time.Date(2024, time.January, 5, 0, 0, 0, 0, time.UTC)}},
+		Amount: Decimal{Width: 4, Scale: 1, Value: big.NewInt(15)},
+	}
+
+	var outer testScanOuter
+	require.NoError(t, db.QueryRow(query).Scan(&outer))
+	require.Equal(t, expected, outer)
+
+	var ptr *testScanOuter
+	require.NoError(t, db.QueryRow(query).Scan(&ptr))
+	require.Equal(t, expected, *ptr)
+
+	require.NoError(t, db.QueryRow(`SELECT NULL::STRUCT(x BIGINT)`).Scan(&ptr))
+	require.Nil(t, ptr)
+
+	// STRUCT values still scan into maps.
+	var m map[string]any
+	require.NoError(t, db.QueryRow(`SELECT {'x': 1}`).Scan(&m))
+	require.Equal(t, map[string]any{"x": int32(1)}, m)
+}
+
+func BenchmarkScanPrimitiveArray(b *testing.B) {
+	db := openDbWrapper(b, ``)
+	defer closeDbWrapper(b, db)
+
+	_, err := db.Exec(`CREATE TABLE embeddings AS
+		SELECT list_transform(range(128), x -> (i + x)::FLOAT)::FLOAT[128] AS vec FROM range(10000) t(i)`)
+	require.NoError(b, err)
+
+	var vec [128]float32
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		res, err := db.Query(`SELECT vec FROM embeddings`)
+		require.NoError(b, err)
+		for res.Next() {
+			require.NoError(b, res.Scan(&vec))
+		}
+		require.NoError(b, res.Err())
+		closeRowsWrapper(b, res)
+	}
+}
+
+func TestScanFixedBytes(t *testing.T) {
+	db := openDbWrapper(t, ``)
+	defer closeDbWrapper(t, db)
+
+	var digest [32]byte
+	require.NoError(t, db.QueryRow(`SELECT unhex(sha256('duckdb'))`).Scan(&digest))
+	var want string
+	require.NoError(t, db.QueryRow(`SELECT sha256('duckdb')`).Scan(&want))
+	require.Equal(t, want, fmt.Sprintf("%x", digest))
+
+	type key [4]byte
+	var k key
+	require.NoError(t, db.QueryRow(`SELECT '\xAA\xBB\xCC\xDD'::BLOB`).Scan(&k))
+	require.Equal(t, key{0xAA, 0xBB, 0xCC, 0xDD}, k)
+
+	// The length of the value must match the length of the array.
+	err := db.QueryRow(`SELECT '\xAA\xBB'::BLOB`).Scan(&k)
+	require.ErrorContains(t, err, invalidInputErrMsg)
+	require.ErrorContains(t, err, "expected 4 bytes")
+
+	var nullKey *key
+	require.NoError(t, db.QueryRow(`SELECT NULL::BLOB`).Scan(&nullKey))
+	require.Nil(t, nullKey)
+}
+
+func TestScanTextCasts(t *testing.T) {
+	c, err := NewConnectorWithOptions(``, nil, WithTextCasts())
+	require.NoError(t, err)
+	db := sql.OpenDB(c)
+	defer closeDbWrapper(t, db)
+
+	createTable(t, db, `CREATE TYPE mood AS ENUM ('sad', 'happy')`)
+
+	exprs := []string{
+		`true`,
+		`-42::TINYINT`,
+		`42::UBIGINT`,
+		`1.5::FLOAT`,
+		`'nan'::DOUBLE`,
+		`170141183460469231731687303715884105727::HUGEINT`,
+		`1.50::DECIMAL(4, 2)`,
+		`-123456789012345678.9012::DECIMAL(22, 4)`,
+		`'hello'`,
+		`'happy'::mood`,
+		`'\xAA\xBB'::BLOB`,
+		`'f47ac10b-58cc-4372-a567-0e02b2c3d479'::UUID`,
+		`DATE '2024-02-29'`,
+		`TIME '10:30:00.5'`,
+		`TIMETZ '10:30:00+02'`,
+		`TIMESTAMP '2024-01-01 10:00:00.123456'`,
+		`TIMESTAMP_S '2024-01-01 10:00:00'`,
+		`TIMESTAMP_MS '2024-01-01 10:00:00.123'`,
+		`TIMESTAMP_NS '2024-01-01 10:00:00.123456789'`,
+		`TIMESTAMPTZ '2024-01-01 10:00:00+02'`,
+		`INTERVAL 90 MINUTE`,
+		`'{"a": [1, 2]}'::JSON`,
+		`[1, NULL, 3]`,
+		`[['a b', 'c,d'], []]`,
+		`[1.5, 2.5]::DOUBLE[2]`,
+		`{'a': 1, 'b c': [true], 'd': NULL}`,
+		`MAP {'k': [1], 'l': NULL}`,
+		`[MAP {1: 'one'}]`,
+		`union_value(str := 'x y')::UNION(num INTEGER, str VARCHAR)`,
+	}
+	for _, expr := range exprs {
+		var want, got string
+		require.NoError(t, db.QueryRow(`SELECT (`+expr+`)::VARCHAR, `+expr).Scan(&want, &got), expr)
+		require.Equal(t, want, got, expr)
+	}
+
+	// NULL values fail to scan into a *string, as without WithTextCasts.
+	var s string
+	require.Error(t, db.QueryRow(`SELECT NULL::INTEGER`).Scan(&s))
+	var null *string
+	require.NoError(t, db.QueryRow(`SELECT NULL::INTEGER`).Scan(&null))
+	require.Nil(t, null)
+
+	// Other destinations are unaffected.
+	var n int
+	require.NoError(t, db.QueryRow(`SELECT 42`).Scan(&n))
+	require.Equal(t, 42, n)
+}
+
+func TestScanTextCastsDisabled(t *testing.T) {
+	db := openDbWrapper(t, ``)
+	defer closeDbWrapper(t, db)
+
+	var s string
+	require.NoError(t, db.QueryRow(`SELECT '\xAA'::BLOB`).Scan(&s))
+	require.Equal(t, "\xAA", s)
+	require.Error(t, db.QueryRow(`SELECT [1, 2]`).Scan(&s))
+}
+
+func TestScanRawBytes(t *testing.T) {
+	db := openDbWrapper(t, ``)
+	defer closeDbWrapper(t, db)
+
+	rows, err := db.Query(`SELECT CASE WHEN i % 100 = 0 THEN NULL ELSE repeat('x', i % 20) || i END AS s,
+		unhex(lpad(to_hex(i % 256), 2, '0')) AS b, ('{"i": ' || i || '}')::JSON AS j
+		FROM range(5000) t(i)`)
+	require.NoError(t, err)
+	defer closeRowsWrapper(t, rows)
+
+	var i int
+	for rows.Next() {
+		var s, b, j sql.RawBytes
+		require.NoError(t, rows.Scan(&s, &b, &j))
+		if i%100 == 0 {
+			require.Nil(t, s)
+		} else {
+			require.Equal(t, strings.Repeat("x", i%20)+fmt.Sprint(i), string(s))
+		}
+		require.Equal(t, []byte{byte(i % 256)}, []byte(b))
+		require.Equal(t, fmt.Sprintf(`{"i": %d}`, i), string(j))
+		i++
+	}
+	require.NoError(t, rows.Err())
+	require.Equal(t, 5000, i)
+
+	// Empty strings are not NULL.
+	var s sql.RawBytes
+	rows, err = db.Query(`SELECT ''`)
+	require.NoError(t, err)
+	defer closeRowsWrapper(t, rows)
+	require.True(t, rows.Next())
+	require.NoError(t, rows.Scan(&s))
+	require.NotNil(t, s)
+	require.Empty(t, s)
+}
+
+func TestScanCompositeFieldNaming(t *testing.T) {
+	type user struct {
+		UserID    int
+		FirstName string
+		Nick      string `duckdb:"nick_name"`
+	}
+	const query = `SELECT {'userid': 1, 'FirstName': 'ann', 'NICK_NAME': 'a'}`
+	const snakeQuery = `SELECT {'user_id': 1, 'first_name': 'ann', 'nick_name': 'a'}`
+
+	tests := []struct {
+		naming   FieldNaming
+		query    string
+		expected user
+	}{
+		{naming: FieldNamingCaseInsensitive, query: query, expected: user{UserID: 1, FirstName: "ann", Nick: "a"}},
+		{naming: FieldNamingCaseInsensitive, query: snakeQuery, expected: user{Nick: "a"}},
+		{naming: FieldNamingExact, query: query, expected: user{FirstName: "ann"}},
+		{naming: FieldNamingExact, query: snakeQuery, expected: user{Nick: "a"}},
+		{naming: FieldNamingSnakeCase, query: query, expected: user{UserID: 1, FirstName: "ann", Nick: "a"}},
+		{naming: FieldNamingSnakeCase, query: snakeQuery, expected: user{UserID: 1, FirstName: "ann", Nick: "a"}},
+	}
+	for _, test := range tests {
+		c, err := NewConnectorWithOptions(``, nil, WithCompositeFieldNaming(test.naming))
+		require.NoError(t, err)
+		db := sql.OpenDB(c)
+
+		var composite Composite[user]
+		require.NoError(t, db.QueryRow(test.query).Scan(&composite))
+		require.Equal(t, test.expected, composite.Get())
+
+		var direct user
+		require.NoError(t, db.QueryRow(test.query).Scan(&direct))
+		require.Equal(t, test.expected, direct)
+
+		var null Null[user]
+		require.NoError(t, db.QueryRow(test.query).Scan(&null))
+		require.Equal(t, test.expected, null.V)
+		closeDbWrapper(t, db)
+	}
+
+	// Strict decoding reports the STRUCT fields that do not match.
+	c, err := NewConnectorWithOptions(``, nil, WithCompositeFieldNaming(FieldNamingExact))
+	require.NoError(t, err)
+	db := sql.OpenDB(c)
+	defer closeDbWrapper(t, db)
+	var strict StrictComposite[user]
+	require.ErrorContains(t, db.QueryRow(snakeQuery).Scan(&strict), "user_id")
+
+	c, err = NewConnectorWithOptions(``, nil, WithCompositeFieldNaming(FieldNamingSnakeCase))
+	require.NoError(t, err)
+	snakeDB := sql.OpenDB(c)
+	defer closeDbWrapper(t, snakeDB)
+	require.NoError(t, snakeDB.QueryRow(snakeQuery).Scan(&strict))
+	require.Equal(t, user{UserID: 1, FirstName: "ann", Nick: "a"}, strict.Get())
+
+	// The naming applies to the values of typed maps and to nested structs of ScanStruct.
+	var m TypedMap[string, user]
+	require.NoError(t, snakeDB.QueryRow(`SELECT MAP {'a': {'user_id': 2}}`).Scan(&m))
+	require.Equal(t, TypedMap[string, user]{"a": {UserID: 2}}, m)
+
+	type account struct {
+		Owner user
+	}
+	r, err := snakeDB.Query(`SELECT {'user_id': 3} AS owner`)
+	require.NoError(t, err)
+	accounts, err := CollectStructs[account](r)
+	require.NoError(t, err)
+	require.Equal(t, []account{{Owner: user{UserID: 3}}}, accounts)
+}
+
+func TestScanCompositeDecodeHooks(t *testing.T) {
+	type event struct {
+		At time.Time
+		ID uuid.UUID
+	}
+	query := `SELECT [{'at': '2024-01-02T03:04:05Z', 'id': '8f5b4cf0-6a0b-4b9e-9d5e-2d1b3c4a5e6f'}]`
+
+	db := openDbWrapper(t, ``)
+	defer closeDbWrapper(t, db)
+	var c Composite[[]event]
+	require.Error(t, db.QueryRow(query).Scan(&c))
+
+	connector, err := NewConnectorWithOptions(``, nil,
+		WithCompositeDecodeHooks(mapstructure.StringToTimeHookFunc(time.RFC3339)),
+		WithCompositeDecodeHooks(func(from reflect.Type, to reflect.Type, data any) (any, error) {
+			if from.Kind() != reflect.String || to != reflect.TypeFor[uuid.UUID]() {
+				return data, nil
+			}
+			return uuid.Parse(data.(string))
+		}))
+	require.NoError(t, err)
+	hooksDB := sql.OpenDB(connector)
+	defer closeDbWrapper(t, hooksDB)
+
+	expected := []event{{
+		At: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		ID: uuid.MustParse("8f5b4cf0-6a0b-4b9e-9d5e-2d1b3c4a5e6f"),
+	}}
+	require.NoError(t, hooksDB.QueryRow(query).Scan(&c))
+	require.Equal(t, expected, c.Get())
+
+	var direct []event
+	require.NoError(t, hooksDB.QueryRow(query).Scan(&direct))
+	require.Equal(t, expected, direct)
+
+	// The hooks apply to the values of typed maps.
+	var m TypedMap[string, time.Time]
+	require.NoError(t, hooksDB.QueryRow(`SELECT MAP {'a': '2024-01-02T03:04:05Z'}`).Scan(&m))
+	require.Equal(t, TypedMap[string, time.Time]{"a": time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)}, m)
+
+	// Other connectors do not use the hooks.
+	var other Composite[[]event]
+	require.Error(t, db.QueryRow(query).Scan(&other))
+}
@@ -226,6 +226,28 @@ func TestConstantScalarUDF(t *testing.T) {
 	require.Equal(t, 1, one)
 }
 
+func TestDecimalScalarUDF(t *testing.T) {
+	db := openDbWrapper(t, ``)
+	defer closeDbWrapper(t, db)
+
+	conn := openConnWrapper(t, db, context.Background())
+	defer closeConnWrapper(t, conn)
+
+	var err error
+	currentInfo, err = NewDecimalInfo(4, 2)
+	require.NoError(t, err)
+
+	var udf *constantSUDF
+	err = RegisterScalarUDF(conn, "decimal_one", udf)
+	require.NoError(t, err)
+
+	// Integer results are the unscaled values of DECIMAL results.
+	var res string
+	row := db.QueryRow(`SELECT decimal_one()::VARCHAR`)
+	require.NoError(t, row.Scan(&res))
+	require.Equal(t, "0.01", res)
+}
+
 func TestAllTypesScalarUDF(t *testing.T) {
 	typeInfos := getTypeInfos(t, false)
 	for _, info := range typeInfos {
@@ -0,0 +1,153 @@
+package duckdb
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+)
+
+var reflectTypeScanner = reflect.TypeFor[sql.Scanner]()
+
+// ScanStruct scans the current row of rows into the struct that dest points to.
+// Each column maps to the exported field with the matching `duckdb` or `db` struct tag, or name,
+// like for BulkLoad. A column matches the name exactly, ignoring case, or ignoring case and underscores,
+// in this order of preference, e.g., the column user_id matches the field UserID.
+// Scanning fails for columns without a matching field, and leaves fields without a matching column unchanged.
+// Struct, slice, map, and array fields receive STRUCT, LIST, ARRAY, and MAP values like Composite,
+// e.g., nested STRUCT columns scan into nested structs.
+func ScanStruct(rows *sql.Rows, dest any) error {
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return getError(errScanStruct, castError(fmt.Sprintf("%T", dest), "a non-nil pointer to a struct"))
+	}
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	fields, err := structScanFields(v.Elem().Type(), columns)
+	if err != nil {
+		return getError(errScanStruct, err)
+	}
+	return scanStruct(rows, v.Elem(), fields, make([]any, len(fields)))
+}
+
+// CollectStructs scans the remaining rows of rows into structs of type T, like ScanStruct, and closes the rows.
+func CollectStructs[T any](rows *sql.Rows) ([]T, error) {
+	defer rows.Close()
+
+	t := reflect.TypeFor[T]()
+	if t.Kind() != reflect.Struct {
+		return nil, getError(errScanStruct, castError(t.String(), reflect.Struct.String()))
+	}
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	fields, err := structScanFields(t, columns)
+	if err != nil {
+		return nil, getError(errScanStruct, err)
+	}
+
+	var result []T
+	dest := make([]any, len(fields))
+	for rows.Next() {
+		var row T
+		if err = scanStruct(rows, reflect.ValueOf(&row).Elem(), fields, dest); err != nil {
+			return nil, err
+		}
+		result = append(result, row)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return result, rows.Close()
+}
+
+// structScanNamings are the namings matching columns to struct fields, in their order of preference.
+var structScanNamings = []FieldNaming{FieldNamingExact, FieldNamingCaseInsensitive, FieldNamingSnakeCase}
+
+// structScanFields returns the index of the struct field of each column.
+func structScanFields(t reflect.Type, columns []string) ([][]int, error) {
+	fields := make([][]int, len(columns))
+	for i, column := range columns {
+	namings:
+		for _, naming := range structScanNamings {
+			for j := 0; j < t.NumField(); j++ {
+				field := t.Field(j)
+				name, ok := structFieldName(field)
+				if ok && naming.match(column, name) {
+					fields[i] = field.Index
+					break namings
+				}
+			}
+		}
+		if fields[i] == nil {
+			return nil, fmt.Errorf("column %s has no matching field in %s", column, t.String())
+		}
+	}
+	return fields, nil
+}
+
+func scanStruct(rows *sql.Rows, v reflect.Value, fields [][]int, dest []any) error {
+	for i, index := range fields {
+		field := v.FieldByIndex(index)
+		if isCompositeField(field.Type()) {
+			dest[i] = compositeField{field: field}
+		} else {
+			dest[i] = field.Addr().Interface()
+		}
+	}
+	return rows.Scan(dest...)
+}
+
+// isCompositeField returns true, if a field of type t receives composite values.
+func isCompositeField(t reflect.Type) bool {
+	if reflect.PointerTo(t).Implements(reflectTypeScanner) {
+		return false
+	}
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+		if t.Implements(reflectTypeScanner) || reflect.PointerTo(t).Implements(reflectTypeScanner) {
+			return false
+		}
+	}
+	switch t.Kind() {
+	case reflect.Struct, reflect.Map, reflect.Array:
+		return true
+	case reflect.Slice:
+		return t.Elem().Kind() != reflect.Uint8
+	default:
+		return false
+	}
+}
+
+// compositeField scans a value into a struct field. It assigns values of the field's type,
+// e.g., time.Time values to time.Time fields, and decodes other values like Composite.
+type compositeField struct {
+	field reflect.Value
+}
+
+func (f compositeField) Scan(v any) error {
+	return f.scanComposite(v, compositeOptions{})
+}
+
+func (f compositeField) scanComposite(v any, opts compositeOptions) error {
+	if v == nil {
+		f.field.SetZero()
+		return nil
+	}
+
+	val := reflect.ValueOf(v)
+	t := f.field.Type()
+	switch {
+	case val.Type().AssignableTo(t):
+		f.field.Set(val)
+		return nil
+	case t.Kind() == reflect.Pointer && val.Type().AssignableTo(t.Elem()):
+		p := reflect.New(t.Elem())
+		p.Elem().Set(val)
+		f.field.Set(p)
+		return nil
+	}
+	return decodeComposite(v, f.field.Addr().Interface(), false, opts)
+}
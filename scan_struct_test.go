@@ -0,0 +1,122 @@
+package duckdb
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type scanStructAddress struct {
+	Street string `duckdb:"street_name"`
+	City   string
+}
+
+type scanStructUser struct {
+	ID        int64 `db:"user_id"`
+	Name      string
+	Nick      *string
+	CreatedAt time.Time `duckdb:"created"`
+	Tags      []string
+	Address   scanStructAddress
+	Previous  *scanStructAddress
+	Scores    map[string]int32
+	Amount    Decimal
+	UUID      *UUID
+	ignored   int
+}
+
+func TestScanStructRows(t *testing.T) {
+	db := openDbWrapper(t, ``)
+	defer closeDbWrapper(t, db)
+
+	query := `SELECT * FROM (VALUES
+		(1, 'duck', NULL, TIMESTAMP '2024-01-02 03:04:05', ['a', 'b'], {'street_name': 'Pond Lane', 'city': 'Amsterdam'},
+			NULL, MAP {'x': 1}, 1.25::DECIMAL(5, 2), '53b4e983-b287-481a-94ad-6e3c90489913'::UUID),
+		(2, 'goose', 'honk', TIMESTAMP '2024-02-03 04:05:06', [], {'street_name': 'Lake Road', 'city': 'Berlin'},
+			{'street_name': 'Pond Lane', 'city': 'Amsterdam'}, MAP {}, 2.50::DECIMAL(5, 2), NULL)
+	) t(user_id, NAME, nick, created, tags, address, previous, scores, amount, uuid) ORDER BY user_id`
+
+	nick := "honk"
+	id := UUID{0x53, 0xb4, 0xe9, 0x83, 0xb2, 0x87, 0x48, 0x1a, 0x94, 0xad, 0x6e, 0x3c, 0x90, 0x48, 0x99, 0x13}
+	expected := []scanStructUser{
+		{
+			ID:        1,
+			Name:      "duck",
+			CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			Tags:      []string{"a", "b"},
+			Address:   scanStructAddress{Street: "Pond Lane", City: "Amsterdam"},
+			Scores:    map[string]int32{"x": 1},
+			Amount:    Decimal{Width: 5, Scale: 2, Value: big.NewInt(125)},
+			UUID:      &id,
+		},
+		{
+			ID:        2,
+			Name:      "goose",
+			Nick:      &nick,
+			CreatedAt: time.Date(2024, 2, 3, 4, 5, 6, 0, time.UTC),
+			Tags:      []string{},
+			Address:   scanStructAddress{Street: "Lake Road", City: "Berlin"},
+			Previous:  &scanStructAddress{Street: "Pond Lane", City: "Amsterdam"},
+			Scores:    map[string]int32{},
+			Amount:    Decimal{Width: 5, Scale: 2, Value: big.NewInt(250)},
+		},
+	}
+
+	t.Run("scan struct", func(t *testing.T) {
+		r, err := db.Query(query)
+		require.NoError(t, err)
+		defer closeRowsWrapper(t, r)
+
+		var users []scanStructUser
+		for r.Next() {
+			user := scanStructUser{ignored: 42}
+			require.NoError(t, ScanStruct(r, &user))
+			require.Equal(t, 42, user.ignored)
+			user.ignored = 0
+			users = append(users, user)
+		}
+		require.NoError(t, r.Err())
+		require.Equal(t, expected, users)
+	})
+
+	t.Run("collect structs", func(t *testing.T) {
+		r, err := db.QueryContext(context.Background(), query)
+		require.NoError(t, err)
+		users, err := CollectStructs[scanStructUser](r)
+		require.NoError(t, err)
+		require.Equal(t, expected, users)
+	})
+
+	t.Run("naming", func(t *testing.T) {
+		type row struct {
+			UserID   int64
+			User_ID  int64
+			FullName string
+		}
+		// Columns prefer the fields whose names match them more exactly.
+		r, err := db.Query(`SELECT 1 AS userid, 2 AS user_id, 'duck' AS FULL_NAME`)
+		require.NoError(t, err)
+		rows, err := CollectStructs[row](r)
+		require.NoError(t, err)
+		require.Equal(t, []row{{UserID: 1, User_ID: 2, FullName: "duck"}}, rows)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		r, err := db.Query(`SELECT 1 AS user_id, 2 AS unknown`)
+		require.NoError(t, err)
+		_, err = CollectStructs[scanStructUser](r)
+		testError(t, err, errScanStruct.Error(), "column unknown has no matching field")
+
+		r, err = db.Query(`SELECT 1 AS user_id`)
+		require.NoError(t, err)
+		defer closeRowsWrapper(t, r)
+		require.True(t, r.Next())
+		var user scanStructUser
+		testError(t, ScanStruct(r, user), errScanStruct.Error(), castErrMsg)
+		_, err = CollectStructs[int](r)
+		testError(t, err, errScanStruct.Error(), castErrMsg)
+	})
+}
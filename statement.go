@@ -3,9 +3,14 @@ package duckdb
 import (
 	"context"
 	"database/sql/driver"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/marcboeker/go-duckdb/mapping"
 )
@@ -148,6 +153,25 @@ func (s *Stmt) bindHugeint(val *big.Int, n int) (mapping.State, error) {
 	return state, nil
 }
 
+// bindDecimal binds a DECIMAL value with the width and scale of the Decimal.
+func (s *Stmt) bindDecimal(val Decimal, n int) (mapping.State, error) {
+	if val.Value == nil {
+		val.Value = new(big.Int)
+	}
+	invalid := val.Width == 0 || val.Width > max_decimal_width || val.Scale > val.Width
+	if invalid || new(big.Int).Abs(val.Value).Cmp(pow10(val.Width)) >= 0 {
+		expected := fmt.Sprintf("DECIMAL(%d, %d)", val.Width, val.Scale)
+		return mapping.StateError, addIndexToError(castError(val.String(), expected), n+1)
+	}
+
+	hugeint, err := hugeIntFromNative(val.Value)
+	if err != nil {
+		return mapping.StateError, err
+	}
+	d := mapping.NewDecimal(val.Width, val.Scale, *hugeint)
+	return mapping.BindDecimal(*s.preparedStmt, mapping.IdxT(n+1), *d), nil
+}
+
 func (s *Stmt) bindTimestamp(val driver.NamedValue, t Type, n int) (mapping.State, error) {
 	ts, err := getMappedTimestamp(t, val.Value)
 	if err != nil {
@@ -186,11 +210,202 @@ func (s *Stmt) bindTime(val driver.NamedValue, t Type, n int) (mapping.State, er
 	return state, nil
 }
 
+// paramIsJSON returns true, if the parameter at the given index (1-based) has the JSON type.
+func (s *Stmt) paramIsJSON(n int) bool {
+	logicalType := mapping.ParamLogicalType(*s.preparedStmt, mapping.IdxT(n))
+	if logicalType.Ptr == nil {
+		// The type of the parameter is unresolved.
+		return false
+	}
+	defer mapping.DestroyLogicalType(&logicalType)
+	return mapping.LogicalTypeGetAlias(logicalType) == aliasJSON
+}
+
+// bindJSON marshals the value with encoding/json and binds the JSON text.
+func (s *Stmt) bindJSON(val any, n int) (mapping.State, error) {
+	bytes, err := json.Marshal(val)
+	if err != nil {
+		return mapping.StateError, addIndexToError(err, n+1)
+	}
+	return mapping.BindVarchar(*s.preparedStmt, mapping.IdxT(n+1), string(bytes)), nil
+}
+
+// bindNested binds a Go slice, array, struct, or map[string]any to a LIST, ARRAY, or STRUCT parameter.
+func (s *Stmt) bindNested(val any, n int) (mapping.State, error) {
+	logicalType := mapping.ParamLogicalType(*s.preparedStmt, mapping.IdxT(n+1))
+	defer mapping.DestroyLogicalType(&logicalType)
+
+	v, err := createNestedValue(logicalType, val)
+	if err != nil {
+		return mapping.StateError, addIndexToError(err, n+1)
+	}
+	state := mapping.BindValue(*s.preparedStmt, mapping.IdxT(n+1), v)
+	mapping.DestroyValue(&v)
+	return state, nil
+}
+
+// bindUnion binds a Union[T], or a Go value whose member inferUnionMember infers, to a UNION parameter.
+// The C API cannot create UNION values, so bindUnion binds a value of exactly the member's type,
+// which DuckDB casts to the member. Hence, the member's type must differ from the types of the other members.
+func (s *Stmt) bindUnion(val any, n int) (mapping.State, error) {
+	val, err := unwrapValue(val)
+	if err != nil {
+		return mapping.StateError, addIndexToError(err, n+1)
+	}
+	if val == nil {
+		return mapping.BindNull(*s.preparedStmt, mapping.IdxT(n+1)), nil
+	}
+
+	logicalType := mapping.ParamLogicalType(*s.preparedStmt, mapping.IdxT(n+1))
+	defer mapping.DestroyLogicalType(&logicalType)
+	memberCount := mapping.UnionTypeMemberCount(logicalType)
+	names := make([]string, memberCount)
+	types := make([]Type, memberCount)
+	for i := mapping.IdxT(0); i < memberCount; i++ {
+		names[i] = mapping.UnionTypeMemberName(logicalType, i)
+		memberType := mapping.UnionTypeMemberType(logicalType, i)
+		types[i] = Type(mapping.GetTypeId(memberType))
+		mapping.DestroyLogicalType(&memberType)
+	}
+
+	idx := -1
+	if u, ok := val.(unionMember); ok {
+		var name string
+		name, val = u.unionMember()
+		if idx = slices.Index(names, name); idx < 0 {
+			return mapping.StateError, addIndexToError(invalidInputError(name, "UNION member"), n+1)
+		}
+	} else if idx, err = inferUnionMember(names, types, val); err != nil {
+		return mapping.StateError, addIndexToError(err, n+1)
+	}
+
+	memberType := mapping.UnionTypeMemberType(logicalType, mapping.IdxT(idx))
+	defer mapping.DestroyLogicalType(&memberType)
+	v, err := createExactValue(memberType, val)
+	if err != nil {
+		return mapping.StateError, addIndexToError(err, n+1)
+	}
+	state := mapping.BindValue(*s.preparedStmt, mapping.IdxT(n+1), v)
+	mapping.DestroyValue(&v)
+	return state, nil
+}
+
+// bindMap binds a Map, an OrderedMap, or a Go map to a MAP parameter.
+// The C API cannot create MAP values, so bindMap creates the keys and values with createNestedValue,
+// and binds their MAP text, which DuckDB casts to the type of the parameter.
+// The text of nested keys and values does not quote their elements, so bindMap rejects nested key and value types.
+func (s *Stmt) bindMap(val any, n int) (mapping.State, error) {
+	entries, err := mapEntries(val)
+	if err != nil {
+		return mapping.StateError, addIndexToError(err, n+1)
+	}
+
+	logicalType := mapping.ParamLogicalType(*s.preparedStmt, mapping.IdxT(n+1))
+	defer mapping.DestroyLogicalType(&logicalType)
+	keyType := mapping.MapTypeKeyType(logicalType)
+	defer mapping.DestroyLogicalType(&keyType)
+	valueType := mapping.MapTypeValueType(logicalType)
+	defer mapping.DestroyLogicalType(&valueType)
+	for _, elemType := range []mapping.LogicalType{keyType, valueType} {
+		switch t := Type(mapping.GetTypeId(elemType)); t {
+		case TYPE_LIST, TYPE_ARRAY, TYPE_STRUCT, TYPE_MAP, TYPE_UNION:
+			return mapping.StateError, addIndexToError(unsupportedTypeError("MAP with "+typeToStringMap[t]+" keys or values"), n+1)
+		}
+	}
+
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, entry := range entries {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		if entry.Key == nil {
+			return mapping.StateError, addIndexToError(invalidInputError("NULL", "a MAP key"), n+1)
+		}
+		key, err := mapLiteralElement(keyType, entry.Key)
+		if err != nil {
+			return mapping.StateError, addIndexToError(err, n+1)
+		}
+		value, err := mapLiteralElement(valueType, entry.Value)
+		if err != nil {
+			return mapping.StateError, addIndexToError(err, n+1)
+		}
+		b.WriteString(key)
+		b.WriteByte('=')
+		b.WriteString(value)
+	}
+	b.WriteByte('}')
+	return mapping.BindVarchar(*s.preparedStmt, mapping.IdxT(n+1), b.String()), nil
+}
+
+// mapEntries returns the entries of a Map, an OrderedMap, or a Go map.
+func mapEntries(val any) ([]MapEntry, error) {
+	switch m := val.(type) {
+	case OrderedMap:
+		return m, nil
+	case Map:
+		entries := make([]MapEntry, 0, len(m))
+		for key, value := range m {
+			entries = append(entries, MapEntry{Key: key, Value: value})
+		}
+		return entries, nil
+	}
+
+	rv := reflect.ValueOf(val)
+	if rv.Kind() != reflect.Map {
+		return nil, castError(reflect.TypeOf(val).String(), reflect.TypeOf(Map{}).String())
+	}
+	entries := make([]MapEntry, 0, rv.Len())
+	iter := rv.MapRange()
+	for iter.Next() {
+		entries = append(entries, MapEntry{Key: iter.Key().Interface(), Value: iter.Value().Interface()})
+	}
+	return entries, nil
+}
+
+// mapLiteralElement returns the quoted text of a MAP key or value.
+// DuckDB's cast from text to MAP does not unescape quotes, and it reads a quoted NULL as NULL.
+// Hence, mapLiteralElement rejects text that it cannot quote without changing it.
+func mapLiteralElement(logicalType mapping.LogicalType, val any) (string, error) {
+	val, err := unwrapValue(val)
+	if err != nil {
+		return "", err
+	}
+	if val == nil {
+		return "NULL", nil
+	}
+	v, err := createNestedValue(logicalType, val)
+	if err != nil {
+		return "", err
+	}
+	str := mapping.GetVarchar(v)
+	mapping.DestroyValue(&v)
+
+	quote := "'"
+	if strings.Contains(str, quote) {
+		quote = `"`
+	}
+	if strings.Contains(str, quote) || strings.HasSuffix(str, `\`) || strings.EqualFold(str, "NULL") {
+		return "", invalidInputError(strconv.Quote(str), "a MAP key or value without both quote characters, a trailing backslash, or the text NULL")
+	}
+	return quote + str + quote, nil
+}
+
 func (s *Stmt) bindComplexValue(val driver.NamedValue, n int) (mapping.State, error) {
 	t, err := s.ParamType(n + 1)
 	if err != nil {
 		return mapping.StateError, err
 	}
+	if s.paramIsJSON(n + 1) {
+		return s.bindJSON(val.Value, n)
+	}
+	if !driver.IsValue(val.Value) {
+		// CheckNamedValue keeps values for JSON parameters, convert them for any other parameter.
+		if v, errConvert := driver.DefaultParameterConverter.ConvertValue(val.Value); errConvert == nil {
+			val.Value = v
+			return s.bindValue(val, n)
+		}
+	}
 	if name, ok := unsupportedTypeToStringMap[t]; ok {
 		return mapping.StateError, addIndexToError(unsupportedTypeError(name), n+1)
 	}
@@ -202,8 +417,11 @@ func (s *Stmt) bindComplexValue(val driver.NamedValue, n int) (mapping.State, er
 		return s.bindDate(val, n)
 	case TYPE_TIME, TYPE_TIME_TZ:
 		return s.bindTime(val, t, n)
-	case TYPE_TIMESTAMP_S, TYPE_TIMESTAMP_MS, TYPE_TIMESTAMP_NS, TYPE_LIST, TYPE_STRUCT, TYPE_MAP,
-		TYPE_ARRAY, TYPE_ENUM:
+	case TYPE_MAP:
+		return s.bindMap(val.Value, n)
+	case TYPE_LIST, TYPE_ARRAY, TYPE_STRUCT:
+		return s.bindNested(val.Value, n)
+	case TYPE_TIMESTAMP_S, TYPE_TIMESTAMP_MS, TYPE_TIMESTAMP_NS, TYPE_ENUM:
 		// FIXME: for timestamps: distinguish between timestamp[_s|ms|ns] once available.
 		// FIXME: for other types: duckdb_param_logical_type once available, then create duckdb_value + duckdb_bind_value
 		// FIXME: for other types: implement NamedValueChecker to support custom data types.
@@ -231,9 +449,7 @@ func (s *Stmt) bindValue(val driver.NamedValue, n int) (mapping.State, error) {
 	case *big.Int:
 		return s.bindHugeint(v, n)
 	case Decimal:
-		// FIXME: implement NamedValueChecker to support custom data types.
-		name := typeToStringMap[TYPE_DECIMAL]
-		return mapping.StateError, addIndexToError(unsupportedTypeError(name), n+1)
+		return s.bindDecimal(v, n)
 	case uint8:
 		return mapping.BindUInt8(*s.preparedStmt, mapping.IdxT(n+1), v), nil
 	case uint16:
@@ -242,6 +458,8 @@ func (s *Stmt) bindValue(val driver.NamedValue, n int) (mapping.State, error) {
 		return mapping.BindUInt32(*s.preparedStmt, mapping.IdxT(n+1), v), nil
 	case uint64:
 		return mapping.BindUInt64(*s.preparedStmt, mapping.IdxT(n+1), v), nil
+	case uint:
+		return mapping.BindUInt64(*s.preparedStmt, mapping.IdxT(n+1), uint64(v)), nil
 	case float32:
 		return mapping.BindFloat(*s.preparedStmt, mapping.IdxT(n+1), v), nil
 	case float64:
@@ -252,6 +470,16 @@ func (s *Stmt) bindValue(val driver.NamedValue, n int) (mapping.State, error) {
 		return mapping.BindBlob(*s.preparedStmt, mapping.IdxT(n+1), v), nil
 	case Interval:
 		return mapping.BindInterval(*s.preparedStmt, mapping.IdxT(n+1), *v.getMappedInterval()), nil
+	case TimestampNS:
+		ts := mapping.CreateTimestampNS(*mapping.NewTimestampNS(int64(v)))
+		state := mapping.BindValue(*s.preparedStmt, mapping.IdxT(n+1), ts)
+		mapping.DestroyValue(&ts)
+		return state, nil
+	case UUID:
+		uuidVal := mapping.CreateUUID(*uuidToUHugeInt(v))
+		state := mapping.BindValue(*s.preparedStmt, mapping.IdxT(n+1), uuidVal)
+		mapping.DestroyValue(&uuidVal)
+		return state, nil
 	case nil:
 		return mapping.BindNull(*s.preparedStmt, mapping.IdxT(n+1)), nil
 	}
@@ -284,10 +512,16 @@ func (s *Stmt) bind(args []driver.NamedValue) error {
 			}
 		}
 
-		state, err := s.bindValue(arg, i)
+		var state mapping.State
+		var err error
+		if t, _ := s.ParamType(i + 1); t == TYPE_UNION {
+			state, err = s.bindUnion(arg.Value, i)
+		} else {
+			state, err = s.bindValue(arg, i)
+		}
 		if state == mapping.StateError {
 			errMsg := mapping.PrepareError(*s.preparedStmt)
-			err = errors.Join(err, getDuckDBError(errMsg))
+			err = errors.Join(err, s.conn.getDuckDBError(errMsg))
 			return errors.Join(errCouldNotBind, err)
 		}
 	}
@@ -304,14 +538,13 @@ func (s *Stmt) Exec(args []driver.Value) (driver.Result, error) {
 // ExecContext executes a query that doesn't return rows, such as an INSERT or UPDATE.
 // It implements the driver.StmtExecContext interface.
 func (s *Stmt) ExecContext(ctx context.Context, nargs []driver.NamedValue) (driver.Result, error) {
-	res, err := s.execute(ctx, nargs)
+	res, err := s.execute(ctx, nargs, false)
 	if err != nil {
 		return nil, err
 	}
 	defer mapping.DestroyResult(res)
 
-	ra := mapping.ValueInt64(res, 0, 0)
-	return &result{ra}, nil
+	return newResult(getRowsAffected(res)), nil
 }
 
 // ExecBound executes a bound query that doesn't return rows, such as an INSERT or UPDATE.
@@ -328,14 +561,13 @@ func (s *Stmt) ExecBound(ctx context.Context) (driver.Result, error) {
 		return nil, errNotBound
 	}
 
-	res, err := s.executeBound(ctx)
+	res, err := s.executeBound(ctx, false)
 	if err != nil {
 		return nil, err
 	}
 	defer mapping.DestroyResult(res)
 
-	ra := mapping.ValueInt64(res, 0, 0)
-	return &result{ra}, nil
+	return newResult(getRowsAffected(res)), nil
 }
 
 // Deprecated: Use QueryContext instead.
@@ -346,12 +578,12 @@ func (s *Stmt) Query(args []driver.Value) (driver.Rows, error) {
 // QueryContext executes a query that may return rows, such as a SELECT.
 // It implements the driver.StmtQueryContext interface.
 func (s *Stmt) QueryContext(ctx context.Context, nargs []driver.NamedValue) (driver.Rows, error) {
-	res, err := s.execute(ctx, nargs)
+	res, err := s.execute(ctx, nargs, streamingResults(ctx))
 	if err != nil {
 		return nil, err
 	}
 	s.rows = true
-	return newRowsWithStmt(*res, s), nil
+	return newRowsWithContext(ctx, *res, s), nil
 }
 
 // QueryBound executes a bound query that may return rows, such as a SELECT.
@@ -368,17 +600,18 @@ func (s *Stmt) QueryBound(ctx context.Context) (driver.Rows, error) {
 		return nil, errNotBound
 	}
 
-	res, err := s.executeBound(ctx)
+	res, err := s.executeBound(ctx, streamingResults(ctx))
 	if err != nil {
 		return nil, err
 	}
 	s.rows = true
-	return newRowsWithStmt(*res, s), nil
+	return newRowsWithContext(ctx, *res, s), nil
 }
 
 // This method executes the query in steps and checks if context is cancelled before executing each step.
 // It uses Pending Result Interface C APIs to achieve this. Reference - https://duckdb.org/docs/api/c/api#pending-result-interface
-func (s *Stmt) execute(ctx context.Context, args []driver.NamedValue) (*mapping.Result, error) {
+// If streaming is true, the result is a streaming result, whose chunks must be fetched with fetchChunk.
+func (s *Stmt) execute(ctx context.Context, args []driver.NamedValue, streaming bool) (*mapping.Result, error) {
 	if s.closed {
 		panic("database/sql/driver: misuse of duckdb driver: ExecContext or QueryContext after Close")
 	}
@@ -388,13 +621,23 @@ func (s *Stmt) execute(ctx context.Context, args []driver.NamedValue) (*mapping.
 	if err := s.bind(args); err != nil {
 		return nil, err
 	}
-	return s.executeBound(ctx)
+	return s.executeBound(ctx, streaming)
 }
 
-func (s *Stmt) executeBound(ctx context.Context) (*mapping.Result, error) {
+func (s *Stmt) executeBound(ctx context.Context, streaming bool) (*mapping.Result, error) {
+	if _, ok := ctx.Deadline(); !ok && s.conn.defaultQueryTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.conn.defaultQueryTimeout)
+		defer cancel()
+	}
+
 	var pendingRes mapping.PendingResult
-	if mapping.PendingPrepared(*s.preparedStmt, &pendingRes) == mapping.StateError {
-		dbErr := getDuckDBError(mapping.PendingError(pendingRes))
+	pendingPrepared := mapping.PendingPrepared
+	if streaming {
+		pendingPrepared = pendingPreparedStreaming
+	}
+	if pendingPrepared(*s.preparedStmt, &pendingRes) == mapping.StateError {
+		dbErr := s.conn.getDuckDBError(mapping.PendingError(pendingRes))
 		mapping.DestroyPending(&pendingRes)
 		return nil, dbErr
 	}
@@ -414,6 +657,7 @@ func (s *Stmt) executeBound(ctx context.Context) (*mapping.Result, error) {
 		}
 	}()
 
+	executeTasksWithProgress(ctx, s.conn.conn, pendingRes)
 	var res mapping.Result
 	state := mapping.ExecutePending(pendingRes, &res)
 	close(mainDoneCh)
@@ -427,7 +671,7 @@ func (s *Stmt) executeBound(ctx context.Context) (*mapping.Result, error) {
 			return nil, ctx.Err()
 		}
 
-		err := getDuckDBError(mapping.ResultError(&res))
+		err := s.conn.getDuckDBError(mapping.ResultError(&res))
 		mapping.DestroyResult(&res)
 		return nil, err
 	}
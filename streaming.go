@@ -0,0 +1,58 @@
+package duckdb
+
+/*
+#include <stdint.h>
+
+// The bindings do not wrap DuckDB's streaming result interface yet, so we declare it here.
+// streaming_result has the layout of duckdb_result.
+typedef struct {
+	uint64_t deprecated_column_count;
+	uint64_t deprecated_row_count;
+	uint64_t deprecated_rows_changed;
+	void *deprecated_columns;
+	char *deprecated_error_message;
+	void *internal_data;
+} streaming_result;
+
+int duckdb_pending_prepared_streaming(void *prepared_statement, void **out_result);
+void *duckdb_fetch_chunk(streaming_result result);
+*/
+import "C"
+
+import (
+	"context"
+	"unsafe"
+
+	"github.com/marcboeker/go-duckdb/mapping"
+)
+
+type streamingResultsContextKey struct{}
+
+// WithStreamingResults returns a context streaming the results of the queries executed with it.
+// Instead of materializing the entire result before returning the first row,
+// DuckDB then produces the rows incrementally while the caller iterates over them.
+// The connection must not execute other queries until the rows are closed,
+// as DuckDB closes the streaming result of a connection when it executes another query.
+func WithStreamingResults(ctx context.Context) context.Context {
+	return context.WithValue(ctx, streamingResultsContextKey{}, true)
+}
+
+func streamingResults(ctx context.Context) bool {
+	streaming, _ := ctx.Value(streamingResultsContextKey{}).(bool)
+	return streaming
+}
+
+// pendingPreparedStreaming wraps duckdb_pending_prepared_streaming.
+func pendingPreparedStreaming(preparedStmt mapping.PreparedStatement, outPendingRes *mapping.PendingResult) mapping.State {
+	var pendingRes unsafe.Pointer
+	state := C.duckdb_pending_prepared_streaming(preparedStmt.Ptr, &pendingRes)
+	outPendingRes.Ptr = pendingRes
+	return mapping.State(state)
+}
+
+// fetchChunk wraps duckdb_fetch_chunk.
+// It returns a chunk with a nil pointer, if the result is exhausted or has an error.
+func fetchChunk(res *mapping.Result) mapping.DataChunk {
+	chunk := C.duckdb_fetch_chunk(*(*C.streaming_result)(unsafe.Pointer(res)))
+	return mapping.DataChunk{Ptr: chunk}
+}
@@ -0,0 +1,74 @@
+package duckdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamingResults(t *testing.T) {
+	db := openDbWrapper(t, ``)
+	defer closeDbWrapper(t, db)
+	ctx := WithStreamingResults(context.Background())
+
+	t.Run("all rows", func(t *testing.T) {
+		r, err := db.QueryContext(ctx, `SELECT i, i::VARCHAR FROM range(100000) t(i) ORDER BY i`)
+		require.NoError(t, err)
+		defer closeRowsWrapper(t, r)
+
+		var i int64
+		for r.Next() {
+			var n int64
+			var s string
+			require.NoError(t, r.Scan(&n, &s))
+			require.Equal(t, i, n)
+			i++
+		}
+		require.NoError(t, r.Err())
+		require.Equal(t, int64(100000), i)
+	})
+
+	t.Run("rows before an error", func(t *testing.T) {
+		// A materialized result fails before returning the first row.
+		query := `SELECT CASE WHEN i < 5000000 THEN i ELSE error('late error') END FROM range(10000000) t(i)`
+		_, err := db.QueryContext(context.Background(), query)
+		require.ErrorContains(t, err, "late error")
+
+		r, err := db.QueryContext(ctx, query)
+		require.NoError(t, err)
+		defer closeRowsWrapper(t, r)
+
+		require.True(t, r.Next())
+		var i int64
+		require.NoError(t, r.Scan(&i))
+		require.Equal(t, int64(0), i)
+		for r.Next() {
+		}
+		require.ErrorContains(t, r.Err(), "late error")
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		cancelCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		r, err := db.QueryContext(cancelCtx, `SELECT i FROM range(100000000) t(i)`)
+		require.NoError(t, err)
+		defer closeRowsWrapper(t, r)
+
+		require.True(t, r.Next())
+		cancel()
+		for r.Next() {
+		}
+		require.ErrorIs(t, r.Err(), context.Canceled)
+	})
+
+	t.Run("exec", func(t *testing.T) {
+		_, err := db.ExecContext(ctx, `CREATE TABLE streamed (i INTEGER)`)
+		require.NoError(t, err)
+		res, err := db.ExecContext(ctx, `INSERT INTO streamed SELECT * FROM range(10)`)
+		require.NoError(t, err)
+		n, err := res.RowsAffected()
+		require.NoError(t, err)
+		require.Equal(t, int64(10), n)
+	})
+}
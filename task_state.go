@@ -0,0 +1,119 @@
+package duckdb
+
+/*
+#include <stdbool.h>
+#include <stdint.h>
+
+// The bindings do not wrap DuckDB's task state interface yet, so we declare it here.
+void *duckdb_create_task_state(void *database);
+uint64_t duckdb_execute_n_tasks_state(void *state, uint64_t max_tasks);
+void duckdb_finish_execution(void *state);
+bool duckdb_task_state_is_finished(void *state);
+void duckdb_destroy_task_state(void *state);
+*/
+import "C"
+
+import (
+	"context"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+const (
+	// taskBatchSize is the maximum number of tasks that ExecuteTasks executes between checking its context.
+	taskBatchSize = 64
+	// taskIdleWait is the time that ExecuteTasks waits before checking for new tasks, if there are none.
+	taskIdleWait = time.Millisecond
+)
+
+// TaskState executes the tasks of a database's queries on application goroutines,
+// e.g., to bound DuckDB's concurrency with the workers of an existing pool.
+// Open the database with threads=1, so that DuckDB does not start any background threads,
+// and call ExecuteTasks from as many goroutines as should execute tasks concurrently.
+// The goroutine executing a query also executes its tasks.
+// It is the caller's responsibility to close the TaskState before closing the Connector.
+type TaskState struct {
+	mu      sync.Mutex
+	state   unsafe.Pointer
+	running sync.WaitGroup
+	closed  bool
+}
+
+// NewTaskState creates a TaskState for the database of the Connector.
+func (c *Connector) NewTaskState() (*TaskState, error) {
+	if c.closed {
+		return nil, errClosedConnector
+	}
+	return &TaskState{state: C.duckdb_create_task_state(c.db.Ptr)}, nil
+}
+
+// ExecuteTasks executes the database's tasks on the calling goroutine,
+// until Finish or Close is called, or the context is done.
+// Multiple goroutines can execute the tasks of a TaskState concurrently.
+// It returns the context's error, if the context is done.
+func (s *TaskState) ExecuteTasks(ctx context.Context) error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return errClosedTaskState
+	}
+	s.running.Add(1)
+	s.mu.Unlock()
+	defer s.running.Done()
+
+	timer := time.NewTimer(taskIdleWait)
+	defer timer.Stop()
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if bool(C.duckdb_task_state_is_finished(s.state)) {
+			return nil
+		}
+		if C.duckdb_execute_n_tasks_state(s.state, taskBatchSize) != 0 {
+			continue
+		}
+
+		// Wait for new tasks.
+		timer.Reset(taskIdleWait)
+		select {
+		case <-ctx.Done():
+		case <-timer.C:
+		}
+	}
+}
+
+// Finish stops the execution of tasks, i.e., ExecuteTasks returns after finishing its current tasks.
+func (s *TaskState) Finish() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.closed {
+		C.duckdb_finish_execution(s.state)
+	}
+}
+
+// Finished returns true, if Finish or Close have been called.
+func (s *TaskState) Finished() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.closed || bool(C.duckdb_task_state_is_finished(s.state))
+}
+
+// Close finishes the execution of tasks, waits for the running ExecuteTasks calls to return,
+// and releases the TaskState.
+func (s *TaskState) Close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	C.duckdb_finish_execution(s.state)
+	s.mu.Unlock()
+
+	s.running.Wait()
+	C.duckdb_destroy_task_state(s.state)
+	s.state = nil
+	return nil
+}
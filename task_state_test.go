@@ -0,0 +1,62 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskState(t *testing.T) {
+	c := newConnectorWrapper(t, `?threads=1`, nil)
+	defer closeConnectorWrapper(t, c)
+	db := sql.OpenDB(c)
+	defer closeDbWrapper(t, db)
+
+	s, err := c.NewTaskState()
+	require.NoError(t, err)
+
+	const workers = 4
+	errCh := make(chan error, workers)
+	for range workers {
+		go func() {
+			errCh <- s.ExecuteTasks(context.Background())
+		}()
+	}
+
+	for i := range 3 {
+		var count, sum int64
+		err = db.QueryRow(`SELECT count(*), sum(i % 7) FROM (SELECT range + ? AS i FROM range(5000000))`, i).Scan(&count, &sum)
+		require.NoError(t, err)
+		require.Equal(t, int64(5000000), count)
+
+		var expected int64
+		for j := range int64(5000000) {
+			expected += (j + int64(i)) % 7
+		}
+		require.Equal(t, expected, sum)
+	}
+
+	require.False(t, s.Finished())
+	s.Finish()
+	require.True(t, s.Finished())
+	for range workers {
+		require.NoError(t, <-errCh)
+	}
+	require.NoError(t, s.Close())
+	require.ErrorIs(t, s.ExecuteTasks(context.Background()), errClosedTaskState)
+
+	// ExecuteTasks returns once its context is done.
+	s, err = c.NewTaskState()
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		errCh <- s.ExecuteTasks(ctx)
+	}()
+	cancel()
+	require.ErrorIs(t, <-errCh, context.Canceled)
+	require.False(t, s.Finished())
+	require.NoError(t, s.Close())
+	require.True(t, s.Finished())
+}
@@ -0,0 +1,104 @@
+package duckdb
+
+import (
+	"reflect"
+	"sync"
+)
+
+// TypeHandler converts values of the Go type T to and from the values of go-duckdb.
+// Either function can be nil, in which case go-duckdb converts the values of T as usual.
+type TypeHandler[T any] struct {
+	// Bind converts a T into a value that go-duckdb binds and appends, e.g., a string or an int64.
+	// The value must not be a T.
+	Bind func(v T) (any, error)
+	// Scan converts a value that go-duckdb scans, e.g., a Decimal or a time.Time, into a T.
+	// The value is nil for NULL values.
+	Scan func(v any) (T, error)
+}
+
+// typeHandlers maps the Go types registered with RegisterTypeHandler to their handlers.
+var typeHandlers sync.Map
+
+type typeHandler struct {
+	bind func(v any) (any, error)
+	scan func(v any) (any, error)
+}
+
+// RegisterTypeHandler registers the handler converting the values of the Go type T.
+// go-duckdb consults the handler before its default conversions when binding and appending T values,
+// including T values nested in slices and structs. It also consults the handler when scanning into T
+// with Composite, StrictComposite, TypedMap, and Union, and when scanning into *T with Go 1.27 or later.
+// T cannot be a predeclared type, []byte, or time.Time. Registering T again replaces its handler.
+// It is safe for concurrent use.
+func RegisterTypeHandler[T any](handler TypeHandler[T]) error {
+	t := reflect.TypeFor[T]()
+	if (t.PkgPath() == "" && t.Name() != "") || t == reflectTypeBytes || t == reflectTypeTime {
+		return getError(errRegisterTypeHandler, unsupportedTypeError(t.String()))
+	}
+
+	var h typeHandler
+	if handler.Bind != nil {
+		h.bind = func(v any) (any, error) {
+			return handler.Bind(v.(T))
+		}
+	}
+	if handler.Scan != nil {
+		h.scan = func(v any) (any, error) {
+			return handler.Scan(v)
+		}
+	}
+	typeHandlers.Store(t, h)
+	return nil
+}
+
+// handlerValue converts a value with the Bind function of the handler registered for its type.
+// It returns false, if there is no such handler.
+func handlerValue(val any) (any, bool, error) {
+	if val == nil {
+		return nil, false, nil
+	}
+	h, ok := typeHandlers.Load(reflect.TypeOf(val))
+	if !ok || h.(typeHandler).bind == nil {
+		return nil, false, nil
+	}
+	v, err := h.(typeHandler).bind(val)
+	if err != nil {
+		return nil, true, err
+	}
+	if reflect.TypeOf(v) == reflect.TypeOf(val) {
+		return nil, true, invalidInputError(reflect.TypeOf(val).String(), "a value of another type from TypeHandler.Bind")
+	}
+	return v, true, nil
+}
+
+// handlerScan returns the Scan function of the handler registered for the type t.
+func handlerScan(t reflect.Type) (func(v any) (any, error), bool) {
+	h, ok := typeHandlers.Load(t)
+	if !ok || h.(typeHandler).scan == nil {
+		return nil, false
+	}
+	return h.(typeHandler).scan, true
+}
+
+// scanWithHandler converts a value with the Scan function of a handler, and stores it in the non-nil pointer dest.
+func scanWithHandler(scan func(v any) (any, error), val any, dest any) error {
+	v, err := scan(val)
+	if err != nil {
+		return err
+	}
+	elem := reflect.ValueOf(dest).Elem()
+	if v == nil {
+		elem.SetZero()
+	} else {
+		elem.Set(reflect.ValueOf(v))
+	}
+	return nil
+}
+
+// typeHandlerHook is a mapstructure decode hook converting values with the Scan functions of the registered handlers.
+func typeHandlerHook(_ reflect.Type, to reflect.Type, data any) (any, error) {
+	if scan, ok := handlerScan(to); ok {
+		return scan(data)
+	}
+	return data, nil
+}
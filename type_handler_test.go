@@ -0,0 +1,99 @@
+package duckdb
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testAccountID int64
+
+func registerTestAccountID(t *testing.T) {
+	require.NoError(t, RegisterTypeHandler(TypeHandler[testAccountID]{
+		Bind: func(id testAccountID) (any, error) {
+			return fmt.Sprintf("user-%d", id), nil
+		},
+		Scan: func(v any) (testAccountID, error) {
+			str, ok := v.(string)
+			if !ok {
+				return 0, fmt.Errorf("cannot scan %T into testAccountID", v)
+			}
+			id, err := strconv.ParseInt(strings.TrimPrefix(str, "user-"), 10, 64)
+			return testAccountID(id), err
+		},
+	}))
+	t.Cleanup(func() {
+		typeHandlers.Delete(reflect.TypeFor[testAccountID]())
+	})
+}
+
+func TestRegisterTypeHandler(t *testing.T) {
+	db := openDbWrapper(t, ``)
+	defer closeDbWrapper(t, db)
+	registerTestAccountID(t)
+
+	createTable(t, db, `CREATE TABLE users (id VARCHAR, friends VARCHAR[], info STRUCT(owner VARCHAR, since DATE))`)
+
+	type info struct {
+		Owner testAccountID
+		Since time.Time
+	}
+	since := time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("bind", func(t *testing.T) {
+		_, err := db.Exec(`INSERT INTO users VALUES (?, NULL, NULL)`, testAccountID(1))
+		require.NoError(t, err)
+
+		var id string
+		require.NoError(t, db.QueryRow(`SELECT id FROM users WHERE id = ?`, testAccountID(1)).Scan(&id))
+		require.Equal(t, "user-1", id)
+	})
+
+	t.Run("append", func(t *testing.T) {
+		conn := openConnWrapper(t, db, context.Background())
+		defer closeConnWrapper(t, conn)
+
+		err := conn.Raw(func(driverConn any) error {
+			a, err := NewAppenderFromConn(driverConn.(*Conn), "", "users")
+			require.NoError(t, err)
+			require.NoError(t, a.AppendRow(testAccountID(2), []testAccountID{1, 3}, info{Owner: 1, Since: since}))
+			return a.Close()
+		})
+		require.NoError(t, err)
+
+		var friends Composite[[]testAccountID]
+		var owner Composite[info]
+		require.NoError(t, db.QueryRow(`SELECT friends, info FROM users WHERE id = 'user-2'`).Scan(&friends, &owner))
+		require.Equal(t, []testAccountID{1, 3}, friends.Get())
+		require.Equal(t, info{Owner: 1, Since: since}, owner.Get())
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		err := RegisterTypeHandler(TypeHandler[string]{})
+		testError(t, err, errRegisterTypeHandler.Error(), unsupportedTypeErrMsg)
+		err = RegisterTypeHandler(TypeHandler[time.Time]{})
+		testError(t, err, errRegisterTypeHandler.Error(), unsupportedTypeErrMsg)
+
+		type loop int
+		require.NoError(t, RegisterTypeHandler(TypeHandler[loop]{
+			Bind: func(v loop) (any, error) { return v, nil },
+		}))
+		defer typeHandlers.Delete(reflect.TypeFor[loop]())
+		_, err = db.Exec(`INSERT INTO users VALUES (?, NULL, NULL)`, loop(1))
+		require.ErrorContains(t, err, invalidInputErrMsg)
+
+		type failing int
+		require.NoError(t, RegisterTypeHandler(TypeHandler[failing]{
+			Bind: func(failing) (any, error) { return nil, fmt.Errorf("cannot bind failing") },
+		}))
+		defer typeHandlers.Delete(reflect.TypeFor[failing]())
+		_, err = db.Exec(`INSERT INTO users VALUES (?, NULL, NULL)`, failing(1))
+		require.ErrorContains(t, err, "cannot bind failing")
+	})
+}
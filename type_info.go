@@ -101,7 +101,7 @@ func NewTypeInfo(t Type) (TypeInfo, error) {
 		return nil, getError(errAPI, tryOtherFuncError(funcName(NewMapInfo)))
 	case TYPE_ARRAY:
 		return nil, getError(errAPI, tryOtherFuncError(funcName(NewArrayInfo)))
-	case TYPE_SQLNULL:
+	case TYPE_SQLNULL, TYPE_UNION:
 		return nil, getError(errAPI, unsupportedTypeError(typeToStringMap[t]))
 	}
 
@@ -54,7 +54,7 @@ func getTypeInfos(t *testing.T, useAny bool) []testTypeInfo {
 			continue
 		}
 		switch k {
-		case TYPE_DECIMAL, TYPE_ENUM, TYPE_LIST, TYPE_STRUCT, TYPE_MAP, TYPE_ARRAY, TYPE_SQLNULL:
+		case TYPE_DECIMAL, TYPE_ENUM, TYPE_LIST, TYPE_STRUCT, TYPE_MAP, TYPE_ARRAY, TYPE_SQLNULL, TYPE_UNION:
 			continue
 		}
 		primitiveTypes = append(primitiveTypes, k)
@@ -214,7 +214,7 @@ func TestErrTypeInfo(t *testing.T) {
 			unsupportedTypes = append(unsupportedTypes, k)
 		}
 	}
-	unsupportedTypes = append(unsupportedTypes, TYPE_SQLNULL)
+	unsupportedTypes = append(unsupportedTypes, TYPE_SQLNULL, TYPE_UNION)
 
 	for _, unsupported := range unsupportedTypes {
 		_, err := NewTypeInfo(unsupported)
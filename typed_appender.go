@@ -0,0 +1,176 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql/driver"
+	"reflect"
+	"unsafe"
+
+	"github.com/marcboeker/go-duckdb/mapping"
+)
+
+// TypedAppender appends Go structs of type T to a table.
+// It maps the struct fields to the table's columns once, when creating the TypedAppender,
+// and then writes each field directly into the appender's data chunks.
+type TypedAppender[T any] struct {
+	a       *Appender
+	setters []fieldSetter
+}
+
+// fieldSetter writes the field of a struct, pointed to by row, to a vector.
+type fieldSetter func(vec *vector, rowIdx mapping.IdxT, row unsafe.Pointer) error
+
+// NewTypedAppender returns a new TypedAppender from a DuckDB driver connection.
+// T must be a struct. Each exported field maps to the table column with the same name, ignoring case.
+// The `duckdb` or `db` struct tag overrides the column name, and `db:"-"` skips a field.
+// Columns without a field are filled with their DEFAULT value, or NULL, see WithColumns.
+// Nil pointer fields are appended as NULL values.
+func NewTypedAppender[T any](driverConn driver.Conn, catalog, schema, table string, opts ...AppenderOption) (*TypedAppender[T], error) {
+	t := reflect.TypeFor[T]()
+	columns, err := structColumns(t)
+	if err != nil {
+		return nil, getError(errAppenderCreation, err)
+	}
+
+	names := make([]string, len(columns))
+	for i, c := range columns {
+		names[i] = c.name
+	}
+	a, err := NewAppenderWithOptions(driverConn, catalog, schema, table, append(opts, WithColumns(names...))...)
+	if err != nil {
+		return nil, err
+	}
+
+	setters := make([]fieldSetter, len(columns))
+	for i, c := range columns {
+		field := t.FieldByIndex(c.index)
+		physicalType := Type(mapping.GetTypeId(a.types[i]))
+		setters[i] = newFieldSetter(field.Type, field.Offset, physicalType)
+	}
+	return &TypedAppender[T]{a: a, setters: setters}, nil
+}
+
+// NewAppenderForType creates the table from the fields of T, if it does not exist yet,
+// and returns a new TypedAppender for it. The column types follow the Go types of the fields,
+// like for BulkLoad with CreateTable. An existing table is not altered.
+func NewAppenderForType[T any](driverConn driver.Conn, catalog, schema, table string, opts ...AppenderOption) (*TypedAppender[T], error) {
+	conn, ok := driverConn.(*Conn)
+	if !ok {
+		return nil, getError(errInvalidCon, nil)
+	}
+	if conn.closed {
+		return nil, getError(errClosedCon, nil)
+	}
+
+	columns, err := structColumns(reflect.TypeFor[T]())
+	if err != nil {
+		return nil, getError(errAppenderCreation, err)
+	}
+	if _, err = conn.ExecContext(context.Background(), createTableQuery(catalog, schema, table, columns), nil); err != nil {
+		return nil, getError(errAppenderCreation, err)
+	}
+	return NewTypedAppender[T](driverConn, catalog, schema, table, opts...)
+}
+
+// Append loads the rows into the appender.
+func (t *TypedAppender[T]) Append(rows ...T) error {
+	a := t.a
+	if a.closed {
+		return getError(errAppenderAppendAfterClose, nil)
+	}
+
+	for i := range rows {
+		chunk, err := a.rowChunk()
+		if err != nil {
+			return getError(errAppenderAppendRow, err)
+		}
+		row := unsafe.Pointer(&rows[i])
+		for j, set := range t.setters {
+			if err = set(&chunk.columns[j], mapping.IdxT(a.rowCount), row); err != nil {
+				a.clearRow(chunk)
+				return getError(errAppenderAppendRow, err)
+			}
+		}
+		a.rowCount++
+
+		if err = a.autoFlush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Flush the appended rows to the table. See Appender.Flush.
+func (t *TypedAppender[T]) Flush() error {
+	return t.a.Flush()
+}
+
+// FlushContext flushes the appended rows to the table. See Appender.FlushContext.
+func (t *TypedAppender[T]) FlushContext(ctx context.Context) error {
+	return t.a.FlushContext(ctx)
+}
+
+// Close the appender. See Appender.Close.
+func (t *TypedAppender[T]) Close() error {
+	return t.a.Close()
+}
+
+// CloseContext closes the appender. See Appender.CloseContext.
+func (t *TypedAppender[T]) CloseContext(ctx context.Context) error {
+	return t.a.CloseContext(ctx)
+}
+
+func newFieldSetter(fieldType reflect.Type, offset uintptr, physicalType Type) fieldSetter {
+	switch fieldType.Kind() {
+	case reflect.Bool:
+		return newPrimitiveFieldSetter[bool](offset, physicalType)
+	case reflect.Int8:
+		return newPrimitiveFieldSetter[int8](offset, physicalType)
+	case reflect.Int16:
+		return newPrimitiveFieldSetter[int16](offset, physicalType)
+	case reflect.Int32:
+		return newPrimitiveFieldSetter[int32](offset, physicalType)
+	case reflect.Int64:
+		return newPrimitiveFieldSetter[int64](offset, physicalType)
+	case reflect.Int:
+		return newPrimitiveFieldSetter[int](offset, physicalType)
+	case reflect.Uint8:
+		return newPrimitiveFieldSetter[uint8](offset, physicalType)
+	case reflect.Uint16:
+		return newPrimitiveFieldSetter[uint16](offset, physicalType)
+	case reflect.Uint32:
+		return newPrimitiveFieldSetter[uint32](offset, physicalType)
+	case reflect.Uint64:
+		return newPrimitiveFieldSetter[uint64](offset, physicalType)
+	case reflect.Uint:
+		return newPrimitiveFieldSetter[uint](offset, physicalType)
+	case reflect.Float32:
+		return newPrimitiveFieldSetter[float32](offset, physicalType)
+	case reflect.Float64:
+		return newPrimitiveFieldSetter[float64](offset, physicalType)
+	case reflect.String:
+		return newPrimitiveFieldSetter[string](offset, physicalType)
+	}
+
+	// Fall back to reflection for all other types, e.g., pointers, slices, and structs.
+	return func(vec *vector, rowIdx mapping.IdxT, row unsafe.Pointer) error {
+		v := reflect.NewAt(fieldType, unsafe.Add(row, offset)).Elem()
+		val, err := structColumnValue(v)
+		if err != nil {
+			return err
+		}
+		return vec.setFn(vec, rowIdx, val)
+	}
+}
+
+func newPrimitiveFieldSetter[F any](offset uintptr, physicalType Type) fieldSetter {
+	if isPhysicalType[F](physicalType) {
+		return func(vec *vector, rowIdx mapping.IdxT, row unsafe.Pointer) error {
+			setPrimitive(vec, rowIdx, *(*F)(unsafe.Add(row, offset)))
+			return nil
+		}
+	}
+	return func(vec *vector, rowIdx mapping.IdxT, row unsafe.Pointer) error {
+		return vec.setFn(vec, rowIdx, *(*F)(unsafe.Add(row, offset)))
+	}
+}
@@ -0,0 +1,179 @@
+package duckdb
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type typedAppenderStatus string
+
+type typedAppenderRow struct {
+	ID      int64
+	Score   float64
+	Small   int16
+	Name    string
+	Status  typedAppenderStatus
+	Created time.Time
+	Comment *string `db:"note"`
+	Tags    []string
+	skipped int
+	Ignored int `db:"-"`
+}
+
+func TestTypedAppender(t *testing.T) {
+	c := newConnectorWrapper(t, ``, nil)
+	defer closeConnectorWrapper(t, c)
+	db := sql.OpenDB(c)
+	defer closeDbWrapper(t, db)
+	conn := openDriverConnWrapper(t, c)
+	defer closeDriverConnWrapper(t, &conn)
+
+	createTable(t, db, `CREATE TABLE test (
+		inserted VARCHAR DEFAULT 'default',
+		tags VARCHAR[],
+		note VARCHAR,
+		created TIMESTAMP,
+		status VARCHAR,
+		name VARCHAR,
+		small INTEGER,
+		score DOUBLE,
+		id BIGINT
+	)`)
+
+	a, err := NewTypedAppender[typedAppenderRow](conn, "", "", "test")
+	require.NoError(t, err)
+
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	comment := "comment"
+	rows := make([]typedAppenderRow, GetDataChunkCapacity()+10)
+	for i := range rows {
+		rows[i] = typedAppenderRow{ID: int64(i), Score: float64(i) / 2, Small: int16(i % 10), Name: "name", Status: "active", Created: ts, skipped: i, Ignored: i}
+		if i%2 == 0 {
+			rows[i].Comment = &comment
+			rows[i].Tags = []string{"a", "b"}
+		}
+	}
+	require.NoError(t, a.Append(rows...))
+	require.NoError(t, a.Append(typedAppenderRow{ID: -1}))
+	require.NoError(t, a.Close())
+	testError(t, a.Append(typedAppenderRow{}), errAppenderAppendAfterClose.Error())
+
+	var (
+		count, idSum, notes int64
+		inserted            string
+	)
+	row := db.QueryRow(`SELECT count(*), sum(id)::BIGINT, count(note), any_value(inserted) FROM test`)
+	require.NoError(t, row.Scan(&count, &idSum, &notes, &inserted))
+	require.Equal(t, int64(len(rows)+1), count)
+	require.Equal(t, int64(len(rows)*(len(rows)-1)/2-1), idSum)
+	require.Equal(t, int64(len(rows)/2), notes)
+	require.Equal(t, "default", inserted)
+
+	var (
+		score   float64
+		small   int32
+		name    string
+		status  string
+		created time.Time
+		note    string
+		tags    Composite[[]string]
+	)
+	row = db.QueryRow(`SELECT score, small, name, status, created, note, tags FROM test WHERE id = 12`)
+	require.NoError(t, row.Scan(&score, &small, &name, &status, &created, &note, &tags))
+	require.Equal(t, 6.0, score)
+	require.Equal(t, int32(2), small)
+	require.Equal(t, "name", name)
+	require.Equal(t, "active", status)
+	require.Equal(t, ts, created)
+	require.Equal(t, "comment", note)
+	require.Equal(t, []string{"a", "b"}, tags.Get())
+
+	// The struct fields must match the table's columns.
+	type unknownField struct {
+		Unknown int64
+	}
+	_, err = NewTypedAppender[unknownField](conn, "", "", "test")
+	testError(t, err, errAppenderCreation.Error())
+	_, err = NewTypedAppender[int](conn, "", "", "test")
+	testError(t, err, errAppenderCreation.Error(), castErrMsg)
+}
+
+func TestNewAppenderForType(t *testing.T) {
+	c := newConnectorWrapper(t, ``, nil)
+	defer closeConnectorWrapper(t, c)
+	db := sql.OpenDB(c)
+	defer closeDbWrapper(t, db)
+	conn := openDriverConnWrapper(t, c)
+	defer closeDriverConnWrapper(t, &conn)
+
+	type logEntry struct {
+		ID      int64
+		Level   typedAppenderStatus
+		Message string `duckdb:"msg"`
+		Created time.Time
+		Attrs   []string
+		hidden  int
+	}
+
+	for i := 0; i < 2; i++ {
+		// The second iteration appends to the existing table.
+		a, err := NewAppenderForType[logEntry](conn, "", "", "logs")
+		require.NoError(t, err)
+		require.NoError(t, a.Append(logEntry{ID: int64(i), Level: "info", Message: "started", Attrs: []string{"a"}}))
+		require.NoError(t, a.Close())
+	}
+
+	res, err := db.Query(`SELECT column_name, data_type FROM duckdb_columns() WHERE table_name = 'logs' ORDER BY column_index`)
+	require.NoError(t, err)
+	defer closeRowsWrapper(t, res)
+
+	var columns [][2]string
+	for res.Next() {
+		var column [2]string
+		require.NoError(t, res.Scan(&column[0], &column[1]))
+		columns = append(columns, column)
+	}
+	require.NoError(t, res.Err())
+	require.Equal(t, [][2]string{
+		{"ID", "BIGINT"},
+		{"Level", "VARCHAR"},
+		{"msg", "VARCHAR"},
+		{"Created", "TIMESTAMP"},
+		{"Attrs", "VARCHAR[]"},
+	}, columns)
+
+	var count int
+	require.NoError(t, db.QueryRow(`SELECT count(*) FROM logs WHERE msg = 'started'`).Scan(&count))
+	require.Equal(t, 2, count)
+
+	// Types without a column mapping fail.
+	_, err = NewAppenderForType[int](conn, "", "", "ints")
+	testError(t, err, errAppenderCreation.Error(), castErrMsg)
+}
+
+func BenchmarkTypedAppender(b *testing.B) {
+	c, db, conn, a := prepareAppender(b, `CREATE TABLE test (id BIGINT, score DOUBLE, name VARCHAR)`)
+	defer cleanupAppender(b, c, db, conn, a)
+
+	type benchRow struct {
+		ID    int64
+		Score float64
+		Name  string
+	}
+	typed, err := NewTypedAppender[benchRow](conn, "", "", "test")
+	require.NoError(b, err)
+	defer func() {
+		require.NoError(b, typed.Close())
+	}()
+
+	rows := make([]benchRow, 10000)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		if err = typed.Append(rows...); err != nil {
+			b.Error(err)
+		}
+	}
+}
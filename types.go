@@ -1,12 +1,20 @@
 package duckdb
 
 import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
 	"encoding/binary"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"math"
 	"math/big"
 	"reflect"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/marcboeker/go-duckdb/mapping"
@@ -62,14 +70,19 @@ func (u *UUID) String() string {
 // The value is computed as: upper * 2^64 + lower
 
 func hugeIntToUUID(hugeInt *mapping.HugeInt) []byte {
-	// Flip the sign bit of the signed hugeint to transform it to UUID bytes.
 	var val [uuidLength]byte
-	lower, upper := mapping.HugeIntMembers(hugeInt)
-	binary.BigEndian.PutUint64(val[:8], uint64(upper)^1<<63)
-	binary.BigEndian.PutUint64(val[8:], lower)
+	putUUID(val[:], hugeInt)
 	return val[:]
 }
 
+// putUUID writes the UUID bytes of hugeInt into dst, which must hold at least uuidLength bytes.
+func putUUID(dst []byte, hugeInt *mapping.HugeInt) {
+	// Flip the sign bit of the signed hugeint to transform it to UUID bytes.
+	lower, upper := mapping.HugeIntMembers(hugeInt)
+	binary.BigEndian.PutUint64(dst[:8], uint64(upper)^1<<63)
+	binary.BigEndian.PutUint64(dst[8:], lower)
+}
+
 func uuidToHugeInt(uuid UUID) *mapping.HugeInt {
 	// Flip the sign bit.
 	lower := binary.BigEndian.Uint64(uuid[8:])
@@ -77,6 +90,13 @@ func uuidToHugeInt(uuid UUID) *mapping.HugeInt {
 	return mapping.NewHugeInt(lower, int64(upper^(1<<63)))
 }
 
+func uuidToUHugeInt(uuid UUID) *mapping.UHugeInt {
+	// The unsigned representation is the big-endian UUID without a flipped sign bit.
+	lower := binary.BigEndian.Uint64(uuid[8:])
+	upper := binary.BigEndian.Uint64(uuid[:8])
+	return mapping.NewUHugeInt(lower, upper)
+}
+
 func hugeIntToNative(hugeInt *mapping.HugeInt) *big.Int {
 	lower, upper := mapping.HugeIntMembers(hugeInt)
 	i := big.NewInt(upper)
@@ -103,6 +123,9 @@ func hugeIntFromNative(i *big.Int) (*mapping.HugeInt, error) {
 type Map map[any]any
 
 func (m *Map) Scan(v any) error {
+	if ordered, ok := v.(OrderedMap); ok {
+		v = ordered.Map()
+	}
 	data, ok := v.(Map)
 	if !ok {
 		return fmt.Errorf("invalid type `%T` for scanning `Map`, expected `Map`", data)
@@ -112,6 +135,95 @@ func (m *Map) Scan(v any) error {
 	return nil
 }
 
+// MapEntry is an entry of a MAP value.
+type MapEntry struct {
+	Key   any
+	Value any
+}
+
+// OrderedMap is a MAP value that keeps the order of its entries. See WithOrderedMaps.
+type OrderedMap []MapEntry
+
+func (m *OrderedMap) Scan(v any) error {
+	data, ok := v.(OrderedMap)
+	if !ok {
+		return fmt.Errorf("invalid type `%T` for scanning `OrderedMap`, expected `OrderedMap`, see WithOrderedMaps", v)
+	}
+
+	*m = data
+	return nil
+}
+
+// Map returns the entries as a Map. Later entries overwrite earlier entries with the same key.
+func (m OrderedMap) Map() Map {
+	data := make(Map, len(m))
+	for _, entry := range m {
+		data[entry.Key] = entry.Value
+	}
+	return data
+}
+
+// MarshalJSON marshals the entries to a JSON object in their order. It formats the keys with fmt.Sprint.
+func (m OrderedMap) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, entry := range m {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		key, err := json.Marshal(fmt.Sprint(entry.Key))
+		if err != nil {
+			return nil, err
+		}
+		val, err := json.Marshal(entry.Value)
+		if err != nil {
+			return nil, err
+		}
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(val)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// TypedMap scans a MAP value into a map with typed keys and values.
+// It converts the keys and values like Composite. A NULL value scans into a nil map.
+type TypedMap[K comparable, V any] map[K]V
+
+func (m *TypedMap[K, V]) Scan(v any) error {
+	return m.scanComposite(v, compositeOptions{})
+}
+
+func (m *TypedMap[K, V]) scanComposite(v any, opts compositeOptions) error {
+	if v == nil {
+		*m = nil
+		return nil
+	}
+	if ordered, ok := v.(OrderedMap); ok {
+		v = ordered.Map()
+	}
+	data, ok := v.(Map)
+	if !ok {
+		return fmt.Errorf("invalid type `%T` for scanning `TypedMap`, expected `Map`", v)
+	}
+
+	typed := make(TypedMap[K, V], len(data))
+	for key, val := range data {
+		var k K
+		if err := decodeComposite(key, &k, false, opts); err != nil {
+			return err
+		}
+		var value V
+		if err := decodeComposite(val, &value, false, opts); err != nil {
+			return err
+		}
+		typed[k] = value
+	}
+	*m = typed
+	return nil
+}
+
 func mapKeysField() string {
 	return "key"
 }
@@ -126,6 +238,265 @@ type Interval struct {
 	Micros int64 `json:"micros"`
 }
 
+// TimestampNS is a TIMESTAMP_NS value in nanoseconds since the Unix epoch.
+// It covers the full range of TIMESTAMP_NS, from 1677-09-21 to 2262-04-11, and the infinities,
+// and converts to TIMESTAMP_NS values losslessly. Scan TIMESTAMP_NS values into it to receive the raw nanoseconds.
+type TimestampNS int64
+
+// Time returns the timestamp as a time.Time in UTC.
+func (ts TimestampNS) Time() time.Time {
+	return time.Unix(0, int64(ts)).UTC()
+}
+
+// TimestampNSInfinity and TimestampNSNegInfinity are the TIMESTAMP_NS values 'infinity' and '-infinity'.
+const (
+	TimestampNSInfinity    TimestampNS = math.MaxInt64
+	TimestampNSNegInfinity TimestampNS = -math.MaxInt64
+)
+
+// Scan implements the sql.Scanner interface. It scans all TIMESTAMP_NS values losslessly, including the infinities.
+func (ts *TimestampNS) Scan(v any) error {
+	switch val := v.(type) {
+	case time.Time:
+		infinite := val.Equal(TimestampNSInfinity.Time()) || val.Equal(TimestampNSNegInfinity.Time())
+		if !infinite && (val.Before(minTimestampNS) || val.After(maxTimestampNS)) {
+			return conversionError(val.Year(), minTimestampNS.Year(), maxTimestampNS.Year())
+		}
+		*ts = TimestampNS(val.UnixNano())
+	case int64:
+		*ts = TimestampNS(val)
+	default:
+		return fmt.Errorf("invalid type `%T` for scanning `TimestampNS`, expected `time.Time`", v)
+	}
+	return nil
+}
+
+// minTimestampNS and maxTimestampNS are the bounds of TIMESTAMP_NS.
+// DuckDB reserves the outermost values for -infinity and infinity.
+var (
+	minTimestampNS = time.Unix(0, -math.MaxInt64+1).UTC()
+	maxTimestampNS = time.Unix(0, math.MaxInt64-1).UTC()
+)
+
+// DurationConversion defines how a time.Duration converts to an INTERVAL.
+type DurationConversion int
+
+const (
+	// DurationToMicros converts a duration to microseconds. This is the default.
+	DurationToMicros DurationConversion = iota
+	// DurationToDays splits a duration into days of 24 hours and the remaining microseconds.
+	DurationToDays
+)
+
+// NonFiniteFloats defines how go-duckdb handles NaN, +Inf, and -Inf FLOAT and DOUBLE values
+// when scanning them and binding them as arguments.
+type NonFiniteFloats int
+
+const (
+	// NonFiniteFloatsPassThrough scans and binds non-finite values unchanged. This is the default.
+	NonFiniteFloatsPassThrough NonFiniteFloats = iota
+	// NonFiniteFloatsError fails scanning and binding non-finite values.
+	NonFiniteFloatsError
+	// NonFiniteFloatsToNull scans and binds non-finite values as NULL.
+	NonFiniteFloatsToNull
+)
+
+// nonFiniteFloatError returns an error, if f is NaN, +Inf, or -Inf.
+func nonFiniteFloatError(f float64) error {
+	if !math.IsNaN(f) && !math.IsInf(f, 0) {
+		return nil
+	}
+	return getError(errNonFiniteFloat, invalidInputError(strconv.FormatFloat(f, 'g', -1, 64), "a finite value"))
+}
+
+// IntervalFromDuration converts a duration to an Interval, truncating it to microseconds.
+func IntervalFromDuration(d time.Duration, conv DurationConversion) Interval {
+	if conv == DurationToDays {
+		const day = 24 * time.Hour
+		return Interval{Days: int32(d / day), Micros: (d % day).Microseconds()}
+	}
+	return Interval{Micros: d.Microseconds()}
+}
+
+// Duration converts the interval to a time.Duration, with days of 24 hours.
+// It fails, if the interval has months, whose length varies, or if the duration overflows.
+func (i Interval) Duration() (time.Duration, error) {
+	if i.Months != 0 {
+		return 0, getError(errAPI, invalidInputError(fmt.Sprintf("%d months", i.Months), "an INTERVAL without months"))
+	}
+
+	const maxMicros = math.MaxInt64 / int64(time.Microsecond)
+	micros := new(big.Int).Add(big.NewInt(int64(i.Days)*24*60*60*1000*1000), big.NewInt(i.Micros))
+	if !micros.IsInt64() || micros.Int64() > maxMicros || micros.Int64() < -maxMicros {
+		return 0, getError(errAPI, castError(fmt.Sprintf("%d days %d microseconds", i.Days, i.Micros), "time.Duration"))
+	}
+	return time.Duration(micros.Int64()) * time.Microsecond, nil
+}
+
+// String formats the interval as an ISO-8601 duration, e.g., P1Y2M3DT4H5M6.000007S.
+// Each component carries its own sign, e.g., P1MT-1H, and a zero interval formats as PT0S.
+func (i Interval) String() string {
+	var b strings.Builder
+	b.WriteByte('P')
+	writeComponent := func(v int64, unit byte) {
+		if v != 0 {
+			b.WriteString(strconv.FormatInt(v, 10))
+			b.WriteByte(unit)
+		}
+	}
+	writeComponent(int64(i.Months/12), 'Y')
+	writeComponent(int64(i.Months%12), 'M')
+	writeComponent(int64(i.Days), 'D')
+
+	const microsPerSecond = int64(time.Second / time.Microsecond)
+	hours := i.Micros / (3600 * microsPerSecond)
+	minutes := i.Micros / (60 * microsPerSecond) % 60
+	micros := i.Micros % (60 * microsPerSecond)
+	if i.Micros != 0 || b.Len() == 1 {
+		b.WriteByte('T')
+		writeComponent(hours, 'H')
+		writeComponent(minutes, 'M')
+		if micros != 0 || b.Len() == 2 {
+			if micros < 0 {
+				b.WriteByte('-')
+				micros = -micros
+			}
+			b.WriteString(strconv.FormatInt(micros/microsPerSecond, 10))
+			if frac := micros % microsPerSecond; frac != 0 {
+				b.WriteString(strings.TrimRight(fmt.Sprintf(".%06d", frac), "0"))
+			}
+			b.WriteByte('S')
+		}
+	}
+	return b.String()
+}
+
+// ParseInterval parses an ISO-8601 duration, e.g., P1Y2M3DT4H5M6.5S, into an Interval.
+// Years and months add to the months, weeks and days to the days, and the time components to the microseconds.
+// The components may carry their own sign, and a leading sign negates all components.
+// Only the seconds may have a fraction, which ParseInterval truncates to microseconds.
+func ParseInterval(s string) (Interval, error) {
+	i, ok := parseInterval(s)
+	if !ok {
+		return Interval{}, getError(errAPI, invalidInputError(s, "an ISO-8601 duration"))
+	}
+	return i, nil
+}
+
+// intervalDateUnits and intervalTimeUnits are the multipliers of the ISO-8601 duration components,
+// in months or days, and in microseconds.
+var (
+	intervalDateUnits = map[byte]int64{'Y': 12, 'M': 1, 'W': 7, 'D': 1}
+	intervalTimeUnits = map[byte]int64{
+		'H': int64(time.Hour / time.Microsecond),
+		'M': int64(time.Minute / time.Microsecond),
+		'S': int64(time.Second / time.Microsecond),
+	}
+)
+
+func parseInterval(s string) (Interval, bool) {
+	var sign int64 = 1
+	rest := s
+	if strings.HasPrefix(rest, "-") {
+		sign, rest = -1, rest[1:]
+	} else {
+		rest = strings.TrimPrefix(rest, "+")
+	}
+	rest, ok := strings.CutPrefix(rest, "P")
+	if !ok || rest == "" {
+		return Interval{}, false
+	}
+
+	// The components must follow the order of their units.
+	units := "YMWD"
+	inTime := false
+	last := -1
+	var months, days, micros int64
+	for rest != "" {
+		if rest[0] == 'T' && !inTime {
+			units, inTime, last, rest = "HMS", true, -1, rest[1:]
+			if rest == "" {
+				return Interval{}, false
+			}
+			continue
+		}
+
+		// Split the number from its unit.
+		end := strings.IndexFunc(rest, func(r rune) bool { return r >= 'A' && r <= 'Z' })
+		if end <= 0 {
+			return Interval{}, false
+		}
+		number, unit := rest[:end], rest[end]
+		rest = rest[end+1:]
+		idx := strings.IndexByte(units, unit)
+		if idx <= last {
+			return Interval{}, false
+		}
+		last = idx
+
+		// Truncate the fraction of the seconds to microseconds.
+		var frac int64
+		if whole, fraction, found := strings.Cut(number, "."); found {
+			if !inTime || unit != 'S' || fraction == "" || strings.ContainsAny(fraction, "+-") {
+				return Interval{}, false
+			}
+			var err error
+			if frac, err = strconv.ParseInt((fraction + "000000")[:6], 10, 64); err != nil {
+				return Interval{}, false
+			}
+			if strings.HasPrefix(whole, "-") {
+				frac = -frac
+			}
+			number = whole
+		}
+		v, err := strconv.ParseInt(number, 10, 64)
+		if err != nil {
+			return Interval{}, false
+		}
+
+		multiplier := intervalDateUnits[unit]
+		if inTime {
+			multiplier = intervalTimeUnits[unit]
+		}
+		if v > math.MaxInt64/multiplier || v < -math.MaxInt64/multiplier {
+			return Interval{}, false
+		}
+		v = v*multiplier + frac
+
+		switch {
+		case inTime:
+			if (v > 0 && micros > math.MaxInt64-v) || (v < 0 && micros < -math.MaxInt64-v) {
+				return Interval{}, false
+			}
+			micros += v
+		case unit == 'Y' || unit == 'M':
+			months += v
+		default:
+			days += v
+		}
+		if months > math.MaxInt32 || months < -math.MaxInt32 || days > math.MaxInt32 || days < -math.MaxInt32 {
+			return Interval{}, false
+		}
+	}
+
+	return Interval{Months: int32(sign * months), Days: int32(sign * days), Micros: sign * micros}, true
+}
+
+// MarshalText implements the encoding.TextMarshaler interface. It formats the interval as an ISO-8601 duration.
+func (i Interval) MarshalText() ([]byte, error) {
+	return []byte(i.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. It parses an ISO-8601 duration.
+func (i *Interval) UnmarshalText(text []byte) error {
+	parsed, err := ParseInterval(string(text))
+	if err != nil {
+		return err
+	}
+	*i = parsed
+	return nil
+}
+
 func (i *Interval) getMappedInterval() *mapping.Interval {
 	return mapping.NewInterval(i.Months, i.Days, i.Micros)
 }
@@ -140,7 +511,400 @@ func (s Composite[T]) Get() T {
 }
 
 func (s *Composite[T]) Scan(v any) error {
-	return mapstructure.Decode(v, &s.t)
+	return s.scanComposite(v, compositeOptions{})
+}
+
+func (s *Composite[T]) scanComposite(v any, opts compositeOptions) error {
+	return decodeComposite(v, &s.t, false, opts)
+}
+
+// StrictComposite is like Composite, but fails to scan STRUCT values containing fields without a matching struct field,
+// or missing a struct field.
+type StrictComposite[T any] struct {
+	t T
+}
+
+func (s StrictComposite[T]) Get() T {
+	return s.t
+}
+
+func (s *StrictComposite[T]) Scan(v any) error {
+	return s.scanComposite(v, compositeOptions{})
+}
+
+func (s *StrictComposite[T]) scanComposite(v any, opts compositeOptions) error {
+	return decodeComposite(v, &s.t, true, opts)
+}
+
+// FieldNaming defines how decoding a STRUCT value into a Go struct matches STRUCT field names to struct field names.
+type FieldNaming int
+
+const (
+	// FieldNamingCaseInsensitive matches names ignoring case. This is the default.
+	FieldNamingCaseInsensitive FieldNaming = iota
+	// FieldNamingExact matches names exactly.
+	FieldNamingExact
+	// FieldNamingSnakeCase matches names ignoring case and underscores, e.g., user_id matches UserID.
+	FieldNamingSnakeCase
+)
+
+func (naming FieldNaming) match(name string, fieldName string) bool {
+	switch naming {
+	case FieldNamingExact:
+		return name == fieldName
+	case FieldNamingSnakeCase:
+		return strings.EqualFold(strings.ReplaceAll(name, "_", ""), strings.ReplaceAll(fieldName, "_", ""))
+	default:
+		return strings.EqualFold(name, fieldName)
+	}
+}
+
+// compositeOptions configures decoding composite values. The zero value decodes them with the defaults.
+type compositeOptions struct {
+	// naming defines how STRUCT field names match struct field names, see WithCompositeFieldNaming.
+	naming FieldNaming
+	// hooks are the decode hooks converting values, see WithCompositeDecodeHooks.
+	hooks []mapstructure.DecodeHookFunc
+}
+
+// compositeScanner is implemented by the sql.Scanner types decoding composite values, e.g., Composite.
+// ScanColumn scans into them with the options of the connection.
+type compositeScanner interface {
+	scanComposite(v any, opts compositeOptions) error
+}
+
+// decodeComposite decodes a composite value into the result. It matches STRUCT fields to struct fields
+// by their duckdb or db tags, like the Appender, or by their mapstructure tags or names.
+func decodeComposite(v any, result any, strict bool, opts compositeOptions) error {
+	hooks := append([]mapstructure.DecodeHookFunc{typeHandlerHook, compositeFieldNames(opts.naming)}, opts.hooks...)
+
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook:  mapstructure.ComposeDecodeHookFunc(hooks...),
+		ErrorUnused: strict,
+		ErrorUnset:  strict,
+		MatchName:   opts.naming.match,
+		Result:      result,
+	})
+	if err != nil {
+		return err
+	}
+	return decoder.Decode(v)
+}
+
+// compositeFieldNames returns a hook that renames the fields of a STRUCT value decoded into a struct
+// from the duckdb or db tags of the struct fields to the names of the struct fields.
+func compositeFieldNames(naming FieldNaming) mapstructure.DecodeHookFuncType {
+	return func(_ reflect.Type, to reflect.Type, data any) (any, error) {
+		m, ok := data.(map[string]any)
+		for to.Kind() == reflect.Pointer {
+			to = to.Elem()
+		}
+		if !ok || to.Kind() != reflect.Struct {
+			return data, nil
+		}
+
+		var renamed map[string]any
+		for i := 0; i < to.NumField(); i++ {
+			field := to.Field(i)
+			if _, ok = field.Tag.Lookup("mapstructure"); ok {
+				continue
+			}
+			name, ok := structFieldName(field)
+			if !ok || name == field.Name {
+				continue
+			}
+			for key, val := range m {
+				if !naming.match(key, name) {
+					continue
+				}
+				if renamed == nil {
+					renamed = make(map[string]any, len(m))
+					for k, v := range m {
+						renamed[k] = v
+					}
+				}
+				delete(renamed, key)
+				renamed[field.Name] = val
+			}
+		}
+		if renamed == nil {
+			return data, nil
+		}
+		return renamed, nil
+	}
+}
+
+// Union is a UNION value. Scanning a UNION value into Union[T] converts the member's value to T.
+// T is any, a Go interface implemented by the members' values, or a type that mapstructure decodes the values into.
+// Register the Go types of the members with RegisterUnionMembers to convert them to these types first, e.g., structs.
+// Appending or binding a Union[T] to a UNION column or parameter sets the member with the name to the value.
+// Appending or binding any other value sets the member whose type matches the value's Go type best,
+// and fails, if no member or several equally good members match, e.g., an int16 for INTEGER and BIGINT members.
+// DuckDB cannot bind a UNION parameter to a member whose type another member also has.
+type Union[T any] struct {
+	MemberName string
+	Value      T
+}
+
+// unionMember is implemented by all Union[T] types.
+type unionMember interface {
+	unionMember() (string, any)
+}
+
+func (u Union[T]) unionMember() (string, any) {
+	return u.MemberName, u.Value
+}
+
+func (u *Union[T]) Scan(v any) error {
+	return u.scanComposite(v, compositeOptions{})
+}
+
+func (u *Union[T]) scanComposite(v any, opts compositeOptions) error {
+	if v == nil {
+		*u = Union[T]{}
+		return nil
+	}
+	data, ok := v.(Union[any])
+	if !ok {
+		return fmt.Errorf("invalid type `%T` for scanning `Union`, expected `Union[any]`", v)
+	}
+
+	var value T
+	if data.Value != nil {
+		val := data.Value
+		if memberType, ok := unionMemberType[T](data.MemberName); ok {
+			member := reflect.New(memberType)
+			if err := decodeComposite(val, member.Interface(), false, opts); err != nil {
+				return err
+			}
+			val = member.Elem().Interface()
+		}
+		if typed, ok := val.(T); ok {
+			value = typed
+		} else if err := decodeComposite(val, &value, false, opts); err != nil {
+			return err
+		}
+	}
+
+	*u = Union[T]{MemberName: data.MemberName, Value: value}
+	return nil
+}
+
+// unionRegistry maps the type of T to the Go types of the UNION members registered for Union[T].
+var unionRegistry sync.Map
+
+// RegisterUnionMembers registers the Go types of UNION members for scanning into Union[T].
+// The keys are the member names, and the values are zero values of the members' Go types, e.g.,
+// RegisterUnionMembers[Shape](map[string]Shape{"circle": Circle{}, "square": Square{}}).
+// Scanning converts the value of a registered member to its Go type, like Composite.
+// Registering the members of T again replaces them. It is safe for concurrent use.
+func RegisterUnionMembers[T any](members map[string]T) {
+	types := make(map[string]reflect.Type, len(members))
+	for name, member := range members {
+		types[name] = reflect.TypeOf(member)
+	}
+	unionRegistry.Store(reflect.TypeFor[T](), types)
+}
+
+// unionMemberType returns the registered Go type of a member of Union[T].
+func unionMemberType[T any](name string) (reflect.Type, bool) {
+	types, ok := unionRegistry.Load(reflect.TypeFor[T]())
+	if !ok {
+		return nil, false
+	}
+	t, ok := types.(map[string]reflect.Type)[name]
+	return t, ok && t != nil
+}
+
+var (
+	unionIntegerTypes = []Type{TYPE_TINYINT, TYPE_SMALLINT, TYPE_INTEGER, TYPE_BIGINT, TYPE_UTINYINT, TYPE_USMALLINT,
+		TYPE_UINTEGER, TYPE_UBIGINT, TYPE_HUGEINT, TYPE_UHUGEINT}
+	unionFloatTypes     = []Type{TYPE_FLOAT, TYPE_DOUBLE}
+	unionTimestampTypes = []Type{TYPE_TIMESTAMP, TYPE_TIMESTAMP_S, TYPE_TIMESTAMP_MS, TYPE_TIMESTAMP_NS, TYPE_TIMESTAMP_TZ}
+
+	// unionKindTypes maps the kinds of Go values to the types of the UNION members matching them exactly.
+	unionKindTypes = map[reflect.Kind]Type{
+		reflect.Bool:    TYPE_BOOLEAN,
+		reflect.Int8:    TYPE_TINYINT,
+		reflect.Int16:   TYPE_SMALLINT,
+		reflect.Int32:   TYPE_INTEGER,
+		reflect.Int64:   TYPE_BIGINT,
+		reflect.Int:     TYPE_BIGINT,
+		reflect.Uint8:   TYPE_UTINYINT,
+		reflect.Uint16:  TYPE_USMALLINT,
+		reflect.Uint32:  TYPE_UINTEGER,
+		reflect.Uint64:  TYPE_UBIGINT,
+		reflect.Uint:    TYPE_UBIGINT,
+		reflect.Float32: TYPE_FLOAT,
+		reflect.Float64: TYPE_DOUBLE,
+		reflect.String:  TYPE_VARCHAR,
+		reflect.Slice:   TYPE_LIST,
+		reflect.Array:   TYPE_ARRAY,
+		reflect.Map:     TYPE_MAP,
+		reflect.Struct:  TYPE_STRUCT,
+	}
+)
+
+// unionMemberTypes returns the types of the UNION members that the non-nil Go value val matches:
+// the type matching its Go type, the types of the same kind, and the types that val converts to.
+func unionMemberTypes(val any) (Type, []Type, []Type) {
+	switch val.(type) {
+	case *big.Int:
+		return TYPE_HUGEINT, unionIntegerTypes, slices.Concat(unionFloatTypes, []Type{TYPE_DECIMAL})
+	case Decimal:
+		return TYPE_DECIMAL, nil, unionFloatTypes
+	case time.Time:
+		return TYPE_TIMESTAMP, unionTimestampTypes, []Type{TYPE_DATE, TYPE_TIME, TYPE_TIME_TZ}
+	case TimestampNS:
+		return TYPE_TIMESTAMP_NS, unionTimestampTypes, nil
+	case Interval, time.Duration:
+		return TYPE_INTERVAL, nil, nil
+	case UUID, uuid.UUID:
+		return TYPE_UUID, nil, nil
+	case []byte:
+		return TYPE_BLOB, nil, nil
+	case Map, OrderedMap:
+		return TYPE_MAP, nil, nil
+	case map[string]any:
+		return TYPE_STRUCT, nil, []Type{TYPE_MAP}
+	}
+
+	kind := reflect.TypeOf(val).Kind()
+	switch kind {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Int,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uint:
+		return unionKindTypes[kind], unionIntegerTypes, slices.Concat(unionFloatTypes, []Type{TYPE_DECIMAL})
+	case reflect.Float32, reflect.Float64:
+		return unionKindTypes[kind], unionFloatTypes, []Type{TYPE_DECIMAL}
+	case reflect.String:
+		return TYPE_VARCHAR, []Type{TYPE_ENUM}, nil
+	case reflect.Slice, reflect.Array:
+		return unionKindTypes[kind], []Type{TYPE_LIST, TYPE_ARRAY}, nil
+	}
+	if t, ok := unionKindTypes[kind]; ok {
+		return t, nil, nil
+	}
+	return TYPE_INVALID, nil, nil
+}
+
+// inferUnionMember returns the index of the UNION member that the non-nil Go value val matches best.
+// It prefers members whose type matches the Go type of val, e.g., INTEGER for an int32,
+// over members of the same kind, e.g., BIGINT, and those over members that val converts to, e.g., DOUBLE.
+// It fails, if no member or several equally good members match.
+func inferUnionMember(names []string, types []Type, val any) (int, error) {
+	exact, kind, convertible := unionMemberTypes(val)
+	best := 0
+	var candidates []int
+	for i, t := range types {
+		match := 0
+		switch {
+		case t == exact:
+			match = 3
+		case slices.Contains(kind, t):
+			match = 2
+		case slices.Contains(convertible, t):
+			match = 1
+		}
+		if match == 0 || match < best {
+			continue
+		}
+		if match > best {
+			best, candidates = match, candidates[:0]
+		}
+		candidates = append(candidates, i)
+	}
+
+	switch len(candidates) {
+	case 0:
+		return 0, castError(reflect.TypeOf(val).String(), "a UNION member")
+	case 1:
+		return candidates[0], nil
+	}
+	matching := make([]string, len(candidates))
+	for i, idx := range candidates {
+		matching[i] = names[idx]
+	}
+	return 0, invalidInputError(fmt.Sprintf("%s matching the members %s", reflect.TypeOf(val), strings.Join(matching, ", ")),
+		"a value matching one UNION member, or a Union[T] naming the member")
+}
+
+// Null is a nullable value of any Go type that DuckDB values scan into, e.g., Null[Decimal], Null[[]int32],
+// or Null[T] with a struct T for STRUCT values. Valid is false, if the value is NULL.
+// LIST, ARRAY, STRUCT, and MAP values convert like Composite.
+// Binding or appending a Null writes NULL, if Valid is false, and V otherwise.
+type Null[T any] struct {
+	V     T
+	Valid bool
+}
+
+func (n *Null[T]) Scan(v any) error {
+	return n.scanComposite(v, compositeOptions{})
+}
+
+func (n *Null[T]) scanComposite(v any, opts compositeOptions) error {
+	if v == nil {
+		*n = Null[T]{}
+		return nil
+	}
+
+	var value T
+	if scanner, ok := any(&value).(compositeScanner); ok {
+		if err := scanner.scanComposite(v, opts); err != nil {
+			return err
+		}
+	} else if scanner, ok := any(&value).(sql.Scanner); ok {
+		if err := scanner.Scan(v); err != nil {
+			return err
+		}
+	} else if typed, ok := v.(T); ok {
+		value = typed
+	} else if isCompositeType(reflect.TypeFor[T]()) {
+		if err := decodeComposite(v, &value, false, opts); err != nil {
+			return err
+		}
+	} else {
+		var null sql.Null[T]
+		if err := null.Scan(v); err != nil {
+			return err
+		}
+		value = null.V
+	}
+
+	*n = Null[T]{V: value, Valid: true}
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (n Null[T]) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.V, nil
+}
+
+// nullable is implemented by all Null[T] types.
+type nullable interface {
+	nullable() any
+}
+
+func (n Null[T]) nullable() any {
+	if !n.Valid {
+		return nil
+	}
+	return n.V
+}
+
+// isCompositeType returns true, if values of type t convert like Composite.
+func isCompositeType(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Slice:
+		return t.Elem().Kind() != reflect.Uint8
+	case reflect.Array, reflect.Map:
+		return true
+	case reflect.Struct:
+		return t != reflect.TypeFor[time.Time]()
+	}
+	return false
 }
 
 const max_decimal_width = 38
@@ -190,11 +954,82 @@ func (d *Decimal) String() string {
 	return signStr + zeroTrimmed[:len(zeroTrimmed)-scale] + "." + zeroTrimmed[len(zeroTrimmed)-scale:]
 }
 
+// Rat returns the exact value of the decimal.
+func (d *Decimal) Rat() *big.Rat {
+	if d.Value == nil {
+		return new(big.Rat)
+	}
+	return new(big.Rat).SetFrac(d.Value, pow10(d.Scale))
+}
+
+// SetString sets the decimal to the value of a decimal number, e.g., "-12.345" or "1.5e3".
+// If the decimal has a width, SetString keeps its width and scale, and rounds the value half away from zero.
+// Otherwise, it sets the smallest width and scale representing the value exactly.
+// SetString fails, if the value exceeds the width, or the maximum width of 38 digits.
+func (d *Decimal) SetString(s string) error {
+	if d.Width != 0 {
+		v, err := unscaledDecimal(s, d.Width, d.Scale)
+		if err != nil {
+			return getError(errAPI, err)
+		}
+		d.Value = v
+		return nil
+	}
+
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return getError(errAPI, castError(s, "DECIMAL"))
+	}
+	for scale := uint8(0); scale <= max_decimal_width; scale++ {
+		if new(big.Int).Rem(pow10(scale), r.Denom()).Sign() != 0 {
+			continue
+		}
+		v := new(big.Int).Mul(r.Num(), new(big.Int).Quo(pow10(scale), r.Denom()))
+		width := decimalWidth(v, scale)
+		if width > max_decimal_width {
+			break
+		}
+		d.Width, d.Scale, d.Value = uint8(width), scale, v
+		return nil
+	}
+	return getError(errAPI, castError(s, fmt.Sprintf("DECIMAL(%d, scale)", max_decimal_width)))
+}
+
+// Round returns the decimal rounded half away from zero to the scale.
+// The width changes by the difference of the scales, up to the maximum width of 38 digits,
+// and grows, if rounding adds a digit. Round fails, if the result exceeds the maximum width.
+func (d *Decimal) Round(scale uint8) (Decimal, error) {
+	v, err := unscaledDecimal(*d, max_decimal_width, scale)
+	if err != nil {
+		return Decimal{}, getError(errAPI, err)
+	}
+	width := max(int(d.Width)-int(d.Scale)+int(scale), decimalWidth(v, scale))
+	width = min(width, max_decimal_width)
+	return Decimal{Width: uint8(width), Scale: scale, Value: v}, nil
+}
+
+// Cmp compares the values of two decimals, regardless of their widths and scales.
+// It returns -1, if d < other, 0, if d == other, and +1, if d > other.
+func (d *Decimal) Cmp(other Decimal) int {
+	return d.Rat().Cmp(other.Rat())
+}
+
+// decimalWidth returns the smallest width of a DECIMAL with the scale that holds the unscaled value.
+func decimalWidth(v *big.Int, scale uint8) int {
+	digits := len(new(big.Int).Abs(v).String())
+	if v.Sign() == 0 {
+		digits = 0
+	}
+	return max(digits, int(scale), 1)
+}
+
 func castToTime[T any](val T) (time.Time, error) {
 	var ti time.Time
 	switch v := any(val).(type) {
 	case time.Time:
 		ti = v
+	case TimestampNS:
+		ti = v.Time()
 	default:
 		return ti, castError(reflect.TypeOf(val).String(), reflect.TypeOf(ti).String())
 	}
@@ -202,6 +1037,9 @@ func castToTime[T any](val T) (time.Time, error) {
 }
 
 func getTSTicks[T any](t Type, val T) (int64, error) {
+	if ts, ok := any(val).(TimestampNS); ok && t == TYPE_TIMESTAMP_NS {
+		return int64(ts), nil
+	}
 	ti, err := castToTime(val)
 	if err != nil {
 		return 0, err
@@ -223,8 +1061,8 @@ func getTSTicks[T any](t Type, val T) (int64, error) {
 	}
 
 	// TYPE_TIMESTAMP_NS:
-	if year < 1678 || year > 2262 {
-		return 0, conversionError(year, -290307, 294246)
+	if ti.Before(minTimestampNS) || ti.After(maxTimestampNS) {
+		return 0, conversionError(year, minTimestampNS.Year(), maxTimestampNS.Year())
 	}
 	return ti.UnixNano(), nil
 }
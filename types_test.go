@@ -4,7 +4,11 @@ import (
 	"bytes"
 	"context"
 	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
 	"fmt"
+	"io"
+	"math"
 	"math/big"
 	"reflect"
 	"strconv"
@@ -13,6 +17,8 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
+
+	"github.com/marcboeker/go-duckdb/mapping"
 )
 
 // First, this test inserts all types (except UUID and DECIMAL) with the Appender.
@@ -443,6 +449,30 @@ func TestDecimal(t *testing.T) {
 			require.Equal(t, test.want, fs.String())
 		}
 	})
+
+	t.Run("bind DECIMAL parameters", func(t *testing.T) {
+		bigInt, success := new(big.Int).SetString("-12345678901234567890123456789", 10)
+		require.True(t, success)
+		tests := []Decimal{
+			{Value: big.NewInt(1230), Width: 4, Scale: 3},
+			{Value: big.NewInt(-12345), Width: 9, Scale: 2},
+			{Value: big.NewInt(123456789012), Width: 18, Scale: 0},
+			{Value: bigInt, Width: 38, Scale: 20},
+		}
+		for _, test := range tests {
+			var typeName string
+			var fs Decimal
+			require.NoError(t, db.QueryRow(`SELECT typeof($1), $1`, test).Scan(&typeName, &fs))
+			require.Equal(t, fmt.Sprintf("DECIMAL(%d,%d)", test.Width, test.Scale), typeName)
+			compareDecimal(t, test, fs)
+		}
+
+		// Invalid widths and values.
+		err := db.QueryRow(`SELECT ?`, Decimal{Value: big.NewInt(1), Width: 0}).Err()
+		require.ErrorContains(t, err, castErrMsg)
+		err = db.QueryRow(`SELECT ?`, Decimal{Value: big.NewInt(1000), Width: 3, Scale: 1}).Err()
+		require.ErrorContains(t, err, castErrMsg)
+	})
 }
 
 func TestDecimalString(t *testing.T) {
@@ -588,6 +618,443 @@ func TestDecimalString(t *testing.T) {
 	}
 }
 
+func TestDecimalHelpers(t *testing.T) {
+	d := Decimal{Width: 5, Scale: 2, Value: big.NewInt(-12345)}
+	require.Equal(t, big.NewRat(-12345, 100), d.Rat())
+	require.Equal(t, new(big.Rat), (&Decimal{}).Rat())
+
+	// Set values with a fixed width and scale.
+	require.NoError(t, d.SetString("1.235"))
+	compareDecimal(t, Decimal{Width: 5, Scale: 2, Value: big.NewInt(124)}, d)
+	require.NoError(t, d.SetString("-2e2"))
+	compareDecimal(t, Decimal{Width: 5, Scale: 2, Value: big.NewInt(-20000)}, d)
+	testError(t, d.SetString("1000"), castErrMsg)
+	testError(t, d.SetString("abc"), castErrMsg)
+
+	// Set values with the smallest width and scale.
+	tests := []struct {
+		input string
+		want  Decimal
+	}{
+		{input: "0", want: Decimal{Width: 1, Scale: 0, Value: big.NewInt(0)}},
+		{input: "-12.340", want: Decimal{Width: 4, Scale: 2, Value: big.NewInt(-1234)}},
+		{input: "0.001", want: Decimal{Width: 3, Scale: 3, Value: big.NewInt(1)}},
+		{input: "1.5e3", want: Decimal{Width: 4, Scale: 0, Value: big.NewInt(1500)}},
+	}
+	for _, test := range tests {
+		var actual Decimal
+		require.NoError(t, actual.SetString(test.input))
+		compareDecimal(t, test.want, actual)
+	}
+	var actual Decimal
+	testError(t, actual.SetString("1e40"), castErrMsg)
+	testError(t, actual.SetString("1/3"), castErrMsg)
+
+	// Round to other scales.
+	d = Decimal{Width: 3, Scale: 2, Value: big.NewInt(-999)}
+	rounded, err := d.Round(1)
+	require.NoError(t, err)
+	compareDecimal(t, Decimal{Width: 3, Scale: 1, Value: big.NewInt(-100)}, rounded)
+	rounded, err = d.Round(4)
+	require.NoError(t, err)
+	compareDecimal(t, Decimal{Width: 5, Scale: 4, Value: big.NewInt(-99900)}, rounded)
+	d = Decimal{Width: 38, Scale: 0, Value: new(big.Int).Sub(pow10(38), big.NewInt(1))}
+	_, err = d.Round(1)
+	testError(t, err, castErrMsg)
+
+	// Compare values with different scales.
+	a := Decimal{Width: 3, Scale: 1, Value: big.NewInt(15)}
+	require.Equal(t, 0, a.Cmp(Decimal{Width: 4, Scale: 3, Value: big.NewInt(1500)}))
+	require.Equal(t, -1, a.Cmp(Decimal{Width: 2, Scale: 0, Value: big.NewInt(2)}))
+	require.Equal(t, 1, a.Cmp(Decimal{Width: 2, Scale: 2, Value: big.NewInt(-1)}))
+}
+
+func TestNull(t *testing.T) {
+	db := openDbWrapper(t, ``)
+	defer closeDbWrapper(t, db)
+
+	type point struct {
+		X int32 `duckdb:"x"`
+		Y int32 `duckdb:"y"`
+	}
+
+	t.Run("scan values", func(t *testing.T) {
+		var (
+			i    Null[int64]
+			dec  Null[Decimal]
+			list Null[[]int32]
+			p    Null[point]
+			m    Null[TypedMap[string, int32]]
+			id   Null[UUID]
+			ts   Null[time.Time]
+			str  Null[string]
+		)
+		row := db.QueryRow(`SELECT 42::INTEGER, 1.25::DECIMAL(5, 2), [1, 2, 3], {'x': 1, 'y': 2}, MAP {'a': 1},
+			'f47ac10b-58cc-4372-a567-0e02b2c3d479'::UUID, TIMESTAMP '2024-01-02 03:04:05', 'hello'`)
+		require.NoError(t, row.Scan(&i, &dec, &list, &p, &m, &id, &ts, &str))
+
+		require.Equal(t, Null[int64]{V: 42, Valid: true}, i)
+		require.True(t, dec.Valid)
+		require.Equal(t, "1.25", dec.V.String())
+		require.Equal(t, Null[[]int32]{V: []int32{1, 2, 3}, Valid: true}, list)
+		require.Equal(t, Null[point]{V: point{X: 1, Y: 2}, Valid: true}, p)
+		require.Equal(t, TypedMap[string, int32]{"a": 1}, m.V)
+		require.Equal(t, "f47ac10b-58cc-4372-a567-0e02b2c3d479", id.V.String())
+		require.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), ts.V)
+		require.Equal(t, Null[string]{V: "hello", Valid: true}, str)
+	})
+
+	t.Run("scan NULL values", func(t *testing.T) {
+		i := Null[int64]{V: 1, Valid: true}
+		dec := Null[Decimal]{V: Decimal{Width: 5, Scale: 2, Value: big.NewInt(125)}, Valid: true}
+		list := Null[[]int32]{V: []int32{1}, Valid: true}
+		p := Null[point]{V: point{X: 1}, Valid: true}
+		row := db.QueryRow(`SELECT NULL::INTEGER, NULL::DECIMAL(5, 2), NULL::INTEGER[], NULL::STRUCT(x INTEGER, y INTEGER)`)
+		require.NoError(t, row.Scan(&i, &dec, &list, &p))
+		require.Equal(t, Null[int64]{}, i)
+		require.Equal(t, Null[Decimal]{}, dec)
+		require.Equal(t, Null[[]int32]{}, list)
+		require.Equal(t, Null[point]{}, p)
+	})
+
+	t.Run("bind values", func(t *testing.T) {
+		var isNull bool
+		var str string
+		dec := Null[Decimal]{V: Decimal{Width: 5, Scale: 2, Value: big.NewInt(125)}, Valid: true}
+		require.NoError(t, db.QueryRow(`SELECT ? IS NULL, ?::VARCHAR`, dec, dec).Scan(&isNull, &str))
+		require.False(t, isNull)
+		require.Equal(t, "1.25", str)
+
+		require.NoError(t, db.QueryRow(`SELECT ? IS NULL`, Null[Decimal]{}).Scan(&isNull))
+		require.True(t, isNull)
+
+		require.NoError(t, db.QueryRow(`SELECT ?::VARCHAR`, Null[string]{V: "hello", Valid: true}).Scan(&str))
+		require.Equal(t, "hello", str)
+	})
+
+	t.Run("append values", func(t *testing.T) {
+		c, db, conn, a := prepareAppender(t, `CREATE TABLE test (l INTEGER[], p STRUCT(x INTEGER, y INTEGER))`)
+		defer cleanupAppender(t, c, db, conn, a)
+
+		require.NoError(t, a.AppendRow(Null[[]int32]{V: []int32{1, 2}, Valid: true}, Null[point]{V: point{X: 1, Y: 2}, Valid: true}))
+		require.NoError(t, a.AppendRow(Null[[]int32]{}, Null[point]{}))
+		require.NoError(t, a.Flush())
+
+		res, err := db.Query(`SELECT l, p FROM test ORDER BY rowid`)
+		require.NoError(t, err)
+		defer closeRowsWrapper(t, res)
+
+		var lists []Null[[]int32]
+		var points []Null[point]
+		for res.Next() {
+			var l Null[[]int32]
+			var p Null[point]
+			require.NoError(t, res.Scan(&l, &p))
+			lists = append(lists, l)
+			points = append(points, p)
+		}
+		require.NoError(t, res.Err())
+		require.Equal(t, []Null[[]int32]{{V: []int32{1, 2}, Valid: true}, {}}, lists)
+		require.Equal(t, []Null[point]{{V: point{X: 1, Y: 2}, Valid: true}, {}}, points)
+	})
+}
+
+func TestTypedMap(t *testing.T) {
+	db := openDbWrapper(t, ``)
+	defer closeDbWrapper(t, db)
+
+	var m TypedMap[string, int]
+	require.NoError(t, db.QueryRow(`SELECT MAP {'a': 1, 'b': 2}`).Scan(&m))
+	require.Equal(t, TypedMap[string, int]{"a": 1, "b": 2}, m)
+
+	// Convert nested values.
+	type point struct {
+		X int32
+		Y []float64
+	}
+	var points TypedMap[int64, point]
+	require.NoError(t, db.QueryRow(`SELECT MAP {1: {'x': 1, 'y': [1.5::DOUBLE]}, 2: {'x': 2, 'y': []}}`).Scan(&points))
+	require.Equal(t, TypedMap[int64, point]{1: {X: 1, Y: []float64{1.5}}, 2: {X: 2, Y: []float64{}}}, points)
+
+	// Scan NULL values.
+	require.NoError(t, db.QueryRow(`SELECT NULL::MAP(VARCHAR, INTEGER)`).Scan(&m))
+	require.Nil(t, m)
+	var nullable sql.Null[TypedMap[string, int]]
+	require.NoError(t, db.QueryRow(`SELECT MAP {'a': NULL}`).Scan(&nullable))
+	require.Equal(t, TypedMap[string, int]{"a": 0}, nullable.V)
+
+	// Invalid types.
+	require.Error(t, db.QueryRow(`SELECT 42`).Scan(&m))
+	require.Error(t, db.QueryRow(`SELECT MAP {'a': 'not a number'}`).Scan(&m))
+}
+
+func TestOrderedMap(t *testing.T) {
+	c, err := NewConnectorWithOptions(``, nil, WithOrderedMaps())
+	require.NoError(t, err)
+	db := sql.OpenDB(c)
+	defer closeDbWrapper(t, db)
+
+	var m OrderedMap
+	require.NoError(t, db.QueryRow(`SELECT MAP {'z': 1, 'a': 2, 'm': 3}`).Scan(&m))
+	require.Equal(t, OrderedMap{{Key: "z", Value: int32(1)}, {Key: "a", Value: int32(2)}, {Key: "m", Value: int32(3)}}, m)
+
+	b, err := json.Marshal(m)
+	require.NoError(t, err)
+	require.Equal(t, `{"z":1,"a":2,"m":3}`, string(b))
+
+	// Order nested maps.
+	var list Composite[[]any]
+	require.NoError(t, db.QueryRow(`SELECT [MAP {2: 'b', 1: 'a'}]`).Scan(&list))
+	require.Equal(t, []any{OrderedMap{{Key: int32(2), Value: "b"}, {Key: int32(1), Value: "a"}}}, list.Get())
+
+	// Map and TypedMap destinations accept ordered maps.
+	var untyped Map
+	require.NoError(t, db.QueryRow(`SELECT MAP {'a': 1}`).Scan(&untyped))
+	require.Equal(t, Map{"a": int32(1)}, untyped)
+	var typed TypedMap[string, int]
+	require.NoError(t, db.QueryRow(`SELECT MAP {'a': 1}`).Scan(&typed))
+	require.Equal(t, TypedMap[string, int]{"a": 1}, typed)
+
+	// Append ordered maps.
+	conn := openDriverConnWrapper(t, c)
+	defer closeDriverConnWrapper(t, &conn)
+	_, err = db.Exec(`CREATE TABLE test (m MAP(VARCHAR, INTEGER))`)
+	require.NoError(t, err)
+	a, err := NewAppender(conn, "", "", "test")
+	require.NoError(t, err)
+	require.NoError(t, a.AppendRow(m))
+	require.NoError(t, a.Close())
+	require.NoError(t, db.QueryRow(`SELECT m FROM test`).Scan(&m))
+	require.Equal(t, OrderedMap{{Key: "z", Value: int32(1)}, {Key: "a", Value: int32(2)}, {Key: "m", Value: int32(3)}}, m)
+
+	// Without the option, MAP values scan into Map only.
+	defaultDB := openDbWrapper(t, ``)
+	defer closeDbWrapper(t, defaultDB)
+	require.ErrorContains(t, defaultDB.QueryRow(`SELECT MAP {'a': 1}`).Scan(&m), "WithOrderedMaps")
+}
+
+type testUnionShape interface {
+	area() float64
+}
+
+type testUnionCircle struct {
+	Radius float64
+}
+
+func (c testUnionCircle) area() float64 { return 3 * c.Radius * c.Radius }
+
+type testUnionSquare struct {
+	Side float64
+}
+
+func (s testUnionSquare) area() float64 { return s.Side * s.Side }
+
+func TestUnion(t *testing.T) {
+	c, db, conn, a := prepareAppender(t, `CREATE TABLE test (u UNION(num INTEGER, str VARCHAR))`)
+	defer cleanupAppender(t, c, db, conn, a)
+
+	require.NoError(t, a.AppendRow(Union[any]{MemberName: "num", Value: int32(42)}))
+	require.NoError(t, a.AppendRow(Union[string]{MemberName: "str", Value: "hello"}))
+	require.NoError(t, a.AppendRow(nil))
+	testError(t, a.AppendRow(Union[any]{MemberName: "unknown", Value: 1}), errAppenderAppendRow.Error(), invalidInputErrMsg)
+	testError(t, a.AppendRow(true), errAppenderAppendRow.Error(), castErrMsg)
+	require.NoError(t, a.Flush())
+
+	res, err := db.Query(`SELECT u FROM test ORDER BY rowid`)
+	require.NoError(t, err)
+	defer closeRowsWrapper(t, res)
+
+	types, err := res.ColumnTypes()
+	require.NoError(t, err)
+	require.Equal(t, reflect.TypeOf(Union[any]{}), types[0].ScanType())
+	require.Equal(t, "UNION", types[0].DatabaseTypeName())
+
+	var values []any
+	for res.Next() {
+		var u any
+		require.NoError(t, res.Scan(&u))
+		values = append(values, u)
+	}
+	require.NoError(t, res.Err())
+	require.Equal(t, []any{Union[any]{MemberName: "num", Value: int32(42)}, Union[any]{MemberName: "str", Value: "hello"}, nil}, values)
+
+	// Convert the values to T.
+	var num Union[int64]
+	require.NoError(t, db.QueryRow(`SELECT u FROM test WHERE u.num = 42`).Scan(&num))
+	require.Equal(t, Union[int64]{MemberName: "num", Value: 42}, num)
+	require.Error(t, db.QueryRow(`SELECT u FROM test WHERE u.str = 'hello'`).Scan(&num))
+	require.NoError(t, db.QueryRow(`SELECT NULL::UNION(num INTEGER)`).Scan(&num))
+	require.Equal(t, Union[int64]{}, num)
+
+	// Convert the values of registered members.
+	RegisterUnionMembers(map[string]testUnionShape{"circle": testUnionCircle{}, "square": testUnionSquare{}})
+	query := `SELECT union_value(circle := {'radius': 2.0::DOUBLE})::UNION(circle STRUCT(radius DOUBLE), square STRUCT(side DOUBLE))`
+	var shape Union[testUnionShape]
+	require.NoError(t, db.QueryRow(query).Scan(&shape))
+	require.Equal(t, "circle", shape.MemberName)
+	require.Equal(t, testUnionCircle{Radius: 2}, shape.Value)
+	require.Equal(t, float64(12), shape.Value.area())
+
+	// Unregistered members fail to convert to the interface.
+	require.Error(t, db.QueryRow(`SELECT union_value(triangle := {'side': 1.0::DOUBLE})`).Scan(&shape))
+}
+
+func TestUnionInference(t *testing.T) {
+	const unionType = `UNION(num INTEGER, big BIGINT, str VARCHAR, d DOUBLE, ints INTEGER[], ts TIMESTAMP)`
+	ts := time.Date(2024, time.March, 1, 10, 30, 0, 0, time.UTC)
+
+	t.Run("appender", func(t *testing.T) {
+		c, db, conn, a := prepareAppender(t, `CREATE TABLE test (u `+unionType+`)`)
+		defer cleanupAppender(t, c, db, conn, a)
+
+		require.NoError(t, a.AppendRow(int32(1)))
+		require.NoError(t, a.AppendRow(int64(2)))
+		require.NoError(t, a.AppendRow("three"))
+		require.NoError(t, a.AppendRow(float32(4.5)))
+		require.NoError(t, a.AppendRow([]int32{5}))
+		require.NoError(t, a.AppendRow(ts))
+		// Union[T] values name their member.
+		require.NoError(t, a.AppendRow(Union[any]{MemberName: "d", Value: int32(6)}))
+		require.NoError(t, a.Flush())
+
+		// Several members of the same kind match an int16.
+		err := a.AppendRow(int16(7))
+		testError(t, err, errAppenderAppendRow.Error(), invalidInputErrMsg, "int16 matching the members num, big")
+		testError(t, a.AppendRow(true), errAppenderAppendRow.Error(), castErrMsg)
+
+		res, err := db.Query(`SELECT union_tag(u)::VARCHAR FROM test ORDER BY rowid`)
+		require.NoError(t, err)
+		defer closeRowsWrapper(t, res)
+		var tags []string
+		for res.Next() {
+			var tag string
+			require.NoError(t, res.Scan(&tag))
+			tags = append(tags, tag)
+		}
+		require.NoError(t, res.Err())
+		require.Equal(t, []string{"num", "big", "str", "d", "ints", "ts", "d"}, tags)
+	})
+
+	t.Run("parameters", func(t *testing.T) {
+		db := openDbWrapper(t, ``)
+		defer closeDbWrapper(t, db)
+
+		tests := []struct {
+			arg  any
+			want Union[any]
+		}{
+			// database/sql converts Go integers to int64.
+			{arg: int32(1), want: Union[any]{MemberName: "big", Value: int64(1)}},
+			{arg: "two", want: Union[any]{MemberName: "str", Value: "two"}},
+			{arg: 3.5, want: Union[any]{MemberName: "d", Value: 3.5}},
+			{arg: []int{4, 5}, want: Union[any]{MemberName: "ints", Value: []any{int32(4), int32(5)}}},
+			{arg: ts, want: Union[any]{MemberName: "ts", Value: ts}},
+			{arg: Union[any]{MemberName: "num", Value: 6}, want: Union[any]{MemberName: "num", Value: int32(6)}},
+			{arg: Union[int]{MemberName: "d", Value: 7}, want: Union[any]{MemberName: "d", Value: float64(7)}},
+		}
+		for _, test := range tests {
+			var u Union[any]
+			require.NoError(t, db.QueryRow(`SELECT ?::`+unionType, test.arg).Scan(&u))
+			require.Equal(t, test.want, u)
+		}
+
+		var u any
+		require.NoError(t, db.QueryRow(`SELECT ?::`+unionType, nil).Scan(&u))
+		require.Nil(t, u)
+
+		err := db.QueryRow(`SELECT ?::`+unionType, true).Scan(&u)
+		require.ErrorContains(t, err, castErrMsg)
+		err = db.QueryRow(`SELECT ?::`+unionType, Union[any]{MemberName: "num", Value: int64(1) << 40}).Scan(&u)
+		require.ErrorContains(t, err, convertErrMsg)
+		err = db.QueryRow(`SELECT ?::`+unionType, Union[any]{MemberName: "unknown", Value: 1}).Scan(&u)
+		require.ErrorContains(t, err, invalidInputErrMsg)
+		err = db.QueryRow(`SELECT ?::UNION(a INTEGER, b INTEGER)`, Union[any]{MemberName: "a", Value: 1}).Scan(&u)
+		require.ErrorContains(t, err, "ambiguous")
+	})
+}
+
+func TestCompositeTags(t *testing.T) {
+	db := openDbWrapper(t, ``)
+	defer closeDbWrapper(t, db)
+
+	type inner struct {
+		Value int `db:"v"`
+	}
+	type tagged struct {
+		ID    int64  `db:"user_id"`
+		Name  string `duckdb:"full_name"`
+		Inner *inner `db:"nested"`
+		Other string `mapstructure:"other_name"`
+	}
+
+	query := `SELECT {'user_id': 1, 'FULL_NAME': 'a', 'nested': {'v': 2}, 'other_name': 'b'}`
+	var c Composite[tagged]
+	require.NoError(t, db.QueryRow(query).Scan(&c))
+	require.Equal(t, tagged{ID: 1, Name: "a", Inner: &inner{Value: 2}, Other: "b"}, c.Get())
+
+	var list Composite[[]tagged]
+	require.NoError(t, db.QueryRow(`SELECT [{'user_id': 3}]`).Scan(&list))
+	require.Equal(t, []tagged{{ID: 3}}, list.Get())
+
+	// Strict decoding fails for unknown and missing fields.
+	var strict StrictComposite[tagged]
+	require.NoError(t, db.QueryRow(query).Scan(&strict))
+	require.Equal(t, c.Get(), strict.Get())
+	err := db.QueryRow(`SELECT {'user_id': 1, 'full_name': 'a', 'nested': {'v': 2}, 'other_name': 'b', 'unknown': 3}`).Scan(&strict)
+	require.ErrorContains(t, err, "unknown")
+	err = db.QueryRow(`SELECT {'user_id': 1, 'full_name': 'a', 'nested': {'v': 2}}`).Scan(&strict)
+	require.ErrorContains(t, err, "other_name")
+
+	// Non-strict decoding ignores them.
+	var lenient Composite[tagged]
+	require.NoError(t, db.QueryRow(`SELECT {'user_id': 4, 'unknown': 3}`).Scan(&lenient))
+	require.Equal(t, tagged{ID: 4}, lenient.Get())
+}
+
+func TestSQLNull(t *testing.T) {
+	db := openDbWrapper(t, ``)
+	defer closeDbWrapper(t, db)
+
+	// DuckDB returns untyped NULL values as INTEGER values.
+	for _, query := range []string{`SELECT NULL AS x`, `SELECT NULL AS x UNION ALL SELECT NULL`} {
+		var (
+			v   any
+			str sql.NullString
+			ptr *time.Time
+			ts  sql.Null[time.Time]
+		)
+		require.NoError(t, db.QueryRow(query).Scan(&v))
+		require.NoError(t, db.QueryRow(query).Scan(&str))
+		require.NoError(t, db.QueryRow(query).Scan(&ptr))
+		require.NoError(t, db.QueryRow(query).Scan(&ts))
+		require.Nil(t, v)
+		require.False(t, str.Valid)
+		require.Nil(t, ptr)
+		require.False(t, ts.Valid)
+	}
+
+	// SQLNULL columns have NULL values only.
+	logicalType := mapping.CreateLogicalType(TYPE_SQLNULL)
+	defer mapping.DestroyLogicalType(&logicalType)
+	require.Equal(t, reflect.TypeFor[any](), scanType(logicalType))
+	require.Equal(t, "NULL", databaseTypeName(logicalType))
+
+	listType := mapping.CreateListType(logicalType)
+	defer mapping.DestroyLogicalType(&listType)
+	require.Equal(t, "NULL[]", databaseTypeName(listType))
+
+	chunk := mapping.CreateDataChunk([]mapping.LogicalType{logicalType})
+	defer mapping.DestroyDataChunk(&chunk)
+
+	var vec vector
+	require.NoError(t, vec.init(logicalType, 0))
+	vec.initVectors(mapping.DataChunkGetVector(chunk, 0), true)
+	require.True(t, vec.getNull(0))
+	require.Nil(t, vec.getFn(&vec, 0))
+	require.NoError(t, vec.setFn(&vec, 0, nil))
+	require.ErrorIs(t, vec.setFn(&vec, 0, 1), errSetSQLNULLValue)
+}
+
 func TestBlob(t *testing.T) {
 	db := openDbWrapper(t, ``)
 	defer closeDbWrapper(t, db)
@@ -598,6 +1065,75 @@ func TestBlob(t *testing.T) {
 	require.Equal(t, []byte{0xAA}, b)
 }
 
+func TestRawBytes(t *testing.T) {
+	db := openDbWrapper(t, ``)
+	defer closeDbWrapper(t, db)
+
+	const query = `SELECT ('blob' || range)::BLOB, 'str' || range, NULL::BLOB FROM range(3000)`
+	res, err := db.Query(query)
+	require.NoError(t, err)
+
+	var i int
+	var blob, str, null sql.RawBytes
+	for res.Next() {
+		require.NoError(t, res.Scan(&blob, &str, &null))
+		require.Equal(t, fmt.Sprintf("blob%d", i), string(blob))
+		require.Equal(t, fmt.Sprintf("str%d", i), string(str))
+		require.Nil(t, null)
+		i++
+	}
+	require.NoError(t, res.Err())
+	require.Equal(t, 3000, i)
+	closeRowsWrapper(t, res)
+
+	// Scanning into []byte copies the driver's buffer.
+	res, err = db.Query(query)
+	require.NoError(t, err)
+	defer closeRowsWrapper(t, res)
+
+	var blobs [][]byte
+	for res.Next() {
+		var b []byte
+		require.NoError(t, res.Scan(&b, &str, &null))
+		blobs = append(blobs, b)
+	}
+	require.NoError(t, res.Err())
+	for j, b := range blobs {
+		require.Equal(t, fmt.Sprintf("blob%d", j), string(b))
+	}
+}
+
+func TestBlobDriverValues(t *testing.T) {
+	c := newConnectorWrapper(t, ``, nil)
+	defer closeConnectorWrapper(t, c)
+	conn := openDriverConnWrapper(t, c)
+	defer closeDriverConnWrapper(t, &conn)
+
+	// Callers of Next own the values, e.g., to keep the values of multiple rows.
+	r, err := conn.(*Conn).QueryContext(context.Background(),
+		`SELECT ('blob' || range)::BLOB, gen_random_uuid() FROM range(3)`, nil)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, r.Close())
+	}()
+
+	var kept [][]driver.Value
+	for {
+		values := make([]driver.Value, 2)
+		if err = r.Next(values); err != nil {
+			break
+		}
+		kept = append(kept, values)
+	}
+	require.ErrorIs(t, err, io.EOF)
+	require.Len(t, kept, 3)
+	for i, values := range kept {
+		require.Equal(t, []byte(fmt.Sprintf("blob%d", i)), values[0])
+	}
+	require.NotEqual(t, kept[0][1], kept[1][1])
+	require.NotEqual(t, kept[1][1], kept[2][1])
+}
+
 func TestList(t *testing.T) {
 	db := openDbWrapper(t, ``)
 	defer closeDbWrapper(t, db)
@@ -647,7 +1183,31 @@ func TestUUID(t *testing.T) {
 
 		require.NoError(t, db.QueryRow(`SELECT ?::uuid`, test).Scan(&u))
 		require.Equal(t, test.String(), u.String())
+
+		// uuid.UUID and UUID parameters bind as UUID values.
+		var typeName, str string
+		require.NoError(t, db.QueryRow(`SELECT typeof(?), ?::VARCHAR`, test, test).Scan(&typeName, &str))
+		require.Equal(t, "UUID", typeName)
+		require.Equal(t, test.String(), str)
+
+		require.NoError(t, db.QueryRow(`SELECT typeof(?), ?::VARCHAR`, u, &u).Scan(&typeName, &str))
+		require.Equal(t, "UUID", typeName)
+		require.Equal(t, test.String(), str)
+	}
+
+	// Scanning multiple rows reuses the column buffer without leaking values across rows.
+	res, err := db.Query(`SELECT uuid FROM uuid_test`)
+	require.NoError(t, err)
+	defer closeRowsWrapper(t, res)
+
+	var scanned []uuid.UUID
+	for res.Next() {
+		var val uuid.UUID
+		require.NoError(t, res.Scan(&val))
+		scanned = append(scanned, val)
 	}
+	require.NoError(t, res.Err())
+	require.ElementsMatch(t, tests, scanned)
 }
 
 func TestUUIDScanError(t *testing.T) {
@@ -793,6 +1353,46 @@ func TestHugeInt(t *testing.T) {
 	})
 }
 
+func TestBindLargeUint64(t *testing.T) {
+	db := openDbWrapper(t, ``)
+	defer closeDbWrapper(t, db)
+
+	_, err := db.Exec(`CREATE TABLE hashes (hash UBIGINT, big HUGEINT)`)
+	require.NoError(t, err)
+
+	type hash uint64
+	const val = uint64(math.MaxUint64 - 1)
+	ptr := val
+	args := map[string]any{
+		"uint64":           val,
+		"uint":             uint(val),
+		"named type":       hash(val),
+		"pointer":          &ptr,
+		"sql.Null[uint64]": sql.Null[uint64]{V: val, Valid: true},
+		"Null[uint64]":     Null[uint64]{V: val, Valid: true},
+	}
+	for name, arg := range args {
+		t.Run(name, func(t *testing.T) {
+			_, err = db.Exec(`INSERT INTO hashes VALUES (?, ?)`, arg, arg)
+			require.NoError(t, err)
+
+			var res uint64
+			var huge *big.Int
+			require.NoError(t, db.QueryRow(`SELECT hash, big FROM hashes WHERE hash = ?`, arg).Scan(&res, &huge))
+			require.Equal(t, val, res)
+			require.Equal(t, "18446744073709551614", huge.String())
+
+			_, err = db.Exec(`DELETE FROM hashes`)
+			require.NoError(t, err)
+		})
+	}
+
+	// Smaller values bind as usual.
+	var res int64
+	require.NoError(t, db.QueryRow(`SELECT ?`, uint64(42)).Scan(&res))
+	require.Equal(t, int64(42), res)
+}
+
 func TestTimestampTZ(t *testing.T) {
 	db := openDbWrapper(t, ``)
 	defer closeDbWrapper(t, db)
@@ -856,6 +1456,150 @@ func TestTimestamp(t *testing.T) {
 	}
 }
 
+func TestTimeLocation(t *testing.T) {
+	amsterdam, err := time.LoadLocation("Europe/Amsterdam")
+	require.NoError(t, err)
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+
+	c, err := NewConnectorWithOptions(``, nil, WithTimeLocation(amsterdam))
+	require.NoError(t, err)
+	db := sql.OpenDB(c)
+	defer closeDbWrapper(t, db)
+
+	const query = `SELECT TIMESTAMP '2024-06-01 12:00:00', TIMESTAMPTZ '2024-06-01 12:00:00+00', '2024-06-01 12:00:00'::TIMESTAMP_NS,
+		[TIMESTAMP '2024-06-01 12:00:00'], NULL::TIMESTAMP, DATE '2024-06-01'`
+	want := time.Date(2024, time.June, 1, 12, 0, 0, 0, time.UTC)
+
+	scan := func(t *testing.T, ctx context.Context, loc *time.Location) {
+		var ts, tsTZ, tsNS time.Time
+		var list Composite[[]time.Time]
+		var null *time.Time
+		var date time.Time
+		require.NoError(t, db.QueryRowContext(ctx, query).Scan(&ts, &tsTZ, &tsNS, &list, &null, &date))
+		for _, val := range []time.Time{ts, tsTZ, tsNS, list.Get()[0]} {
+			require.Equal(t, loc, val.Location())
+			require.True(t, want.Equal(val))
+		}
+		require.Nil(t, null)
+		// DATE values are not timestamps.
+		require.Equal(t, time.UTC, date.Location())
+	}
+
+	t.Run("connector location", func(t *testing.T) {
+		scan(t, context.Background(), amsterdam)
+	})
+	t.Run("query location", func(t *testing.T) {
+		scan(t, WithQueryTimeLocation(context.Background(), tokyo), tokyo)
+	})
+}
+
+func TestTimeTZOffsets(t *testing.T) {
+	const query = `SELECT TIMETZ '11:30:00.123456+03', [TIMETZ '09:00:00-05:30'], NULL::TIMETZ, TIME '11:30:00'`
+	plus3 := time.FixedZone("", 3*60*60)
+	minus530 := time.FixedZone("", -(5*60*60 + 30*60))
+
+	t.Run("default", func(t *testing.T) {
+		db := openDbWrapper(t, ``)
+		defer closeDbWrapper(t, db)
+
+		var ti time.Time
+		require.NoError(t, db.QueryRow(query).Scan(&ti, new(any), new(any), new(any)))
+		require.Equal(t, time.Date(1, time.January, 1, 8, 30, 0, 123456000, time.UTC), ti)
+	})
+
+	t.Run("offsets", func(t *testing.T) {
+		c, err := NewConnectorWithOptions(``, nil, WithTimeTZOffsets())
+		require.NoError(t, err)
+		db := sql.OpenDB(c)
+		defer closeDbWrapper(t, db)
+
+		var ti, plain time.Time
+		var list Composite[[]time.Time]
+		var null *time.Time
+		require.NoError(t, db.QueryRow(query).Scan(&ti, &list, &null, &plain))
+		require.Equal(t, time.Date(1, time.January, 1, 11, 30, 0, 123456000, plus3), ti)
+		_, offset := ti.Zone()
+		require.Equal(t, 3*60*60, offset)
+
+		require.Len(t, list.Get(), 1)
+		require.Equal(t, time.Date(1, time.January, 1, 9, 0, 0, 0, minus530), list.Get()[0])
+		require.Nil(t, null)
+
+		// TIME values have no offset.
+		require.Equal(t, time.Date(1, time.January, 1, 11, 30, 0, 0, time.UTC), plain)
+	})
+}
+
+func TestNonFiniteFloats(t *testing.T) {
+	const query = `SELECT 'nan'::DOUBLE, '-inf'::FLOAT, [1.5, 'inf'::DOUBLE], 2.5::DOUBLE`
+	openDB := func(t *testing.T, policy NonFiniteFloats) *sql.DB {
+		c, err := NewConnectorWithOptions(``, nil, WithNonFiniteFloats(policy))
+		require.NoError(t, err)
+		return sql.OpenDB(c)
+	}
+	nan := math.NaN()
+	inf := math.Inf(1)
+
+	t.Run("pass through", func(t *testing.T) {
+		db := openDB(t, NonFiniteFloatsPassThrough)
+		defer closeDbWrapper(t, db)
+
+		var d float64
+		var f float32
+		var l Composite[[]float64]
+		var finite float64
+		require.NoError(t, db.QueryRow(query).Scan(&d, &f, &l, &finite))
+		require.True(t, math.IsNaN(d))
+		require.True(t, math.IsInf(float64(f), -1))
+		require.Equal(t, []float64{1.5, inf}, l.Get())
+
+		require.NoError(t, db.QueryRow(`SELECT ?`, nan).Scan(&d))
+		require.True(t, math.IsNaN(d))
+	})
+
+	t.Run("error", func(t *testing.T) {
+		db := openDB(t, NonFiniteFloatsError)
+		defer closeDbWrapper(t, db)
+
+		var v any
+		err := db.QueryRow(`SELECT 'nan'::DOUBLE`).Scan(&v)
+		require.ErrorContains(t, err, errNonFiniteFloat.Error())
+		err = db.QueryRow(`SELECT [1.5, 'inf'::DOUBLE]`).Scan(&v)
+		require.ErrorContains(t, err, "got +Inf")
+		var finite float64
+		require.NoError(t, db.QueryRow(`SELECT 2.5::DOUBLE`).Scan(&finite))
+		require.Equal(t, 2.5, finite)
+
+		for _, arg := range []any{nan, float32(inf), &inf, sql.NullFloat64{Float64: nan, Valid: true}, Null[float64]{V: inf, Valid: true}} {
+			err = db.QueryRow(`SELECT ?::DOUBLE`, arg).Scan(&v)
+			require.ErrorContains(t, err, errNonFiniteFloat.Error())
+		}
+		require.NoError(t, db.QueryRow(`SELECT ?::DOUBLE`, sql.NullFloat64{Float64: nan}).Scan(&v))
+		require.Nil(t, v)
+	})
+
+	t.Run("NULL", func(t *testing.T) {
+		db := openDB(t, NonFiniteFloatsToNull)
+		defer closeDbWrapper(t, db)
+
+		var d, f *float64
+		var l Composite[[]*float64]
+		var finite float64
+		require.NoError(t, db.QueryRow(query).Scan(&d, &f, &l, &finite))
+		require.Nil(t, d)
+		require.Nil(t, f)
+		require.Len(t, l.Get(), 2)
+		require.Equal(t, 1.5, *l.Get()[0])
+		require.Nil(t, l.Get()[1])
+		require.Equal(t, 2.5, finite)
+
+		var isNull bool
+		require.NoError(t, db.QueryRow(`SELECT ? IS NULL`, &inf).Scan(&isNull))
+		require.True(t, isNull)
+	})
+}
+
 func TestInterval(t *testing.T) {
 	db := openDbWrapper(t, ``)
 	defer closeDbWrapper(t, db)
@@ -869,6 +1613,80 @@ func TestInterval(t *testing.T) {
 		require.Equal(t, interval, res)
 	})
 
+	t.Run("time.Duration binding", func(t *testing.T) {
+		var res Interval
+		require.NoError(t, db.QueryRow("SELECT ?::INTERVAL", 49*time.Hour+time.Microsecond).Scan(&res))
+		require.Equal(t, Interval{Micros: (49*time.Hour + time.Microsecond).Microseconds()}, res)
+
+		c, err := NewConnectorWithOptions(``, nil, WithDurationConversion(DurationToDays))
+		require.NoError(t, err)
+		daysDB := sql.OpenDB(c)
+		defer closeDbWrapper(t, daysDB)
+
+		require.NoError(t, daysDB.QueryRow("SELECT ?", -49*time.Hour).Scan(&res))
+		require.Equal(t, Interval{Days: -2, Micros: -time.Hour.Microseconds()}, res)
+	})
+
+	t.Run("ISO-8601 formatting and parsing", func(t *testing.T) {
+		tests := []struct {
+			input string
+			want  string
+		}{
+			{input: "INTERVAL 0 SECONDS", want: "PT0S"},
+			{input: "INTERVAL 14 MONTHS + INTERVAL 3 DAYS", want: "P1Y2M3D"},
+			{input: "INTERVAL 4 HOURS + INTERVAL 5 MINUTES + INTERVAL 6000007 MICROSECONDS", want: "PT4H5M6.000007S"},
+			{input: "INTERVAL 1 MONTH - INTERVAL 90 MINUTES", want: "P1MT-1H-30M"},
+			{input: "INTERVAL (-500) MILLISECOND", want: "PT-0.5S"},
+			{input: "INTERVAL 2 YEARS + INTERVAL 1 SECOND", want: "P2YT1S"},
+		}
+		for _, test := range tests {
+			var res Interval
+			require.NoError(t, db.QueryRow("SELECT "+test.input).Scan(&res))
+			require.Equal(t, test.want, res.String())
+
+			parsed, err := ParseInterval(test.want)
+			require.NoError(t, err)
+			require.Equal(t, res, parsed)
+		}
+
+		parsed, err := ParseInterval("-P1W2DT1.1234567S")
+		require.NoError(t, err)
+		require.Equal(t, Interval{Days: -9, Micros: -1123456}, parsed)
+
+		for _, invalid := range []string{"", "P", "1D", "PT", "P1S", "PT1D", "P1D1Y", "P1M1M", "P1.5D", "PT1.S", "P1", "PXD", "P3000000000D"} {
+			_, err = ParseInterval(invalid)
+			testError(t, err, errAPI.Error(), invalidInputErrMsg)
+		}
+
+		// Intervals marshal to JSON as ISO-8601 durations.
+		b, err := json.Marshal(map[string]Interval{"i": {Months: 1, Days: 2, Micros: 3000000}})
+		require.NoError(t, err)
+		require.Equal(t, `{"i":"P1M2DT3S"}`, string(b))
+		var m map[string]Interval
+		require.NoError(t, json.Unmarshal(b, &m))
+		require.Equal(t, Interval{Months: 1, Days: 2, Micros: 3000000}, m["i"])
+		require.Error(t, json.Unmarshal([]byte(`{"i":"1 day"}`), &m))
+	})
+
+	t.Run("Duration conversion", func(t *testing.T) {
+		var res Interval
+		require.NoError(t, db.QueryRow("SELECT INTERVAL 2 DAYS + INTERVAL 3 HOURS + INTERVAL 4 MICROSECONDS").Scan(&res))
+		d, err := res.Duration()
+		require.NoError(t, err)
+		require.Equal(t, 51*time.Hour+4*time.Microsecond, d)
+
+		d, err = IntervalFromDuration(-d, DurationToDays).Duration()
+		require.NoError(t, err)
+		require.Equal(t, -51*time.Hour-4*time.Microsecond, d)
+
+		_, err = Interval{Months: 1}.Duration()
+		testError(t, err, errAPI.Error(), invalidInputErrMsg)
+		_, err = Interval{Days: math.MaxInt32}.Duration()
+		testError(t, err, errAPI.Error(), castErrMsg)
+		_, err = Interval{Micros: math.MinInt64}.Duration()
+		testError(t, err, errAPI.Error(), castErrMsg)
+	})
+
 	t.Run("INTERVAL scanning", func(t *testing.T) {
 		tests := map[string]struct {
 			input string
@@ -919,6 +1737,21 @@ func TestArray(t *testing.T) {
 	}
 }
 
+func TestNestedArray(t *testing.T) {
+	db := openDbWrapper(t, ``)
+	defer closeDbWrapper(t, db)
+
+	var matrix Composite[[3][4]float32]
+	row := db.QueryRow(`SELECT [[1, 2, 3, 4], [5, 6, 7, 8], [9, 10, 11, 12]]::FLOAT[4][3]`)
+	require.NoError(t, row.Scan(&matrix))
+	require.Equal(t, [3][4]float32{{1, 2, 3, 4}, {5, 6, 7, 8}, {9, 10, 11, 12}}, matrix.Get())
+
+	var slices Composite[[][]float32]
+	row = db.QueryRow(`SELECT [[1, 2], [3, 4]]::FLOAT[2][2]`)
+	require.NoError(t, row.Scan(&slices))
+	require.Equal(t, [][]float32{{1, 2}, {3, 4}}, slices.Get())
+}
+
 func TestJSONType(t *testing.T) {
 	db := openDbWrapper(t, ``)
 	defer closeDbWrapper(t, db)
@@ -945,6 +1778,211 @@ func TestJSONType(t *testing.T) {
 	require.Equal(t, float64(3), res.Get()["3"])
 }
 
+type testJSONEmail string
+
+func (e testJSONEmail) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]string{"email": string(e)})
+}
+
+func TestBindJSON(t *testing.T) {
+	db := openDbWrapper(t, ``)
+	defer closeDbWrapper(t, db)
+
+	createTable(t, db, `CREATE TABLE test (id INTEGER, j JSON, v VARCHAR)`)
+
+	type address struct {
+		City string `json:"city"`
+		Zip  string `json:"zip,omitempty"`
+	}
+	tests := []struct {
+		val      any
+		expected string
+	}{
+		{val: map[string]any{"address": address{City: "Amsterdam"}, "tags": []string{"a", "b"}}, expected: `{"address":{"city":"Amsterdam"},"tags":["a","b"]}`},
+		{val: &address{City: "Berlin", Zip: "10115"}, expected: `{"city":"Berlin","zip":"10115"}`},
+		{val: []int{1, 2, 3}, expected: `[1,2,3]`},
+		{val: testJSONEmail("me@example.com"), expected: `{"email":"me@example.com"}`},
+		{val: `{"already":"serialized"}`, expected: `{"already":"serialized"}`},
+		{val: 42, expected: `42`},
+	}
+	for i, test := range tests {
+		_, err := db.Exec(`INSERT INTO test VALUES (?, ?, 'x')`, i, test.val)
+		require.NoError(t, err)
+
+		var str string
+		require.NoError(t, db.QueryRow(`SELECT j::VARCHAR FROM test WHERE id = ?`, i).Scan(&str))
+		require.JSONEq(t, test.expected, str)
+	}
+
+	// Casting a parameter to JSON marshals it.
+	var city string
+	require.NoError(t, db.QueryRow(`SELECT ?::JSON->>'$.address.city'`, tests[0].val).Scan(&city))
+	require.Equal(t, "Amsterdam", city)
+
+	// A nil pointer binds NULL.
+	_, err := db.Exec(`INSERT INTO test VALUES (?, ?, 'x')`, len(tests), (*address)(nil))
+	require.NoError(t, err)
+	var j any
+	require.NoError(t, db.QueryRow(`SELECT j FROM test WHERE id = ?`, len(tests)).Scan(&j))
+	require.Nil(t, j)
+
+	// A json.Marshaler binds its underlying value to non-JSON parameters.
+	var v string
+	require.NoError(t, db.QueryRow(`SELECT ?::VARCHAR`, testJSONEmail("me@example.com")).Scan(&v))
+	require.Equal(t, "me@example.com", v)
+
+	// Values that neither marshal to JSON nor convert to a driver.Value fail.
+	_, err = db.Exec(`INSERT INTO test VALUES (?, ?, 'x')`, 100, map[string]any{"ch": make(chan int)})
+	require.ErrorContains(t, err, "unsupported type: chan int")
+	_, err = db.Exec(`UPDATE test SET v = ? WHERE id = 0`, map[string]any{"a": 1})
+	require.Error(t, err)
+}
+
+func TestBindMap(t *testing.T) {
+	db := openDbWrapper(t, ``)
+	defer closeDbWrapper(t, db)
+
+	createTable(t, db, `CREATE TABLE test (id INTEGER, m MAP(VARCHAR, INTEGER))`)
+
+	t.Run("Go map", func(t *testing.T) {
+		_, err := db.Exec(`INSERT INTO test VALUES (1, ?)`, map[string]int{"a": 1, "b": 2})
+		require.NoError(t, err)
+
+		var m Map
+		require.NoError(t, db.QueryRow(`SELECT m FROM test WHERE id = 1`).Scan(&m))
+		require.Equal(t, Map{"a": int32(1), "b": int32(2)}, m)
+	})
+
+	t.Run("Map and OrderedMap", func(t *testing.T) {
+		var m Map
+		require.NoError(t, db.QueryRow(`SELECT ?::MAP(INTEGER, VARCHAR)`, Map{int64(1): "x", int64(2): nil}).Scan(&m))
+		require.Equal(t, Map{int32(1): "x", int32(2): nil}, m)
+
+		var keys Composite[[]int32]
+		ordered := OrderedMap{{Key: 3, Value: "c"}, {Key: 1, Value: "a"}, {Key: 2, Value: "b"}}
+		require.NoError(t, db.QueryRow(`SELECT map_keys(?::MAP(INTEGER, VARCHAR))`, ordered).Scan(&keys))
+		require.Equal(t, []int32{3, 1, 2}, keys.Get())
+	})
+
+	t.Run("special characters", func(t *testing.T) {
+		input := map[string]string{
+			"a=b":        "x,y",
+			"it's":       `say "hi"`,
+			" padded ":   "{}",
+			"[1, 2]":     ` NULL `,
+			`back\slash`: "",
+		}
+		var m Map
+		require.NoError(t, db.QueryRow(`SELECT ?::MAP(VARCHAR, VARCHAR)`, input).Scan(&m))
+		require.Len(t, m, len(input))
+		for key, value := range input {
+			require.Equal(t, value, m[key])
+		}
+	})
+
+	t.Run("typed keys and values", func(t *testing.T) {
+		ts := time.Date(2024, time.March, 1, 10, 30, 0, 0, time.UTC)
+		id := UUID(uuid.New())
+
+		var m Map
+		require.NoError(t, db.QueryRow(`SELECT ?::MAP(TIMESTAMP, UUID)`, map[time.Time]UUID{ts: id}).Scan(&m))
+		// UUID values of nested types scan into byte slices.
+		require.Equal(t, Map{ts: id[:]}, m)
+
+		require.NoError(t, db.QueryRow(`SELECT ?::MAP(DATE, INTERVAL)`, map[time.Time]time.Duration{ts: time.Hour}).Scan(&m))
+		require.Equal(t, Map{time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC): Interval{Micros: 3600000000}}, m)
+
+		require.NoError(t, db.QueryRow(`SELECT ?::MAP(BOOLEAN, DOUBLE)`, map[bool]float32{true: 1.5}).Scan(&m))
+		require.Equal(t, Map{true: 1.5}, m)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := db.Exec(`INSERT INTO test VALUES (2, ?)`, Map{nil: 1})
+		require.ErrorContains(t, err, "expected a MAP key, got NULL")
+
+		_, err = db.Exec(`INSERT INTO test VALUES (2, ?)`, map[string]int{"NULL": 1})
+		require.ErrorContains(t, err, invalidInputErrMsg)
+
+		_, err = db.Exec(`INSERT INTO test VALUES (2, ?)`, map[string]int{`'"`: 1})
+		require.ErrorContains(t, err, invalidInputErrMsg)
+
+		_, err = db.Exec(`INSERT INTO test VALUES (2, ?)`, map[string]string{"a": "b"})
+		require.ErrorContains(t, err, castErrMsg)
+
+		_, err = db.Exec(`INSERT INTO test VALUES (2, ?)`, map[string]int64{"a": 1 << 40})
+		require.ErrorContains(t, err, "Conversion Error")
+
+		_, err = db.Exec(`INSERT INTO test VALUES (2, ?)`, map[string][]int{"a": {1}})
+		require.ErrorContains(t, err, castErrMsg)
+
+		// The text of nested values would not keep their elements, e.g., strings with commas.
+		var m Map
+		err = db.QueryRow(`SELECT ?::MAP(VARCHAR, VARCHAR[])`, map[string][]string{"k": {"a,b", `c"d`}}).Scan(&m)
+		require.ErrorContains(t, err, unsupportedTypeErrMsg)
+		err = db.QueryRow(`SELECT ?::MAP(STRUCT(a VARCHAR), INTEGER)`, OrderedMap{{Key: map[string]any{"a": "x,y"}, Value: 1}}).Scan(&m)
+		require.ErrorContains(t, err, unsupportedTypeErrMsg)
+	})
+}
+
+func TestBindNested(t *testing.T) {
+	db := openDbWrapper(t, ``)
+	defer closeDbWrapper(t, db)
+
+	createTable(t, db, `CREATE TABLE test (id INTEGER, l BIGINT[], a VARCHAR[2], s STRUCT(cents BIGINT, "user" VARCHAR))`)
+
+	alice, bob := testUserID("alice"), testUserID("bob")
+	type payment struct {
+		Cents testMoney
+		User  *testUserID
+	}
+
+	t.Run("Valuer elements", func(t *testing.T) {
+		_, err := db.Exec(`INSERT INTO test VALUES (1, ?, ?, ?)`,
+			[]testMoney{{cents: 100}, {cents: 250}}, [2]*testUserID{&alice, nil}, payment{Cents: testMoney{cents: 5}, User: &bob})
+		require.NoError(t, err)
+
+		var l Composite[[]int64]
+		var a Composite[[]*string]
+		var s Composite[map[string]any]
+		require.NoError(t, db.QueryRow(`SELECT l, a, s FROM test WHERE id = 1`).Scan(&l, &a, &s))
+		require.Equal(t, []int64{100, 250}, l.Get())
+		require.Equal(t, "user-alice", *a.Get()[0])
+		require.Nil(t, a.Get()[1])
+		require.Equal(t, map[string]any{"cents": int64(5), "user": "user-bob"}, s.Get())
+	})
+
+	t.Run("nested values", func(t *testing.T) {
+		var res Composite[[]map[string]any]
+		input := []any{
+			map[string]any{"id": sql.NullInt32{Int32: 1, Valid: true}, "tags": []*testUserID{&alice}},
+			map[string]any{"id": nil, "tags": []string(nil)},
+		}
+		require.NoError(t, db.QueryRow(`SELECT ?::STRUCT(id INTEGER, tags VARCHAR[])[]`, input).Scan(&res))
+		require.Equal(t, []map[string]any{
+			{"id": int32(1), "tags": []any{"user-alice"}},
+			{"id": nil, "tags": nil},
+		}, res.Get())
+
+		var m Map
+		require.NoError(t, db.QueryRow(`SELECT ?::MAP(VARCHAR, BIGINT)`, map[*testUserID]testMoney{&bob: {cents: 1}}).Scan(&m))
+		require.Equal(t, Map{"user-bob": int64(1)}, m)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := db.Exec(`INSERT INTO test VALUES (2, ?, NULL, NULL)`, []*testUserID{new(testUserID)})
+		require.ErrorContains(t, err, "empty user ID")
+
+		_, err = db.Exec(`INSERT INTO test VALUES (2, NULL, ?, NULL)`, []string{"a"})
+		require.ErrorContains(t, err, invalidInputErrMsg)
+
+		_, err = db.Exec(`INSERT INTO test VALUES (2, NULL, NULL, ?)`, map[string]any{"cents": 1})
+		require.ErrorContains(t, err, "missing field")
+
+		_, err = db.Exec(`INSERT INTO test VALUES (2, ?, NULL, NULL)`, payment{})
+		require.ErrorContains(t, err, castErrMsg)
+	})
+}
+
 func TestJSONColType(t *testing.T) {
 	db := openDbWrapper(t, ``)
 	defer closeDbWrapper(t, db)
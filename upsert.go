@@ -0,0 +1,184 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync/atomic"
+)
+
+// UpsertOptions configures Upsert.
+type UpsertOptions struct {
+	// Catalog and Schema of the target table. Empty values refer to the defaults.
+	Catalog string
+	Schema  string
+	// UpdateColumns are the columns to update for rows whose keys already exist in the target table.
+	// Nil updates all non-key columns. If there are no columns to update, Upsert skips these rows.
+	UpdateColumns []string
+}
+
+// UpsertStats contains the number of inserted and updated rows of a finished Upsert.
+type UpsertStats struct {
+	Inserted int64
+	Updated  int64
+}
+
+// stagingCounter makes the names of temporary staging tables unique.
+var stagingCounter atomic.Uint64
+
+func stagingTableName(prefix string) string {
+	return fmt.Sprintf("%s_%d", prefix, stagingCounter.Add(1))
+}
+
+// Upsert inserts rows into a table, or updates them, if their keys already exist.
+// It appends the rows to a temporary staging table with an Appender,
+// and then inserts them with an INSERT ... ON CONFLICT statement.
+// The key columns must have a PRIMARY KEY or UNIQUE constraint, and the keys of the rows must be unique.
+// T must be a struct, see BulkLoad for the mapping of its fields to columns.
+func Upsert[T any](ctx context.Context, conn *sql.Conn, table string, rows []T, keyColumns []string, opts UpsertOptions) (UpsertStats, error) {
+	stats := UpsertStats{}
+
+	fields, err := structColumns(reflect.TypeFor[T]())
+	if err != nil {
+		return stats, getError(errUpsert, err)
+	}
+	columns := make([]string, len(fields))
+	for i, f := range fields {
+		columns[i] = f.name
+	}
+
+	keys, err := upsertColumns(columns, keyColumns)
+	if err != nil {
+		return stats, getError(errUpsert, err)
+	}
+	if len(keys) == 0 {
+		return stats, getError(errUpsert, invalidInputError("no key columns", "at least one key column"))
+	}
+
+	updates := opts.UpdateColumns
+	if updates == nil {
+		for _, c := range columns {
+			if !containsFold(keys, c) {
+				updates = append(updates, c)
+			}
+		}
+	}
+	if updates, err = upsertColumns(columns, updates); err != nil {
+		return stats, getError(errUpsert, err)
+	}
+
+	if len(rows) == 0 {
+		return stats, nil
+	}
+
+	target := QuoteQualified(opts.Catalog, opts.Schema, table)
+	staging := stagingTableName("duckdb_upsert")
+	stagingQualified := QuoteQualified("temp", "main", staging)
+
+	quoted := quoteColumns(columns)
+	createQuery := fmt.Sprintf(`CREATE TEMP TABLE %s AS SELECT %s FROM %s LIMIT 0`,
+		QuoteIdentifier(staging), strings.Join(quoted, ", "), target)
+	if _, err = conn.ExecContext(ctx, createQuery); err != nil {
+		return stats, getError(errUpsert, err)
+	}
+	defer func() {
+		_, _ = conn.ExecContext(context.Background(), `DROP TABLE IF EXISTS `+stagingQualified)
+	}()
+
+	err = conn.Raw(func(driverConn any) error {
+		a, errAppender := NewAppender(driverConn.(driver.Conn), "temp", "main", staging)
+		if errAppender != nil {
+			return errAppender
+		}
+		var bulkStats BulkLoadStats
+		errAppend := bulkAppend(ctx, a, rows, fields, 0, &bulkStats)
+		errClose := a.Close()
+		if errAppend != nil {
+			return errAppend
+		}
+		return errClose
+	})
+	if err != nil {
+		return stats, getError(errUpsert, err)
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return stats, getError(errUpsert, err)
+	}
+	defer tx.Rollback()
+
+	conditions := make([]string, len(keys))
+	for i, k := range quoteColumns(keys) {
+		conditions[i] = fmt.Sprintf("t.%s = s.%s", k, k)
+	}
+	countQuery := fmt.Sprintf(`SELECT count(*) FROM %s s WHERE EXISTS (SELECT 1 FROM %s t WHERE %s)`,
+		stagingQualified, target, strings.Join(conditions, " AND "))
+	var existing int64
+	if err = tx.QueryRowContext(ctx, countQuery).Scan(&existing); err != nil {
+		return stats, getError(errUpsert, err)
+	}
+
+	action := `DO NOTHING`
+	if len(updates) != 0 {
+		sets := make([]string, len(updates))
+		for i, u := range quoteColumns(updates) {
+			sets[i] = fmt.Sprintf("%s = excluded.%s", u, u)
+		}
+		action = `DO UPDATE SET ` + strings.Join(sets, ", ")
+	}
+	columnList := strings.Join(quoted, ", ")
+	insertQuery := fmt.Sprintf(`INSERT INTO %s (%s) SELECT %s FROM %s ON CONFLICT (%s) %s`,
+		target, columnList, columnList, stagingQualified, strings.Join(quoteColumns(keys), ", "), action)
+	if _, err = tx.ExecContext(ctx, insertQuery); err != nil {
+		return stats, getError(errUpsert, err)
+	}
+	if err = tx.Commit(); err != nil {
+		return stats, getError(errUpsert, err)
+	}
+
+	stats.Inserted = int64(len(rows)) - existing
+	if len(updates) != 0 {
+		stats.Updated = existing
+	}
+	return stats, nil
+}
+
+// upsertColumns returns the struct columns matching names, which must all exist.
+func upsertColumns(columns []string, names []string) ([]string, error) {
+	var matched []string
+	for _, name := range names {
+		found := false
+		for _, c := range columns {
+			if strings.EqualFold(c, name) {
+				matched = append(matched, c)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, structFieldError(name, strings.Join(columns, ", "))
+		}
+	}
+	return matched, nil
+}
+
+func containsFold(names []string, name string) bool {
+	for _, n := range names {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func quoteColumns(names []string) []string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = QuoteIdentifier(name)
+	}
+	return quoted
+}
@@ -0,0 +1,83 @@
+package duckdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type upsertRow struct {
+	ID    int32 `db:"id"`
+	Name  string
+	Score float64
+}
+
+func TestUpsert(t *testing.T) {
+	db := openDbWrapper(t, ``)
+	defer closeDbWrapper(t, db)
+	conn := openConnWrapper(t, db, context.Background())
+	defer closeConnWrapper(t, conn)
+
+	_, err := conn.ExecContext(context.Background(), `CREATE TABLE upsert (id INTEGER PRIMARY KEY, name VARCHAR, score DOUBLE)`)
+	require.NoError(t, err)
+
+	rows := []upsertRow{{1, "a", 1}, {2, "b", 2}}
+	stats, err := Upsert(context.Background(), conn, "upsert", rows, []string{"id"}, UpsertOptions{})
+	require.NoError(t, err)
+	require.Equal(t, UpsertStats{Inserted: 2}, stats)
+
+	rows = []upsertRow{{2, "b2", 20}, {3, "c", 3}}
+	stats, err = Upsert(context.Background(), conn, "upsert", rows, []string{"ID"}, UpsertOptions{})
+	require.NoError(t, err)
+	require.Equal(t, UpsertStats{Inserted: 1, Updated: 1}, stats)
+
+	var name string
+	var score float64
+	require.NoError(t, conn.QueryRowContext(context.Background(), `SELECT name, score FROM upsert WHERE id = 2`).Scan(&name, &score))
+	require.Equal(t, "b2", name)
+	require.Equal(t, float64(20), score)
+
+	// Only update the score.
+	rows = []upsertRow{{1, "ignored", 10}}
+	stats, err = Upsert(context.Background(), conn, "upsert", rows, []string{"id"}, UpsertOptions{UpdateColumns: []string{"score"}})
+	require.NoError(t, err)
+	require.Equal(t, UpsertStats{Updated: 1}, stats)
+	require.NoError(t, conn.QueryRowContext(context.Background(), `SELECT name, score FROM upsert WHERE id = 1`).Scan(&name, &score))
+	require.Equal(t, "a", name)
+	require.Equal(t, float64(10), score)
+
+	// Skip existing rows.
+	rows = []upsertRow{{1, "skipped", 0}, {4, "d", 4}}
+	stats, err = Upsert(context.Background(), conn, "upsert", rows, []string{"id"}, UpsertOptions{UpdateColumns: []string{}})
+	require.NoError(t, err)
+	require.Equal(t, UpsertStats{Inserted: 1}, stats)
+
+	var count int
+	require.NoError(t, conn.QueryRowContext(context.Background(), `SELECT count(*) FROM upsert`).Scan(&count))
+	require.Equal(t, 4, count)
+
+	// The staging tables have been dropped.
+	require.NoError(t, conn.QueryRowContext(context.Background(), `SELECT count(*) FROM duckdb_tables() WHERE temporary`).Scan(&count))
+	require.Equal(t, 0, count)
+}
+
+func TestErrUpsert(t *testing.T) {
+	db := openDbWrapper(t, ``)
+	defer closeDbWrapper(t, db)
+	conn := openConnWrapper(t, db, context.Background())
+	defer closeConnWrapper(t, conn)
+
+	_, err := conn.ExecContext(context.Background(), `CREATE TABLE upsert (id INTEGER PRIMARY KEY, name VARCHAR, score DOUBLE)`)
+	require.NoError(t, err)
+	rows := []upsertRow{{1, "a", 1}}
+
+	_, err = Upsert(context.Background(), conn, "upsert", rows, nil, UpsertOptions{})
+	testError(t, err, errUpsert.Error(), invalidInputErrMsg)
+
+	_, err = Upsert(context.Background(), conn, "upsert", rows, []string{"missing"}, UpsertOptions{})
+	testError(t, err, errUpsert.Error(), structFieldErrMsg)
+
+	_, err = Upsert(context.Background(), conn, "does_not_exist", rows, []string{"id"}, UpsertOptions{})
+	testError(t, err, errUpsert.Error(), "does_not_exist")
+}
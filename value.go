@@ -1,6 +1,14 @@
 package duckdb
 
 import (
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
 	"github.com/marcboeker/go-duckdb/mapping"
 )
 
@@ -56,3 +64,299 @@ func getValue(info TypeInfo, v mapping.Value) (any, error) {
 		return nil, unsupportedTypeError(typeToStringMap[t])
 	}
 }
+
+// createNestedValue creates a value of the logical type from the Go value v, including LIST, ARRAY, and STRUCT values.
+// Like the Appender, it unwraps v and its elements, e.g., it resolves driver.Valuer implementations.
+// The caller must destroy the value.
+func createNestedValue(logicalType mapping.LogicalType, v any) (mapping.Value, error) {
+	v, err := unwrapValue(v)
+	if err != nil {
+		return mapping.Value{}, err
+	}
+	if v == nil {
+		return mapping.CreateNullValue(), nil
+	}
+
+	t := Type(mapping.GetTypeId(logicalType))
+	switch t {
+	case TYPE_LIST, TYPE_ARRAY:
+		return createListValue(logicalType, t, v)
+	case TYPE_STRUCT:
+		return createStructValue(logicalType, v)
+	}
+	return createValue(t, v)
+}
+
+func createListValue(logicalType mapping.LogicalType, t Type, v any) (mapping.Value, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return mapping.Value{}, castError(reflect.TypeOf(v).String(), typeToStringMap[t])
+	}
+	if rv.Kind() == reflect.Slice && rv.IsNil() {
+		return mapping.CreateNullValue(), nil
+	}
+
+	var childType mapping.LogicalType
+	if t == TYPE_ARRAY {
+		if size := mapping.ArrayTypeArraySize(logicalType); rv.Len() != int(size) {
+			return mapping.Value{}, invalidInputError(strconv.Itoa(rv.Len()), strconv.Itoa(int(size)))
+		}
+		childType = mapping.ArrayTypeChildType(logicalType)
+	} else {
+		childType = mapping.ListTypeChildType(logicalType)
+	}
+	defer mapping.DestroyLogicalType(&childType)
+
+	values := make([]mapping.Value, 0, rv.Len())
+	defer func() {
+		destroyValues(values)
+	}()
+	for i := 0; i < rv.Len(); i++ {
+		val, err := createNestedValue(childType, rv.Index(i).Interface())
+		if err != nil {
+			return mapping.Value{}, err
+		}
+		values = append(values, val)
+	}
+
+	var val mapping.Value
+	if t == TYPE_ARRAY {
+		val = mapping.CreateArrayValue(childType, values)
+	} else {
+		val = mapping.CreateListValue(childType, values)
+	}
+	if val.Ptr == nil {
+		return mapping.Value{}, castError(reflect.TypeOf(v).String(), typeToStringMap[t])
+	}
+	return val, nil
+}
+
+func createStructValue(logicalType mapping.LogicalType, v any) (mapping.Value, error) {
+	// Look up the fields by their names, like setStruct.
+	var lookup func(name string) (any, error)
+	if m, ok := v.(map[string]any); ok {
+		lookup = func(name string) (any, error) {
+			val, found := m[name]
+			if !found {
+				return nil, structFieldError("missing field", name)
+			}
+			return val, nil
+		}
+	} else {
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Struct {
+			return mapping.Value{}, castError(reflect.TypeOf(v).String(), reflect.Struct.String())
+		}
+		fields, err := structFieldsOf(rv.Type())
+		if err != nil {
+			return mapping.Value{}, err
+		}
+		lookup = func(name string) (any, error) {
+			index, found := fields.lookup(name)
+			if !found {
+				return nil, structFieldError("missing field", name)
+			}
+			return rv.FieldByIndex(index).Interface(), nil
+		}
+	}
+
+	childCount := mapping.StructTypeChildCount(logicalType)
+	values := make([]mapping.Value, 0, childCount)
+	defer func() {
+		destroyValues(values)
+	}()
+	for i := mapping.IdxT(0); i < childCount; i++ {
+		field, err := lookup(mapping.StructTypeChildName(logicalType, i))
+		if err != nil {
+			return mapping.Value{}, err
+		}
+		childType := mapping.StructTypeChildType(logicalType, i)
+		val, err := createNestedValue(childType, field)
+		mapping.DestroyLogicalType(&childType)
+		if err != nil {
+			return mapping.Value{}, err
+		}
+		values = append(values, val)
+	}
+
+	val := mapping.CreateStructValue(logicalType, values)
+	if val.Ptr == nil {
+		return mapping.Value{}, castError(reflect.TypeOf(v).String(), typeToStringMap[TYPE_STRUCT])
+	}
+	return val, nil
+}
+
+func destroyValues(values []mapping.Value) {
+	for i := range values {
+		mapping.DestroyValue(&values[i])
+	}
+}
+
+// createValue creates a value of the type t from the Go value v. The caller must destroy the value.
+// It creates numeric values with the widest type of their kind, so that DuckDB checks their range when casting them.
+func createValue(t Type, v any) (mapping.Value, error) {
+	switch t {
+	case TYPE_BOOLEAN:
+		if b, ok := v.(bool); ok {
+			return mapping.CreateBool(b), nil
+		}
+	case TYPE_TINYINT, TYPE_SMALLINT, TYPE_INTEGER, TYPE_BIGINT, TYPE_UTINYINT, TYPE_USMALLINT, TYPE_UINTEGER,
+		TYPE_UBIGINT, TYPE_FLOAT, TYPE_DOUBLE, TYPE_HUGEINT, TYPE_DECIMAL:
+		return createNumericValue(t, v)
+	case TYPE_TIMESTAMP, TYPE_TIMESTAMP_TZ:
+		ts, err := getMappedTimestamp(t, v)
+		if err != nil {
+			return mapping.Value{}, err
+		}
+		if t == TYPE_TIMESTAMP_TZ {
+			return mapping.CreateTimestampTZ(*ts), nil
+		}
+		return mapping.CreateTimestamp(*ts), nil
+	case TYPE_DATE:
+		date, err := getMappedDate(v)
+		if err != nil {
+			return mapping.Value{}, err
+		}
+		return mapping.CreateDate(*date), nil
+	case TYPE_TIME:
+		ticks, err := getTimeTicks(v)
+		if err != nil {
+			return mapping.Value{}, err
+		}
+		return mapping.CreateTime(*mapping.NewTime(ticks)), nil
+	case TYPE_INTERVAL:
+		i, ok := v.(Interval)
+		if d, isDuration := v.(time.Duration); isDuration {
+			i, ok = IntervalFromDuration(d, DurationToMicros), true
+		}
+		if ok {
+			return mapping.CreateInterval(*mapping.NewInterval(i.Months, i.Days, i.Micros)), nil
+		}
+	case TYPE_UUID:
+		switch id := v.(type) {
+		case UUID:
+			return mapping.CreateUUID(*uuidToUHugeInt(id)), nil
+		case uuid.UUID:
+			return mapping.CreateUUID(*uuidToUHugeInt(UUID(id))), nil
+		}
+	case TYPE_BLOB:
+		if b, ok := v.([]byte); ok {
+			return mapping.CreateBlob(b), nil
+		}
+	case TYPE_VARCHAR, TYPE_ENUM:
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.String {
+			return mapping.CreateVarchar(rv.String()), nil
+		}
+	default:
+		return mapping.Value{}, unsupportedTypeError(typeToStringMap[t])
+	}
+	return mapping.Value{}, castError(reflect.TypeOf(v).String(), typeToStringMap[t])
+}
+
+// createExactValue creates a value of exactly the type of logicalType from the Go value v, unlike createValue,
+// which creates numeric values with the widest type of their kind. The caller must destroy the value.
+func createExactValue(logicalType mapping.LogicalType, v any) (mapping.Value, error) {
+	t := Type(mapping.GetTypeId(logicalType))
+	switch t {
+	case TYPE_TINYINT, TYPE_SMALLINT, TYPE_INTEGER, TYPE_BIGINT, TYPE_UTINYINT, TYPE_USMALLINT, TYPE_UINTEGER,
+		TYPE_UBIGINT, TYPE_FLOAT, TYPE_DOUBLE:
+	default:
+		return createNestedValue(logicalType, v)
+	}
+
+	rv := reflect.ValueOf(v)
+	var i int64
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i = rv.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if rv.Uint() > math.MaxInt64 {
+			if t == TYPE_UBIGINT {
+				return mapping.CreateUInt64(rv.Uint()), nil
+			}
+			return mapping.Value{}, castError(strconv.FormatUint(rv.Uint(), 10), typeToStringMap[t])
+		}
+		i = int64(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		switch t {
+		case TYPE_FLOAT:
+			return mapping.CreateFloat(float32(rv.Float())), nil
+		case TYPE_DOUBLE:
+			return mapping.CreateDouble(rv.Float()), nil
+		}
+		return mapping.Value{}, castError(reflect.TypeOf(v).String(), typeToStringMap[t])
+	default:
+		return mapping.Value{}, castError(reflect.TypeOf(v).String(), typeToStringMap[t])
+	}
+
+	inRange := func(minVal int64, maxVal int64) error {
+		if i < minVal || i > maxVal {
+			return conversionError(int(i), int(minVal), int(maxVal))
+		}
+		return nil
+	}
+	var err error
+	switch t {
+	case TYPE_TINYINT:
+		if err = inRange(math.MinInt8, math.MaxInt8); err == nil {
+			return mapping.CreateInt8(int8(i)), nil
+		}
+	case TYPE_SMALLINT:
+		if err = inRange(math.MinInt16, math.MaxInt16); err == nil {
+			return mapping.CreateInt16(int16(i)), nil
+		}
+	case TYPE_INTEGER:
+		if err = inRange(math.MinInt32, math.MaxInt32); err == nil {
+			return mapping.CreateInt32(int32(i)), nil
+		}
+	case TYPE_BIGINT:
+		return mapping.CreateInt64(i), nil
+	case TYPE_UTINYINT:
+		if err = inRange(0, math.MaxUint8); err == nil {
+			return mapping.CreateUInt8(uint8(i)), nil
+		}
+	case TYPE_USMALLINT:
+		if err = inRange(0, math.MaxUint16); err == nil {
+			return mapping.CreateUInt16(uint16(i)), nil
+		}
+	case TYPE_UINTEGER:
+		if err = inRange(0, math.MaxUint32); err == nil {
+			return mapping.CreateUInt32(uint32(i)), nil
+		}
+	case TYPE_UBIGINT:
+		if err = inRange(0, math.MaxInt64); err == nil {
+			return mapping.CreateUInt64(uint64(i)), nil
+		}
+	case TYPE_FLOAT:
+		return mapping.CreateFloat(float32(i)), nil
+	case TYPE_DOUBLE:
+		return mapping.CreateDouble(float64(i)), nil
+	}
+	return mapping.Value{}, err
+}
+
+func createNumericValue(t Type, v any) (mapping.Value, error) {
+	switch n := v.(type) {
+	case *big.Int:
+		if n != nil {
+			hugeInt, err := hugeIntFromNative(n)
+			if err != nil {
+				return mapping.Value{}, err
+			}
+			return mapping.CreateHugeInt(*hugeInt), nil
+		}
+	case Decimal:
+		return mapping.CreateVarchar(n.String()), nil
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return mapping.CreateInt64(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return mapping.CreateUInt64(rv.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return mapping.CreateDouble(rv.Float()), nil
+	}
+	return mapping.Value{}, castError(reflect.TypeOf(v).String(), typeToStringMap[t])
+}
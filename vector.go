@@ -2,6 +2,7 @@ package duckdb
 
 import (
 	"reflect"
+	"time"
 	"unsafe"
 
 	"github.com/marcboeker/go-duckdb/mapping"
@@ -21,11 +22,36 @@ type vector struct {
 	setFn fnSetVectorValue
 	// The child vectors of nested data types.
 	childVectors []vector
+	// The number of bytes of the VARCHAR and BLOB values written to the vector.
+	varBytes int
+	// isJSON is true if the vector holds JSON values.
+	isJSON bool
+	// isGeometry is true if the vector holds GEOMETRY values of the spatial extension.
+	isGeometry bool
+	// isINET is true if the vector holds INET values of the inet extension.
+	isINET bool
 
 	// The vector's type information.
 	vectorTypeInfo
 }
 
+// writtenBytes returns the number of bytes of the VARCHAR and BLOB values written to the vector and its children.
+func (vec *vector) writtenBytes() int {
+	n := vec.varBytes
+	for i := range vec.childVectors {
+		n += vec.childVectors[i].writtenBytes()
+	}
+	return n
+}
+
+// resetWrittenBytes resets the number of written bytes of the vector and its children.
+func (vec *vector) resetWrittenBytes() {
+	vec.varBytes = 0
+	for i := range vec.childVectors {
+		vec.childVectors[i].resetWrittenBytes()
+	}
+}
+
 func (*vector) canNil(val reflect.Value) bool {
 	switch val.Kind() {
 	case reflect.Chan, reflect.Func, reflect.Map, reflect.Pointer,
@@ -48,6 +74,11 @@ func (vec *vector) init(logicalType mapping.LogicalType, colIdx int) error {
 	case aliasJSON:
 		vec.initJSON()
 		return nil
+	case aliasGeometry:
+		vec.initGeometry()
+		return nil
+	case aliasINET:
+		return vec.initINET(logicalType, colIdx)
 	}
 
 	switch t {
@@ -95,6 +126,8 @@ func (vec *vector) init(logicalType mapping.LogicalType, colIdx int) error {
 		return vec.initStruct(logicalType, colIdx)
 	case TYPE_MAP:
 		return vec.initMap(logicalType, colIdx)
+	case TYPE_UNION:
+		return vec.initUnion(logicalType, colIdx)
 	case TYPE_ARRAY:
 		return vec.initArray(logicalType, colIdx)
 	case TYPE_UUID:
@@ -135,7 +168,7 @@ func (vec *vector) getChildVectors(v mapping.Vector, writable bool) {
 	case TYPE_LIST, TYPE_MAP:
 		child := mapping.ListVectorGetChild(v)
 		vec.childVectors[0].initVectors(child, writable)
-	case TYPE_STRUCT:
+	case TYPE_STRUCT, TYPE_UNION:
 		for i := 0; i < len(vec.childVectors); i++ {
 			child := mapping.StructVectorGetChild(v, mapping.IdxT(i))
 			vec.childVectors[i].initVectors(child, writable)
@@ -297,6 +330,18 @@ func (vec *vector) initJSON() {
 		return setJSON(vec, rowIdx, val)
 	}
 	vec.Type = TYPE_VARCHAR
+	vec.isJSON = true
+}
+
+func (vec *vector) initGeometry() {
+	vec.initBytes(TYPE_BLOB)
+	vec.getFn = func(vec *vector, rowIdx mapping.IdxT) any {
+		if vec.getNull(rowIdx) {
+			return nil
+		}
+		return vec.getGeometry(rowIdx)
+	}
+	vec.isGeometry = true
 }
 
 func (vec *vector) initDecimal(logicalType mapping.LogicalType, colIdx int) error {
@@ -330,13 +375,7 @@ func (vec *vector) initDecimal(logicalType mapping.LogicalType, colIdx int) erro
 
 func (vec *vector) initEnum(logicalType mapping.LogicalType, colIdx int) error {
 	// Initialize the dictionary.
-	dictSize := mapping.EnumDictionarySize(logicalType)
-	vec.dict = make(map[string]uint32)
-
-	for i := uint32(0); i < dictSize; i++ {
-		str := mapping.EnumDictionaryValue(logicalType, mapping.IdxT(i))
-		vec.dict[str] = i
-	}
+	vec.dict = enumDictionary(logicalType)
 
 	t := Type(mapping.EnumInternalType(logicalType))
 	switch t {
@@ -363,6 +402,17 @@ func (vec *vector) initEnum(logicalType mapping.LogicalType, colIdx int) error {
 	return nil
 }
 
+// enumDictionary returns the dictionary indexes of an ENUM type's values.
+func enumDictionary(logicalType mapping.LogicalType) map[string]uint32 {
+	dictSize := mapping.EnumDictionarySize(logicalType)
+	dict := make(map[string]uint32, dictSize)
+	for i := uint32(0); i < dictSize; i++ {
+		str := mapping.EnumDictionaryValue(logicalType, mapping.IdxT(i))
+		dict[str] = i
+	}
+	return dict
+}
+
 func (vec *vector) initList(logicalType mapping.LogicalType, colIdx int) error {
 	// Get the child vector type.
 	childType := mapping.ListTypeChildType(logicalType)
@@ -434,6 +484,132 @@ func (vec *vector) initStruct(logicalType mapping.LogicalType, colIdx int) error
 	return nil
 }
 
+func (vec *vector) initUnion(logicalType mapping.LogicalType, colIdx int) error {
+	// A UNION is a STRUCT holding the UTINYINT tag of the member, followed by a child per member.
+	// The struct entries hold the member names.
+	memberCount := mapping.UnionTypeMemberCount(logicalType)
+	vec.childVectors = make([]vector, memberCount+1)
+	vec.structEntries = make([]StructEntry, 0, memberCount)
+	initNumeric[uint8](&vec.childVectors[0], TYPE_UTINYINT)
+
+	// Recurse into the members.
+	for i := mapping.IdxT(0); i < memberCount; i++ {
+		entry, err := NewStructEntry(nil, mapping.UnionTypeMemberName(logicalType, i))
+		if err != nil {
+			return err
+		}
+		vec.structEntries = append(vec.structEntries, entry)
+
+		memberType := mapping.UnionTypeMemberType(logicalType, i)
+		err = vec.childVectors[i+1].init(memberType, colIdx)
+		mapping.DestroyLogicalType(&memberType)
+		if err != nil {
+			return err
+		}
+	}
+
+	vec.getFn = func(vec *vector, rowIdx mapping.IdxT) any {
+		if vec.getNull(rowIdx) {
+			return nil
+		}
+		return vec.getUnion(rowIdx)
+	}
+	vec.setFn = func(vec *vector, rowIdx mapping.IdxT, val any) error {
+		if val == nil {
+			vec.setNull(rowIdx)
+			return nil
+		}
+		return setUnion(vec, rowIdx, val)
+	}
+	vec.Type = TYPE_UNION
+	return nil
+}
+
+// orderMaps makes the MAP values of the vector and its children return OrderedMap values instead of Map values.
+func (vec *vector) orderMaps() {
+	for i := range vec.childVectors {
+		vec.childVectors[i].orderMaps()
+	}
+	if vec.Type != TYPE_MAP {
+		return
+	}
+	vec.getFn = func(vec *vector, rowIdx mapping.IdxT) any {
+		if vec.getNull(rowIdx) {
+			return nil
+		}
+		return vec.getOrderedMap(rowIdx)
+	}
+}
+
+// inLocation makes the TIMESTAMP and TIMESTAMP WITH TIME ZONE values of the vector and its children
+// return time.Time values in the location.
+func (vec *vector) inLocation(loc *time.Location) {
+	for i := range vec.childVectors {
+		vec.childVectors[i].inLocation(loc)
+	}
+	switch vec.Type {
+	case TYPE_TIMESTAMP, TYPE_TIMESTAMP_S, TYPE_TIMESTAMP_MS, TYPE_TIMESTAMP_NS, TYPE_TIMESTAMP_TZ:
+	default:
+		return
+	}
+	getFn := vec.getFn
+	vec.getFn = func(vec *vector, rowIdx mapping.IdxT) any {
+		val := getFn(vec, rowIdx)
+		if ts, ok := val.(time.Time); ok {
+			return ts.In(loc)
+		}
+		return val
+	}
+}
+
+// keepTimeTZOffsets makes the TIMETZ values of the vector and its children return time.Time values
+// in a fixed zone with their stored UTC offset, instead of normalizing them to UTC.
+func (vec *vector) keepTimeTZOffsets() {
+	for i := range vec.childVectors {
+		vec.childVectors[i].keepTimeTZOffsets()
+	}
+	if vec.Type != TYPE_TIME_TZ {
+		return
+	}
+	vec.getFn = func(vec *vector, rowIdx mapping.IdxT) any {
+		if vec.getNull(rowIdx) {
+			return nil
+		}
+		ti := getPrimitive[mapping.TimeTZ](vec, rowIdx)
+		return getTimeTZWithOffset(&ti)
+	}
+}
+
+// handleNonFiniteFloats makes the FLOAT and DOUBLE values of the vector and its children follow the policy.
+// With NonFiniteFloatsError, it passes the errors of non-finite values to report.
+func (vec *vector) handleNonFiniteFloats(policy NonFiniteFloats, report func(err error)) {
+	for i := range vec.childVectors {
+		vec.childVectors[i].handleNonFiniteFloats(policy, report)
+	}
+	if vec.Type != TYPE_FLOAT && vec.Type != TYPE_DOUBLE {
+		return
+	}
+	getFn := vec.getFn
+	vec.getFn = func(vec *vector, rowIdx mapping.IdxT) any {
+		val := getFn(vec, rowIdx)
+		var err error
+		switch v := val.(type) {
+		case float32:
+			err = nonFiniteFloatError(float64(v))
+		case float64:
+			err = nonFiniteFloatError(v)
+		}
+		if err == nil {
+			return val
+		}
+		if policy == NonFiniteFloatsToNull {
+			return nil
+		}
+		report(err)
+		return val
+	}
+}
+
 func (vec *vector) initMap(logicalType mapping.LogicalType, colIdx int) error {
 	// A MAP is a LIST of STRUCT values. Each STRUCT holds two children: a key and a value.
 
@@ -530,6 +706,9 @@ func (vec *vector) initSQLNull() {
 		return nil
 	}
 	vec.setFn = func(vec *vector, rowIdx mapping.IdxT, val any) error {
+		if val == nil {
+			return nil
+		}
 		return errSetSQLNULLValue
 	}
 	vec.Type = TYPE_SQLNULL
@@ -3,7 +3,10 @@ package duckdb
 import (
 	"encoding/json"
 	"math/big"
+	"net/netip"
+	"strings"
 	"time"
+	"unsafe"
 
 	"github.com/marcboeker/go-duckdb/mapping"
 )
@@ -12,6 +15,9 @@ import (
 type fnGetVectorValue func(vec *vector, rowIdx mapping.IdxT) any
 
 func (vec *vector) getNull(rowIdx mapping.IdxT) bool {
+	if vec.Type == TYPE_SQLNULL {
+		return true
+	}
 	if vec.maskPtr == nil {
 		return false
 	}
@@ -67,6 +73,19 @@ func (vec *vector) getTime(rowIdx mapping.IdxT) time.Time {
 	return time.Time{}
 }
 
+// getTimeOfDay returns a TIME or TIME WITH TIME ZONE value as the duration since midnight.
+// Like getTime, it normalizes TIME WITH TIME ZONE values to UTC.
+func (vec *vector) getTimeOfDay(rowIdx mapping.IdxT) time.Duration {
+	if vec.Type == TYPE_TIME {
+		val := getPrimitive[mapping.Time](vec, rowIdx)
+		return time.Duration(mapping.TimeMembers(&val)) * time.Microsecond
+	}
+	ti := vec.getTime(rowIdx)
+	hour, minute, sec := ti.Clock()
+	return time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute +
+		time.Duration(sec)*time.Second + time.Duration(ti.Nanosecond())
+}
+
 func getTime(ti *mapping.Time) time.Time {
 	micros := mapping.TimeMembers(ti)
 	unix := time.UnixMicro(micros).UTC()
@@ -74,6 +93,11 @@ func getTime(ti *mapping.Time) time.Time {
 }
 
 func getTimeTZ(ti *mapping.TimeTZ) time.Time {
+	return getTimeTZWithOffset(ti).UTC()
+}
+
+// getTimeTZWithOffset returns a TIMETZ value in a fixed zone with the value's UTC offset.
+func getTimeTZWithOffset(ti *mapping.TimeTZ) time.Time {
 	timeTZStruct := mapping.FromTimeTZ(*ti)
 	timeStruct, offset := mapping.TimeTZStructMembers(&timeTZStruct)
 
@@ -81,7 +105,7 @@ func getTimeTZ(ti *mapping.TimeTZ) time.Time {
 	hour, minute, sec, micro := mapping.TimeStructMembers(&timeStruct)
 	nanos := int(micro) * 1000
 	loc := time.FixedZone("", int(offset))
-	return time.Date(1, time.January, 1, int(hour), int(minute), int(sec), nanos, loc).UTC()
+	return time.Date(1, time.January, 1, int(hour), int(minute), int(sec), nanos, loc)
 }
 
 func (vec *vector) getInterval(rowIdx mapping.IdxT) Interval {
@@ -112,6 +136,24 @@ func (vec *vector) getBytes(rowIdx mapping.IdxT) any {
 	return []byte(str)
 }
 
+// getRawBytes returns the bytes of a VARCHAR or BLOB value without copying them.
+// They point into the vector's memory, or into DuckDB's memory of the vector's strings,
+// so they are only valid as long as the vector's data chunk.
+func (vec *vector) getRawBytes(rowIdx mapping.IdxT) []byte {
+	// A duckdb_string_t holds its uint32 length, followed by either the inlined string of up to 12 bytes,
+	// or a 4-byte prefix and a pointer to the string.
+	ptr := unsafe.Add(vec.dataPtr, uintptr(rowIdx)*unsafe.Sizeof(mapping.StringT{}))
+	length := *(*uint32)(ptr)
+	if length <= stringInlineLength {
+		return unsafe.Slice((*byte)(unsafe.Add(ptr, 4)), length)
+	}
+	data := *(*unsafe.Pointer)(unsafe.Add(ptr, 8))
+	return unsafe.Slice((*byte)(data), length)
+}
+
+// stringInlineLength is the maximum length of a string that a duckdb_string_t inlines.
+const stringInlineLength = 12
+
 func (vec *vector) getJSON(rowIdx mapping.IdxT) any {
 	bytes := vec.getBytes(rowIdx).(string)
 	var value any
@@ -119,6 +161,17 @@ func (vec *vector) getJSON(rowIdx mapping.IdxT) any {
 	return value
 }
 
+// getGeometry returns a GEOMETRY value as Geometry.
+// It returns the value in the internal format of the spatial extension, if it cannot convert it.
+func (vec *vector) getGeometry(rowIdx mapping.IdxT) any {
+	b := vec.getBytes(rowIdx).([]byte)
+	geometry, err := geometryFromSpatial(b)
+	if err != nil {
+		return b
+	}
+	return geometry
+}
+
 func (vec *vector) getDecimal(rowIdx mapping.IdxT) Decimal {
 	var val *big.Int
 	switch vec.internalType {
@@ -172,6 +225,15 @@ func (vec *vector) getStruct(rowIdx mapping.IdxT) map[string]any {
 	return m
 }
 
+func (vec *vector) getUnion(rowIdx mapping.IdxT) Union[any] {
+	tag := getPrimitive[uint8](&vec.childVectors[0], rowIdx)
+	member := &vec.childVectors[tag+1]
+	return Union[any]{
+		MemberName: vec.structEntries[tag].Name(),
+		Value:      member.getFn(member, rowIdx),
+	}
+}
+
 func (vec *vector) getMap(rowIdx mapping.IdxT) Map {
 	list := vec.getList(rowIdx)
 
@@ -185,6 +247,17 @@ func (vec *vector) getMap(rowIdx mapping.IdxT) Map {
 	return m
 }
 
+func (vec *vector) getOrderedMap(rowIdx mapping.IdxT) OrderedMap {
+	list := vec.getList(rowIdx)
+
+	m := make(OrderedMap, 0, len(list))
+	for i := 0; i < len(list); i++ {
+		mapItem := list[i].(map[string]any)
+		m = append(m, MapEntry{Key: mapItem[mapKeysField()], Value: mapItem[mapValuesField()]})
+	}
+	return m
+}
+
 func (vec *vector) getArray(rowIdx mapping.IdxT) []any {
 	length := uint64(vec.arrayLength)
 	return vec.getSliceChild(uint64(rowIdx)*length, length)
@@ -201,3 +274,140 @@ func (vec *vector) getSliceChild(offset uint64, length uint64) []any {
 	}
 	return slice
 }
+
+// getCastText returns the text of a value like DuckDB's VARCHAR cast, and NULL for NULL values.
+// DuckDB casts the primitive values. Like DuckDB, it joins the texts of nested values without quoting them.
+func (vec *vector) getCastText(rowIdx mapping.IdxT) string {
+	if vec.Type == TYPE_SQLNULL || vec.getNull(rowIdx) {
+		return "NULL"
+	}
+
+	switch {
+	case vec.isJSON:
+		return vec.getBytes(rowIdx).(string)
+	case vec.isGeometry:
+		if geometry, ok := vec.getGeometry(rowIdx).(Geometry); ok {
+			return geometry.WKT()
+		}
+	case vec.isINET:
+		if prefix, ok := vec.getINET(rowIdx).(netip.Prefix); ok {
+			return inetString(prefix)
+		}
+	}
+
+	var b strings.Builder
+	switch vec.Type {
+	case TYPE_VARCHAR:
+		return vec.getBytes(rowIdx).(string)
+	case TYPE_ENUM:
+		return vec.getEnum(rowIdx)
+	case TYPE_LIST, TYPE_ARRAY:
+		var offset, length uint64
+		if vec.Type == TYPE_LIST {
+			entry := getPrimitive[mapping.ListEntry](vec, rowIdx)
+			offset, length = mapping.ListEntryMembers(&entry)
+		} else {
+			length = uint64(vec.arrayLength)
+			offset = uint64(rowIdx) * length
+		}
+		child := &vec.childVectors[0]
+		b.WriteByte('[')
+		for i := uint64(0); i < length; i++ {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(child.getCastText(mapping.IdxT(offset + i)))
+		}
+		b.WriteByte(']')
+	case TYPE_STRUCT:
+		b.WriteByte('{')
+		for i := range vec.childVectors {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString("'" + vec.structEntries[i].Name() + "': ")
+			b.WriteString(vec.childVectors[i].getCastText(rowIdx))
+		}
+		b.WriteByte('}')
+	case TYPE_MAP:
+		entry := getPrimitive[mapping.ListEntry](vec, rowIdx)
+		offset, length := mapping.ListEntryMembers(&entry)
+		entries := &vec.childVectors[0]
+		b.WriteByte('{')
+		for i := uint64(0); i < length; i++ {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(entries.childVectors[0].getCastText(mapping.IdxT(offset + i)))
+			b.WriteByte('=')
+			b.WriteString(entries.childVectors[1].getCastText(mapping.IdxT(offset + i)))
+		}
+		b.WriteByte('}')
+	case TYPE_UNION:
+		tag := getPrimitive[uint8](&vec.childVectors[0], rowIdx)
+		return vec.childVectors[tag+1].getCastText(rowIdx)
+	default:
+		v := vec.createPrimitiveValue(rowIdx)
+		defer mapping.DestroyValue(&v)
+		return mapping.GetVarchar(v)
+	}
+	return b.String()
+}
+
+// createPrimitiveValue creates a value from the vector's data. The caller must destroy the value.
+func (vec *vector) createPrimitiveValue(rowIdx mapping.IdxT) mapping.Value {
+	switch vec.Type {
+	case TYPE_BOOLEAN:
+		return mapping.CreateBool(getPrimitive[bool](vec, rowIdx))
+	case TYPE_TINYINT:
+		return mapping.CreateInt8(getPrimitive[int8](vec, rowIdx))
+	case TYPE_SMALLINT:
+		return mapping.CreateInt16(getPrimitive[int16](vec, rowIdx))
+	case TYPE_INTEGER:
+		return mapping.CreateInt32(getPrimitive[int32](vec, rowIdx))
+	case TYPE_BIGINT:
+		return mapping.CreateInt64(getPrimitive[int64](vec, rowIdx))
+	case TYPE_UTINYINT:
+		return mapping.CreateUInt8(getPrimitive[uint8](vec, rowIdx))
+	case TYPE_USMALLINT:
+		return mapping.CreateUInt16(getPrimitive[uint16](vec, rowIdx))
+	case TYPE_UINTEGER:
+		return mapping.CreateUInt32(getPrimitive[uint32](vec, rowIdx))
+	case TYPE_UBIGINT:
+		return mapping.CreateUInt64(getPrimitive[uint64](vec, rowIdx))
+	case TYPE_FLOAT:
+		return mapping.CreateFloat(getPrimitive[float32](vec, rowIdx))
+	case TYPE_DOUBLE:
+		return mapping.CreateDouble(getPrimitive[float64](vec, rowIdx))
+	case TYPE_HUGEINT:
+		return mapping.CreateHugeInt(getPrimitive[mapping.HugeInt](vec, rowIdx))
+	case TYPE_DECIMAL:
+		// A DECIMAL value fits into a HUGEINT.
+		hugeInt, _ := hugeIntFromNative(vec.getDecimal(rowIdx).Value)
+		return mapping.CreateDecimal(*mapping.NewDecimal(vec.decimalWidth, vec.decimalScale, *hugeInt))
+	case TYPE_TIMESTAMP:
+		return mapping.CreateTimestamp(getPrimitive[mapping.Timestamp](vec, rowIdx))
+	case TYPE_TIMESTAMP_TZ:
+		return mapping.CreateTimestampTZ(getPrimitive[mapping.Timestamp](vec, rowIdx))
+	case TYPE_TIMESTAMP_S:
+		return mapping.CreateTimestampS(getPrimitive[mapping.TimestampS](vec, rowIdx))
+	case TYPE_TIMESTAMP_MS:
+		return mapping.CreateTimestampMS(getPrimitive[mapping.TimestampMS](vec, rowIdx))
+	case TYPE_TIMESTAMP_NS:
+		return mapping.CreateTimestampNS(getPrimitive[mapping.TimestampNS](vec, rowIdx))
+	case TYPE_DATE:
+		return mapping.CreateDate(getPrimitive[mapping.Date](vec, rowIdx))
+	case TYPE_TIME:
+		return mapping.CreateTime(getPrimitive[mapping.Time](vec, rowIdx))
+	case TYPE_TIME_TZ:
+		return mapping.CreateTimeTZValue(getPrimitive[mapping.TimeTZ](vec, rowIdx))
+	case TYPE_INTERVAL:
+		return mapping.CreateInterval(getPrimitive[mapping.Interval](vec, rowIdx))
+	case TYPE_UUID:
+		hugeInt := getPrimitive[mapping.HugeInt](vec, rowIdx)
+		return mapping.CreateUUID(*uuidToUHugeInt(UUID(hugeIntToUUID(&hugeInt))))
+	case TYPE_BLOB:
+		return mapping.CreateBlob(vec.getBytes(rowIdx).([]byte))
+	}
+	return mapping.CreateNullValue()
+}
@@ -1,11 +1,20 @@
 package duckdb
 
 import (
+	"database/sql"
+	"database/sql/driver"
 	"encoding/json"
+	"fmt"
+	"math"
 	"math/big"
 	"reflect"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unsafe"
 
+	"github.com/google/uuid"
 	"github.com/marcboeker/go-duckdb/mapping"
 )
 
@@ -17,18 +26,197 @@ type fnSetVectorValue func(vec *vector, rowIdx mapping.IdxT, val any) error
 
 func (vec *vector) setNull(rowIdx mapping.IdxT) {
 	mapping.ValiditySetRowInvalid(vec.maskPtr, rowIdx)
-	if vec.Type == TYPE_STRUCT {
+	if vec.Type == TYPE_STRUCT || vec.Type == TYPE_UNION {
 		for i := 0; i < len(vec.childVectors); i++ {
 			vec.childVectors[i].setNull(rowIdx)
 		}
 	}
 }
 
+// setValid marks a row as not NULL, e.g., to reuse the row of a rejected row.
+func (vec *vector) setValid(rowIdx mapping.IdxT) {
+	mapping.ValiditySetRowValid(vec.maskPtr, rowIdx)
+	if vec.Type == TYPE_STRUCT || vec.Type == TYPE_UNION {
+		for i := 0; i < len(vec.childVectors); i++ {
+			vec.childVectors[i].setValid(rowIdx)
+		}
+	}
+}
+
+// setNullRange sets count rows to NULL, starting at rowIdx, by clearing their bits in the validity mask.
+func (vec *vector) setNullRange(rowIdx mapping.IdxT, count int) {
+	if count <= 0 {
+		return
+	}
+	from := uint64(rowIdx)
+	to := from + uint64(count)
+	mask := unsafe.Slice((*uint64)(vec.maskPtr), (to+63)/64)
+	for i := from; i < to; {
+		word, bit := i/64, i%64
+		if bit == 0 && to-i >= 64 {
+			mask[word] = 0
+			i += 64
+			continue
+		}
+		mask[word] &^= 1 << bit
+		i++
+	}
+
+	if vec.Type == TYPE_STRUCT || vec.Type == TYPE_UNION {
+		for i := 0; i < len(vec.childVectors); i++ {
+			vec.childVectors[i].setNullRange(rowIdx, count)
+		}
+	}
+}
+
 func setPrimitive[T any](vec *vector, rowIdx mapping.IdxT, v T) {
 	xs := (*[1 << 31]T)(vec.dataPtr)
 	xs[rowIdx] = v
 }
 
+// trustedSetter returns a setter writing values of the column's exact Go type without conversion,
+// e.g., int64 values to a BIGINT column. It falls back to the vector's setter for NULL values and other types.
+// It returns nil for columns without such a Go type.
+func trustedSetter(logicalType mapping.LogicalType) fnSetVectorValue {
+	if mapping.LogicalTypeGetAlias(logicalType) != "" {
+		return nil
+	}
+	switch Type(mapping.GetTypeId(logicalType)) {
+	case TYPE_BOOLEAN:
+		return setTrusted[bool]
+	case TYPE_TINYINT:
+		return setTrusted[int8]
+	case TYPE_SMALLINT:
+		return setTrusted[int16]
+	case TYPE_INTEGER:
+		return setTrusted[int32]
+	case TYPE_BIGINT:
+		return setTrusted[int64]
+	case TYPE_UTINYINT:
+		return setTrusted[uint8]
+	case TYPE_USMALLINT:
+		return setTrusted[uint16]
+	case TYPE_UINTEGER:
+		return setTrusted[uint32]
+	case TYPE_UBIGINT:
+		return setTrusted[uint64]
+	case TYPE_FLOAT:
+		return setTrusted[float32]
+	case TYPE_DOUBLE:
+		return setTrusted[float64]
+	case TYPE_VARCHAR, TYPE_BLOB:
+		return setTrustedBytes
+	}
+	return nil
+}
+
+func setTrusted[T any](vec *vector, rowIdx mapping.IdxT, val any) error {
+	if v, ok := val.(T); ok {
+		setPrimitive(vec, rowIdx, v)
+		return nil
+	}
+	return vec.setFn(vec, rowIdx, val)
+}
+
+func setTrustedBytes(vec *vector, rowIdx mapping.IdxT, val any) error {
+	switch v := val.(type) {
+	case string:
+		mapping.VectorAssignStringElement(vec.vec, rowIdx, v)
+		vec.varBytes += len(v)
+	case []byte:
+		mapping.VectorAssignStringElementLen(vec.vec, rowIdx, v)
+		vec.varBytes += len(v)
+	default:
+		return vec.setFn(vec, rowIdx, val)
+	}
+	return nil
+}
+
+// setColumn writes count values of a column slice, starting at offset, to the vector, starting at rowIdx.
+func setColumn(vec *vector, rowIdx int, column any, offset int, count int) error {
+	switch values := column.(type) {
+	case []bool:
+		return setColumnValues(vec, rowIdx, values[offset:offset+count])
+	case []int8:
+		return setColumnValues(vec, rowIdx, values[offset:offset+count])
+	case []int16:
+		return setColumnValues(vec, rowIdx, values[offset:offset+count])
+	case []int32:
+		return setColumnValues(vec, rowIdx, values[offset:offset+count])
+	case []int64:
+		return setColumnValues(vec, rowIdx, values[offset:offset+count])
+	case []uint8:
+		return setColumnValues(vec, rowIdx, values[offset:offset+count])
+	case []uint16:
+		return setColumnValues(vec, rowIdx, values[offset:offset+count])
+	case []uint32:
+		return setColumnValues(vec, rowIdx, values[offset:offset+count])
+	case []uint64:
+		return setColumnValues(vec, rowIdx, values[offset:offset+count])
+	case []float32:
+		return setColumnValues(vec, rowIdx, values[offset:offset+count])
+	case []float64:
+		return setColumnValues(vec, rowIdx, values[offset:offset+count])
+	}
+
+	// Fall back to writing each value with the vector's setter, which also handles NULL values.
+	v := reflect.ValueOf(column)
+	for i := 0; i < count; i++ {
+		val, err := unwrapValue(v.Index(offset + i).Interface())
+		if err != nil {
+			return err
+		}
+		if err = vec.setFn(vec, mapping.IdxT(rowIdx+i), val); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func setColumnValues[T any](vec *vector, rowIdx int, values []T) error {
+	if isPhysicalType[T](vec.Type) {
+		// The vector's data has the same memory layout as the values.
+		copy(unsafe.Slice((*T)(vec.dataPtr), rowIdx+len(values))[rowIdx:], values)
+		return nil
+	}
+	for i, v := range values {
+		if err := vec.setFn(vec, mapping.IdxT(rowIdx+i), v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isPhysicalType returns true, if T is the Go type of the values of a vector of type t.
+func isPhysicalType[T any](t Type) bool {
+	var v T
+	switch any(v).(type) {
+	case bool:
+		return t == TYPE_BOOLEAN
+	case int8:
+		return t == TYPE_TINYINT
+	case int16:
+		return t == TYPE_SMALLINT
+	case int32:
+		return t == TYPE_INTEGER
+	case int64:
+		return t == TYPE_BIGINT
+	case uint8:
+		return t == TYPE_UTINYINT
+	case uint16:
+		return t == TYPE_USMALLINT
+	case uint32:
+		return t == TYPE_UINTEGER
+	case uint64:
+		return t == TYPE_UBIGINT
+	case float32:
+		return t == TYPE_FLOAT
+	case float64:
+		return t == TYPE_DOUBLE
+	}
+	return false
+}
+
 func setNumeric[S any, T numericType](vec *vector, rowIdx mapping.IdxT, val S) error {
 	var fv T
 	switch v := any(val).(type) {
@@ -125,6 +313,8 @@ func setInterval[S any](vec *vector, rowIdx mapping.IdxT, val S) error {
 	switch v := any(val).(type) {
 	case Interval:
 		i = v
+	case time.Duration:
+		i = IntervalFromDuration(v, DurationToMicros)
 	default:
 		return castError(reflect.TypeOf(val).String(), reflect.TypeOf(i).String())
 	}
@@ -198,8 +388,14 @@ func setBytes[S any](vec *vector, rowIdx mapping.IdxT, val S) error {
 	switch v := any(val).(type) {
 	case string:
 		mapping.VectorAssignStringElement(vec.vec, rowIdx, v)
+		vec.varBytes += len(v)
 	case []byte:
 		mapping.VectorAssignStringElementLen(vec.vec, rowIdx, v)
+		vec.varBytes += len(v)
+	case uuid.UUID:
+		str := v.String()
+		mapping.VectorAssignStringElement(vec.vec, rowIdx, str)
+		vec.varBytes += len(str)
 	default:
 		return castError(reflect.TypeOf(val).String(), reflect.String.String())
 	}
@@ -207,49 +403,148 @@ func setBytes[S any](vec *vector, rowIdx mapping.IdxT, val S) error {
 }
 
 func setJSON[S any](vec *vector, rowIdx mapping.IdxT, val S) error {
-	bytes, err := json.Marshal(val)
-	if err != nil {
-		return err
+	var bytes []byte
+	var err error
+	switch v := any(val).(type) {
+	case json.RawMessage:
+		// Write raw and marshaled JSON as is, without re-encoding it.
+		bytes = v
+		if v == nil {
+			bytes = []byte("null")
+		}
+	case json.Marshaler:
+		if bytes, err = v.MarshalJSON(); err != nil {
+			return err
+		}
+	default:
+		if bytes, err = json.Marshal(val); err != nil {
+			return err
+		}
+		return setBytes(vec, rowIdx, bytes)
+	}
+
+	if !json.Valid(bytes) {
+		return invalidInputError(reflect.TypeOf(val).String(), "valid JSON")
 	}
 	return setBytes(vec, rowIdx, bytes)
 }
 
 func setDecimal[S any](vec *vector, rowIdx mapping.IdxT, val S) error {
+	v, err := unscaledDecimal(val, vec.decimalWidth, vec.decimalScale)
+	if err != nil {
+		return err
+	}
+
 	switch vec.internalType {
 	case TYPE_SMALLINT:
-		return setNumeric[S, int16](vec, rowIdx, val)
+		setPrimitive(vec, rowIdx, int16(v.Int64()))
 	case TYPE_INTEGER:
-		return setNumeric[S, int32](vec, rowIdx, val)
+		setPrimitive(vec, rowIdx, int32(v.Int64()))
 	case TYPE_BIGINT:
-		return setNumeric[S, int64](vec, rowIdx, val)
+		setPrimitive(vec, rowIdx, v.Int64())
 	case TYPE_HUGEINT:
-		return setHugeint(vec, rowIdx, val)
+		fv, err := hugeIntFromNative(v)
+		if err != nil {
+			return err
+		}
+		setPrimitive(vec, rowIdx, *fv)
 	}
 	return nil
 }
 
+// unscaledDecimal returns the unscaled value of a DECIMAL(width, scale) value.
+// It rounds values with more decimal places half away from zero,
+// and fails, if the value exceeds the width.
+// Integers, including *big.Int values, already are unscaled values, e.g., 1234 is 12.34 in a DECIMAL(4, 2) column.
+func unscaledDecimal[S any](val S, width uint8, scale uint8) (*big.Int, error) {
+	var r *big.Rat
+	var unscaled *big.Int
+
+	switch v := any(val).(type) {
+	case Decimal:
+		if v.Value != nil && v.Scale == scale {
+			unscaled = v.Value
+		} else if v.Value != nil {
+			r = new(big.Rat).SetFrac(v.Value, pow10(v.Scale))
+		}
+	case *big.Rat:
+		r = v
+	case *big.Float:
+		if v != nil {
+			r, _ = v.Rat(nil)
+		}
+	case *big.Int:
+		if v != nil {
+			unscaled = v
+		}
+	case string:
+		r, _ = new(big.Rat).SetString(v)
+	case int8, int16, int32, int64, int:
+		unscaled = big.NewInt(reflect.ValueOf(v).Int())
+	case uint8, uint16, uint32, uint64, uint:
+		unscaled = new(big.Int).SetUint64(reflect.ValueOf(v).Uint())
+	case float32:
+		if !math.IsInf(float64(v), 0) && !math.IsNaN(float64(v)) {
+			r = new(big.Rat).SetFloat64(float64(v))
+		}
+	case float64:
+		if !math.IsInf(v, 0) && !math.IsNaN(v) {
+			r = new(big.Rat).SetFloat64(v)
+		}
+	}
+
+	if unscaled == nil && r != nil {
+		num := new(big.Int).Mul(r.Num(), pow10(scale))
+		rem := new(big.Int)
+		unscaled, rem = new(big.Int).QuoRem(num, r.Denom(), rem)
+		// Round half away from zero.
+		if rem.Abs(rem).Lsh(rem, 1).Cmp(r.Denom()) >= 0 {
+			unscaled.Add(unscaled, big.NewInt(int64(num.Sign())))
+		}
+	}
+
+	expected := fmt.Sprintf("DECIMAL(%d, %d)", width, scale)
+	if unscaled == nil {
+		return nil, castError(reflect.TypeOf(val).String(), expected)
+	}
+	if new(big.Int).Abs(unscaled).Cmp(pow10(width)) >= 0 {
+		return nil, castError(fmt.Sprint(val), expected)
+	}
+	return unscaled, nil
+}
+
+// pow10 returns 10^n.
+func pow10(n uint8) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// setEnum writes an ENUM value, which is either a string of the dictionary, or the uint32 index of a dictionary value.
 func setEnum[S any](vec *vector, rowIdx mapping.IdxT, val S) error {
-	var str string
+	var idx uint32
 	switch v := any(val).(type) {
 	case string:
-		str = v
+		var ok bool
+		if idx, ok = vec.dict[v]; !ok {
+			return castError(reflect.TypeOf(val).String(), typeToStringMap[TYPE_ENUM])
+		}
+	case uint32:
+		if v >= uint32(len(vec.dict)) {
+			return invalidInputError(strconv.FormatUint(uint64(v), 10), fmt.Sprintf("ENUM index in [0, %d)", len(vec.dict)))
+		}
+		idx = v
 	default:
-		return castError(reflect.TypeOf(val).String(), reflect.TypeOf(str).String())
+		return castError(reflect.TypeOf(val).String(), reflect.String.String())
 	}
 
-	if v, ok := vec.dict[str]; ok {
-		switch vec.internalType {
-		case TYPE_UTINYINT:
-			return setNumeric[uint32, int8](vec, rowIdx, v)
-		case TYPE_SMALLINT:
-			return setNumeric[uint32, int16](vec, rowIdx, v)
-		case TYPE_INTEGER:
-			return setNumeric[uint32, int32](vec, rowIdx, v)
-		case TYPE_BIGINT:
-			return setNumeric[uint32, int64](vec, rowIdx, v)
-		}
-	} else {
-		return castError(reflect.TypeOf(val).String(), reflect.TypeOf(str).String())
+	switch vec.internalType {
+	case TYPE_UTINYINT:
+		setPrimitive(vec, rowIdx, uint8(idx))
+	case TYPE_USMALLINT:
+		setPrimitive(vec, rowIdx, uint16(idx))
+	case TYPE_UINTEGER:
+		setPrimitive(vec, rowIdx, idx)
+	case TYPE_UBIGINT:
+		setPrimitive(vec, rowIdx, uint64(idx))
 	}
 	return nil
 }
@@ -271,71 +566,165 @@ func setList[S any](vec *vector, rowIdx mapping.IdxT, val S) error {
 }
 
 func setStruct[S any](vec *vector, rowIdx mapping.IdxT, val S) error {
-	var m map[string]any
-	switch v := any(val).(type) {
-	case map[string]any:
-		m = v
-	default:
+	if m, ok := any(val).(map[string]any); ok {
 		// FIXME: Add support for all map types.
-
-		// Catch mismatching types.
-		goType := reflect.TypeOf(val)
-		if reflect.TypeOf(val).Kind() != reflect.Struct {
-			return castError(goType.String(), reflect.Struct.String())
-		}
-
-		m = make(map[string]any)
-		rv := reflect.ValueOf(val)
-		structType := rv.Type()
-
-		for i := 0; i < structType.NumField(); i++ {
-			if !rv.Field(i).CanInterface() {
-				continue
-			}
-			fieldName := structType.Field(i).Name
-			if name, ok := structType.Field(i).Tag.Lookup("db"); ok {
-				fieldName = name
+		for i := 0; i < len(vec.childVectors); i++ {
+			child := &vec.childVectors[i]
+			name := vec.structEntries[i].Name()
+			v, ok := m[name]
+			if !ok {
+				return structFieldError("missing field", name)
 			}
-			if _, ok := m[fieldName]; ok {
-				return duplicateNameError(fieldName)
+			if err := child.setFn(child, rowIdx, v); err != nil {
+				return err
 			}
-			m[fieldName] = rv.Field(i).Interface()
 		}
+		return nil
+	}
+
+	rv := reflect.ValueOf(val)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			vec.setNull(rowIdx)
+			return nil
+		}
+		rv = rv.Elem()
+	}
+
+	// Catch mismatching types.
+	if rv.Kind() != reflect.Struct {
+		return castError(reflect.TypeOf(val).String(), reflect.Struct.String())
+	}
+	fields, err := structFieldsOf(rv.Type())
+	if err != nil {
+		return err
 	}
 
 	for i := 0; i < len(vec.childVectors); i++ {
 		child := &vec.childVectors[i]
 		name := vec.structEntries[i].Name()
-		v, ok := m[name]
+		index, ok := fields.lookup(name)
 		if !ok {
 			return structFieldError("missing field", name)
 		}
-		err := child.setFn(child, rowIdx, v)
+		val, err := structColumnValue(rv.FieldByIndex(index))
 		if err != nil {
 			return err
 		}
+		if err = child.setFn(child, rowIdx, val); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
+// structFields maps the column names of a struct type's fields to the fields' indexes.
+type structFields struct {
+	byName       map[string][]int
+	byFoldedName map[string][]int
+}
+
+// structFieldsCache caches the structFields of each struct type appended to a STRUCT column.
+var structFieldsCache sync.Map
+
+func structFieldsOf(t reflect.Type) (*structFields, error) {
+	if cached, ok := structFieldsCache.Load(t); ok {
+		return cached.(*structFields), nil
+	}
+
+	fields := &structFields{
+		byName:       map[string][]int{},
+		byFoldedName: map[string][]int{},
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, ok := structFieldName(field)
+		if !ok {
+			continue
+		}
+		if _, ok = fields.byName[name]; ok {
+			return nil, duplicateNameError(name)
+		}
+		fields.byName[name] = field.Index
+		if _, ok = fields.byFoldedName[strings.ToLower(name)]; !ok {
+			fields.byFoldedName[strings.ToLower(name)] = field.Index
+		}
+	}
+
+	structFieldsCache.Store(t, fields)
+	return fields, nil
+}
+
+// lookup returns the index of the field with the column name. It prefers an exact match over a match ignoring case.
+func (fields *structFields) lookup(name string) ([]int, bool) {
+	if index, ok := fields.byName[name]; ok {
+		return index, true
+	}
+	index, ok := fields.byFoldedName[strings.ToLower(name)]
+	return index, ok
+}
+
 func setMap[S any](vec *vector, rowIdx mapping.IdxT, val S) error {
-	var m Map
+	var list []any
 	switch v := any(val).(type) {
 	case Map:
-		m = v
+		// Create a LIST of STRUCT values.
+		list = make([]any, 0, len(v))
+		for key, value := range v {
+			list = append(list, map[string]any{mapKeysField(): key, mapValuesField(): value})
+		}
+	case OrderedMap:
+		// Keep the order of the entries.
+		list = make([]any, len(v))
+		for i, entry := range v {
+			list[i] = map[string]any{mapKeysField(): entry.Key, mapValuesField(): entry.Value}
+		}
 	default:
-		return castError(reflect.TypeOf(val).String(), reflect.TypeOf(m).String())
+		return castError(reflect.TypeOf(val).String(), reflect.TypeOf(Map{}).String())
 	}
 
-	// Create a LIST of STRUCT values.
-	i := 0
-	list := make([]any, len(m))
-	for key, value := range m {
-		list[i] = map[string]any{mapKeysField(): key, mapValuesField(): value}
-		i++
+	return setList(vec, rowIdx, list)
+}
+
+// setUnion writes a UNION value, which is a Union[T], by setting the tag and the value of its member.
+// The other members are NULL.
+func setUnion[S any](vec *vector, rowIdx mapping.IdxT, val S) error {
+	i := -1
+	value := any(val)
+	if u, ok := value.(unionMember); ok {
+		var name string
+		name, value = u.unionMember()
+		for j, entry := range vec.structEntries {
+			if entry.Name() == name {
+				i = j
+				break
+			}
+		}
+		if i < 0 {
+			return invalidInputError(name, "UNION member")
+		}
+	} else {
+		// Infer the member from the type of the value.
+		names := make([]string, len(vec.structEntries))
+		types := make([]Type, len(vec.structEntries))
+		for j, entry := range vec.structEntries {
+			names[j] = entry.Name()
+			types[j] = vec.childVectors[j+1].Type
+		}
+		var err error
+		if i, err = inferUnionMember(names, types, value); err != nil {
+			return err
+		}
 	}
 
-	return setList(vec, rowIdx, list)
+	setPrimitive(&vec.childVectors[0], rowIdx, uint8(i))
+	for j := 1; j < len(vec.childVectors); j++ {
+		if j != i+1 {
+			vec.childVectors[j].setNull(rowIdx)
+		}
+	}
+	member := &vec.childVectors[i+1]
+	return member.setFn(member, rowIdx, value)
 }
 
 func setArray[S any](vec *vector, rowIdx mapping.IdxT, val S) error {
@@ -376,36 +765,141 @@ func extractSlice[S any](vec *vector, val S) ([]any, error) {
 	return s, nil
 }
 
+// unwrapValue returns the value to write for val. Typed nil pointers and invalid sql.Null values are NULL.
+// Valid sql.Null values and other pointers resolve to the value they hold,
+// except for the pointer types that the setters accept, e.g., *big.Int and json.Marshaler implementations.
+// Values implementing driver.Valuer resolve to the result of their Value method.
+func unwrapValue(val any) (any, error) {
+	switch v := val.(type) {
+	case nil, bool, int8, int16, int32, int64, int, uint8, uint16, uint32, uint64, uint,
+		float32, float64, string, []byte, time.Time:
+		return val, nil
+	case *big.Int:
+		return nullPointer(v)
+	case *big.Rat:
+		return nullPointer(v)
+	case *big.Float:
+		return nullPointer(v)
+	case *UUID:
+		return nullPointer(v)
+	case uuid.UUID:
+		// Skip the Valuer to avoid the string round trip.
+		return v, nil
+	case *uuid.UUID:
+		if v == nil {
+			return nil, nil
+		}
+		return *v, nil
+	case sql.NullString:
+		return nullValue(v.String, v.Valid), nil
+	case sql.NullInt64:
+		return nullValue(v.Int64, v.Valid), nil
+	case sql.NullInt32:
+		return nullValue(v.Int32, v.Valid), nil
+	case sql.NullInt16:
+		return nullValue(v.Int16, v.Valid), nil
+	case sql.NullByte:
+		return nullValue(v.Byte, v.Valid), nil
+	case sql.NullFloat64:
+		return nullValue(v.Float64, v.Valid), nil
+	case sql.NullBool:
+		return nullValue(v.Bool, v.Valid), nil
+	case sql.NullTime:
+		return nullValue(v.Time, v.Valid), nil
+	}
+
+	if v, ok, err := handlerValue(val); ok {
+		if err != nil {
+			return nil, err
+		}
+		return unwrapValue(v)
+	}
+
+	rv := reflect.ValueOf(val)
+	if rv.Kind() == reflect.Pointer && rv.IsNil() {
+		return nil, nil
+	}
+	// sql.Null[T] is generic, so match it by its type name.
+	// Its Valuer rejects unsigned integers exceeding math.MaxInt64.
+	if rv.Kind() == reflect.Struct && isSQLNull(rv.Type()) {
+		v, err := unwrapValue(rv.FieldByName("V").Interface())
+		return nullValue(v, rv.FieldByName("Valid").Bool()), err
+	}
+	if valuer, ok := val.(driver.Valuer); ok {
+		v, err := valuer.Value()
+		if err != nil {
+			return nil, err
+		}
+		return unwrapValue(v)
+	}
+	if str, ok, err := enumValue(val); ok {
+		return str, err
+	}
+
+	if rv.Kind() == reflect.Pointer {
+		if _, ok := val.(json.Marshaler); ok {
+			return val, nil
+		}
+		return unwrapValue(rv.Elem().Interface())
+	}
+	return val, nil
+}
+
+// isSQLNull returns true, if t is an instance of the generic sql.Null[T] type.
+func isSQLNull(t reflect.Type) bool {
+	return t.PkgPath() == "database/sql" && strings.HasPrefix(t.Name(), "Null[")
+}
+
+// nullPointer returns nil for a nil pointer, and the pointer otherwise.
+func nullPointer[T any](v *T) (any, error) {
+	if v == nil {
+		return nil, nil
+	}
+	return v, nil
+}
+
+func nullValue[T any](v T, valid bool) any {
+	if !valid {
+		return nil
+	}
+	return v
+}
+
 func setSliceChildren(vec *vector, s []any, offset mapping.IdxT) error {
 	childVector := &vec.childVectors[0]
 	for i, entry := range s {
-		rowIdx := mapping.IdxT(i) + offset
-		err := childVector.setFn(childVector, rowIdx, entry)
+		val, err := unwrapValue(entry)
 		if err != nil {
 			return err
 		}
+		rowIdx := mapping.IdxT(i) + offset
+		if err = childVector.setFn(childVector, rowIdx, val); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
 func setUUID[S any](vec *vector, rowIdx mapping.IdxT, val S) error {
-	var uuid UUID
+	var id UUID
 	switch v := any(val).(type) {
 	case UUID:
-		uuid = v
+		id = v
 	case *UUID:
-		uuid = *v
+		id = *v
+	case uuid.UUID:
+		id = UUID(v)
 	case []uint8:
 		if len(v) != uuidLength {
-			return castError(reflect.TypeOf(val).String(), reflect.TypeOf(uuid).String())
+			return castError(reflect.TypeOf(val).String(), reflect.TypeOf(id).String())
 		}
 		for i := 0; i < uuidLength; i++ {
-			uuid[i] = v[i]
+			id[i] = v[i]
 		}
 	default:
-		return castError(reflect.TypeOf(val).String(), reflect.TypeOf(uuid).String())
+		return castError(reflect.TypeOf(val).String(), reflect.TypeOf(id).String())
 	}
-	hi := uuidToHugeInt(uuid)
+	hi := uuidToHugeInt(id)
 	setPrimitive(vec, rowIdx, *hi)
 	return nil
 }
@@ -461,6 +955,8 @@ func setVectorVal[S any](vec *vector, rowIdx mapping.IdxT, val S) error {
 		return setList[S](vec, rowIdx, val)
 	case TYPE_STRUCT:
 		return setStruct[S](vec, rowIdx, val)
+	case TYPE_UNION:
+		return setUnion[S](vec, rowIdx, val)
 	case TYPE_MAP, TYPE_ARRAY:
 		// FIXME: Is this already supported? And tested?
 		return unsupportedTypeError(unsupportedTypeToStringMap[vec.Type])